@@ -2,60 +2,272 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"log"
+	"my-article-app/internal/apperr"
+	"my-article-app/internal/cache"
+	"my-article-app/internal/config"
 	"my-article-app/internal/database"
 	"my-article-app/internal/handlers"
 	"my-article-app/internal/repository"
 	"my-article-app/internal/usecase"
+	"my-article-app/internal/worker"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/logger"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+	"gorm.io/gorm"
 )
 
 func main() {
-	// 1. تهيئة اتصال قاعدة البيانات
-	db, err := database.InitGORMDB()
+	// 0. تحميل الإعدادات من الملف ومتغيرات البيئة وأعلام سطر الأوامر
+	cfg, err := config.Load(os.Args[1:])
+	if err != nil {
+		log.Fatalf("فشل تحميل الإعدادات: %v", err)
+	}
+
+	// 1-2. تهيئة التخزين (قاعدة البيانات أو الذاكرة) والـ Repositories (المستودعات)
+	repos, txManager, db, err := openStorage(cfg)
 	if err != nil {
 		log.Fatalf("فشل في تهيئة قاعدة البيانات: %v", err)
 	}
+	articleRepo := repos.Articles
+	authorRepo := repos.Authors
+	revisionRepo := repos.Revisions
+	slugHistoryRepo := repos.SlugHistory
 
-	// 2. تهيئة الـ Repositories (المستودعات)
-	articleRepo := repository.NewArticleRepository(db)
-	authorRepo := repository.NewAuthorRepository(db)
+	// 2.5. التخزين المؤقت للقراءات على Redis (اختياري) يغلّف المستودعات ومدير المعاملات
+	var readCache *cache.Cache
+	if cfg.Cache.Enabled() {
+		readCache, err = cache.New(cfg.Cache)
+		if err != nil {
+			log.Fatalf("فشل في تهيئة التخزين المؤقت: %v", err)
+		}
+		articleRepo = cache.NewArticleRepository(articleRepo, readCache)
+		authorRepo = cache.NewAuthorRepository(authorRepo, readCache)
+		txManager = cache.NewTxManager(txManager, readCache)
+	}
 	// 3. تهيئة الـ Use Cases (حالات الاستخدام)
 	// <-- التعديل هنا: تمرير authorRepo إلى ArticleUseCase
-	articleUseCase := usecase.NewArticleUseCase(articleRepo, authorRepo) 
-	authorUseCase := usecase.NewAuthorUseCase(authorRepo)
+	articleUseCase := usecase.NewArticleUseCase(articleRepo, authorRepo, revisionRepo, slugHistoryRepo, txManager)
+	authorUseCase := usecase.NewAuthorUseCase(authorRepo, txManager)
+	tagUseCase := usecase.NewTagUseCase(repos.Tags, txManager)
+	categoryUseCase := usecase.NewCategoryUseCase(repos.Categories, txManager)
+	commentUseCase := usecase.NewCommentUseCase(repos.Comments, articleRepo, txManager, cfg.Comments.EditWindow)
 
 	// 4. تهيئة الـ Handlers (المعالجات) - استخدام Use Cases
 	articleHandler := handlers.NewArticleHandler(articleUseCase)
 	authorHandler := handlers.NewAuthorHandler(authorUseCase)
+	tagHandler := handlers.NewTagHandler(tagUseCase)
+	categoryHandler := handlers.NewCategoryHandler(categoryUseCase)
+	commentHandler := handlers.NewCommentHandler(commentUseCase)
+
+	app := fiber.New(fiber.Config{
+		ReadTimeout:  cfg.Server.ReadTimeout,
+		WriteTimeout: cfg.Server.WriteTimeout,
+		IdleTimeout:  cfg.Server.IdleTimeout,
+		// جميع الأخطاء المُعادة من المعالجات تُترجم هنا إلى رمز HTTP وجسم JSON موحّد
+		ErrorHandler: handlers.ErrorHandler,
+	})
+
+	// معرف الطلب (X-Request-ID) يُعاد في الترويسة وفي جسم الأخطاء لتتبع الطلب في السجلات
+	app.Use(requestid.New())
 
-	app := fiber.New()
+	if cfg.Features.RequestLogging {
+		app.Use(logger.New(logger.Config{
+			Format: "${time} ${locals:requestid} ${status} - ${latency} ${method} ${path}\n",
+		}))
+	}
 
 	// 5. تعريف مسارات Fiber (Routes)
-	api := app.Group("/api/v1")
+	api := app.Group("/api/v1", handlers.IdentifyAdmin(cfg.Auth.AdminToken), handlers.IdentifyEditor())
+
+	// مهلة الاستعلام لكل فئة من المسارات تُمرر عبر سياق الطلب حتى قاعدة البيانات
+	qt := cfg.Server.QueryTimeouts.Effective()
+	readTimeout := handlers.QueryTimeout(qt.Read)
+	listTimeout := handlers.QueryTimeout(qt.List)
+	searchTimeout := handlers.QueryTimeout(qt.Search)
+	writeTimeout := handlers.QueryTimeout(qt.Write)
+	bulkTimeout := handlers.QueryTimeout(qt.Bulk)
+
+	// If-Match اختيارية في التحديث إلا إذا فُعّل اشتراطها، وعدم تطابقها مع النسخة الحالية يعيد 412
+	ifMatch := func(c *fiber.Ctx) error { return c.Next() }
+	itemVersions := ifMatch
+	if cfg.Features.RequireIfMatch {
+		ifMatch = handlers.RequireIfMatch()
+		itemVersions = handlers.RequireItemVersions()
+	}
 
 	articlesGroup := api.Group("/articles")
-	articlesGroup.Post("/", articleHandler.CreateArticle)
-	articlesGroup.Get("/", articleHandler.GetAllArticles)
-	articlesGroup.Get("/:id", articleHandler.GetArticleByID)
-	articlesGroup.Put("/:id", articleHandler.UpdateArticle)
-	articlesGroup.Delete("/:id", articleHandler.DeleteArticle)
+	articlesGroup.Post("/", writeTimeout, articleHandler.CreateArticle)
+	articlesGroup.Get("/", listTimeout, articleHandler.GetAllArticles)
+	articlesGroup.Get("/search", searchTimeout, articleHandler.SearchArticles)
+	articlesGroup.Get("/trash", listTimeout, articleHandler.GetTrash)
+	articlesGroup.Post("/bulk", bulkTimeout, articleHandler.BulkCreateArticles)
+	articlesGroup.Patch("/bulk", itemVersions, bulkTimeout, articleHandler.BulkUpdateArticles)
+	articlesGroup.Delete("/bulk", bulkTimeout, articleHandler.BulkDeleteArticles)
+	articlesGroup.Get("/by-slug/:slug", readTimeout, articleHandler.GetArticleBySlug)
+	articlesGroup.Get("/:id", readTimeout, articleHandler.GetArticleByID)
+	articlesGroup.Put("/:id", ifMatch, writeTimeout, articleHandler.UpdateArticle)
+	articlesGroup.Delete("/:id", writeTimeout, articleHandler.DeleteArticle)
+	articlesGroup.Post("/:id/restore", writeTimeout, articleHandler.RestoreArticle)
+	articlesGroup.Post("/:id/publish", ifMatch, writeTimeout, articleHandler.PublishArticle)
+	articlesGroup.Post("/:id/unpublish", ifMatch, writeTimeout, articleHandler.UnpublishArticle)
+	articlesGroup.Post("/:id/archive", ifMatch, writeTimeout, articleHandler.ArchiveArticle)
+	articlesGroup.Post("/:id/schedule", ifMatch, writeTimeout, articleHandler.ScheduleArticle)
+	articlesGroup.Get("/:id/revisions", listTimeout, articleHandler.GetArticleRevisions)
+	articlesGroup.Get("/:id/revisions/diff", readTimeout, articleHandler.DiffArticleRevisions)
+	articlesGroup.Get("/:id/revisions/:rev", readTimeout, articleHandler.GetArticleRevision)
+	articlesGroup.Post("/:id/revisions/:rev/revert", ifMatch, writeTimeout, articleHandler.RevertArticle)
+	articlesGroup.Post("/:id/comments", writeTimeout, commentHandler.CreateComment)
+	articlesGroup.Get("/:id/comments", listTimeout, commentHandler.GetComments)
+	articlesGroup.Put("/:id/comments/:comment", writeTimeout, commentHandler.UpdateComment)
+	articlesGroup.Delete("/:id/comments/:comment", writeTimeout, commentHandler.DeleteComment)
+	articlesGroup.Post("/:id/comments/:comment/approve", writeTimeout, commentHandler.ApproveComment)
+	articlesGroup.Post("/:id/comments/:comment/reject", writeTimeout, commentHandler.RejectComment)
+
+	// طابور مراجعة التعليقات يجمع تعليقات كل المقالات للمشرف
+	api.Get("/comments/queue", listTimeout, commentHandler.GetModerationQueue)
 
 	authorsGroup := api.Group("/authors")
-	authorsGroup.Post("/", authorHandler.CreateAuthor)
-	authorsGroup.Get("/", authorHandler.GetAllAuthors)
-	authorsGroup.Get("/:id", authorHandler.GetAuthorByID)
-	authorsGroup.Put("/:id", authorHandler.UpdateAuthor)
-	authorsGroup.Delete("/:id", authorHandler.DeleteAuthor)
+	authorsGroup.Post("/", writeTimeout, authorHandler.CreateAuthor)
+	authorsGroup.Get("/", listTimeout, authorHandler.GetAllAuthors)
+	authorsGroup.Get("/by-slug/:slug", readTimeout, authorHandler.GetAuthorBySlug)
+	authorsGroup.Get("/:id", readTimeout, authorHandler.GetAuthorByID)
+	authorsGroup.Put("/:id", ifMatch, writeTimeout, authorHandler.UpdateAuthor)
+	authorsGroup.Delete("/:id", writeTimeout, authorHandler.DeleteAuthor)
+
+	tagsGroup := api.Group("/tags")
+	tagsGroup.Post("/", writeTimeout, tagHandler.CreateTag)
+	tagsGroup.Get("/", listTimeout, tagHandler.GetAllTags)
+	tagsGroup.Get("/:id", readTimeout, tagHandler.GetTagByID)
+	tagsGroup.Put("/:id", writeTimeout, tagHandler.UpdateTag)
+	tagsGroup.Delete("/:id", writeTimeout, tagHandler.DeleteTag)
+
+	categoriesGroup := api.Group("/categories")
+	categoriesGroup.Post("/", writeTimeout, categoryHandler.CreateCategory)
+	categoriesGroup.Get("/", listTimeout, categoryHandler.GetCategoryTree)
+	categoriesGroup.Get("/:id", readTimeout, categoryHandler.GetCategoryByID)
+	categoriesGroup.Put("/:id", writeTimeout, categoryHandler.UpdateCategory)
+	categoriesGroup.Delete("/:id", writeTimeout, categoryHandler.DeleteCategory)
 
 	app.Get("/health", func(c *fiber.Ctx) error {
 		return c.SendString("Application is healthy!")
 	})
 
+	if readCache != nil {
+		app.Get("/cache/stats", func(c *fiber.Ctx) error {
+			return c.JSON(readCache.Stats())
+		})
+	}
+
 	app.Use(func(c *fiber.Ctx) error {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "عذراً، المسار غير موجود (404)."})
+		return apperr.NotFound("route_not_found")
 	})
 
-	log.Fatal(app.Listen(":3000"))
+	// 6. المهام الخلفية تعمل ضمن مجموعة واحدة تُوقف عند الإغلاق
+	workers := worker.NewGroup(context.Background())
+	if cfg.Trash.RetentionDays > 0 {
+		retention := time.Duration(cfg.Trash.RetentionDays) * 24 * time.Hour
+		workers.Go("trash-retention", worker.Every(cfg.Trash.PurgeInterval, func(ctx context.Context) {
+			purged, err := articleUseCase.PurgeTrash(ctx, retention)
+			if err != nil {
+				log.Printf("فشل تنظيف سلة المحذوفات: %v", err)
+				return
+			}
+			if purged > 0 {
+				log.Printf("تم الحذف النهائي لـ %d مقال مضى على حذفه أكثر من %d يومًا", purged, cfg.Trash.RetentionDays)
+			}
+		}))
+	}
+
+	// نشر المقالات المجدولة آمن مع عدة نسخ من التطبيق: كل مقال ينتقل إلى published بتحديث شرطي واحد
+	if cfg.Scheduler.PublishInterval > 0 {
+		workers.Go("article-scheduler", worker.Every(cfg.Scheduler.PublishInterval, func(ctx context.Context) {
+			published, err := articleUseCase.PublishDueArticles(ctx)
+			if err != nil {
+				log.Printf("فشل نشر المقالات المجدولة: %v", err)
+				return
+			}
+			if published > 0 {
+				log.Printf("تم نشر %d مقال مجدول حان موعده", published)
+			}
+		}))
+	}
+
+	// 7. تشغيل الخادم وانتظار إشارة الإيقاف (SIGINT/SIGTERM)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- app.Listen(cfg.Server.Addr)
+	}()
+
+	// listenErr خطأ الخادم إذا توقف من تلقاء نفسه (مثل عنوان غير صالح أو مستخدم)، فيُغلق التطبيق بحالة فشل بعد التنظيف
+	var listenErr error
+	select {
+	case <-ctx.Done():
+		log.Println("تم استلام إشارة الإيقاف، جارٍ إغلاق الخادم...")
+	case listenErr = <-serverErr:
+		if listenErr != nil {
+			log.Printf("توقف الخادم بشكل غير متوقع: %v", listenErr)
+		}
+	}
+	stop()
+
+	if err := shutdown(app, workers, db, cfg.Server.ShutdownTimeout); err != nil {
+		log.Fatalf("فشل الإغلاق الآمن: %v", err)
+	}
+	if readCache != nil {
+		if err := readCache.Close(); err != nil {
+			log.Printf("فشل إغلاق اتصال Redis: %v", err)
+		}
+	}
+	if listenErr != nil {
+		log.Fatalf("أُغلق التطبيق بعد فشل الخادم: %v", listenErr)
+	}
+	log.Println("تم إغلاق التطبيق بأمان.")
+}
+
+// openStorage يهيئ المستودعات ومدير المعاملات حسب storage؛ db تكون nil مع التخزين في الذاكرة
+func openStorage(cfg *config.Config) (repository.Repositories, repository.TxManager, *gorm.DB, error) {
+	if cfg.Storage == config.StorageMemory {
+		log.Println("التخزين في الذاكرة: البيانات تضيع عند إغلاق التطبيق")
+		store := repository.NewMemoryStore()
+		return repository.NewMemoryRepositories(store), repository.NewMemoryTxManager(store), nil, nil
+	}
+
+	db, err := database.InitGORMDB(cfg)
+	if err != nil {
+		return repository.Repositories{}, nil, nil, err
+	}
+	return repository.NewRepositories(db), repository.NewTxManager(db), db, nil
+}
+
+// shutdown يوقف استقبال الاتصالات ويصرّف الطلبات الجارية خلال المهلة المحددة،
+// ثم يوقف المهام الخلفية ويغلق اتصال قاعدة البيانات إن وُجد
+func shutdown(app *fiber.App, workers *worker.Group, db *gorm.DB, timeout time.Duration) error {
+	var errs []error
+	if err := app.ShutdownWithTimeout(timeout); err != nil {
+		errs = append(errs, fmt.Errorf("فشل تصريف الطلبات الجارية: %w", err))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := workers.Stop(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("فشل إيقاف المهام الخلفية: %w", err))
+	}
+	if db != nil {
+		if err := database.Close(db); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
 }
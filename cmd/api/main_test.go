@@ -0,0 +1,120 @@
+// my-article-app/cmd/api/main_test.go
+package main
+
+import (
+	"context"
+	"io"
+	"my-article-app/internal/dto"
+	"my-article-app/internal/handlers"
+	"my-article-app/internal/pagination"
+	"my-article-app/internal/repository"
+	"my-article-app/internal/usecase"
+	"my-article-app/internal/worker"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// TestShutdownDrainsInFlightCreate يتحقق من أن طلب إنشاء مقال بدأ قبل الإغلاق يكتمل ويُحفظ،
+// وأن الخادم يتوقف عن قبول اتصالات جديدة أثناء تصريفه
+func TestShutdownDrainsInFlightCreate(t *testing.T) {
+	store := repository.NewMemoryStore()
+	repos := repository.NewMemoryRepositories(store)
+	txManager := repository.NewMemoryTxManager(store)
+	articleUseCase := usecase.NewArticleUseCase(repos.Articles, repos.Authors, repos.Revisions, repos.SlugHistory, txManager)
+	authorUseCase := usecase.NewAuthorUseCase(repos.Authors, txManager)
+
+	ctx := context.Background()
+	author, err := authorUseCase.CreateAuthor(ctx, &dto.CreateAuthorRequest{Name: "Shutdown Author", Email: "shutdown@example.com"})
+	if err != nil {
+		t.Fatalf("CreateAuthor: %v", err)
+	}
+
+	// البوابة تُبقي الطلب معلّقًا حتى يبدأ الإغلاق، ثم يكمل إلى CreateArticle
+	started := make(chan struct{})
+	release := make(chan struct{})
+	gate := func(c *fiber.Ctx) error {
+		close(started)
+		<-release
+		return c.Next()
+	}
+
+	app := fiber.New(fiber.Config{ErrorHandler: handlers.ErrorHandler, DisableStartupMessage: true})
+	app.Post("/api/v1/articles", gate, handlers.NewArticleHandler(articleUseCase).CreateArticle)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	go func() { _ = app.Listener(ln) }()
+
+	type result struct {
+		status int
+		body   string
+		err    error
+	}
+	responses := make(chan result, 1)
+	go func() {
+		body := `{"title":"Written during shutdown","content":"content that must not be lost","author_id":` +
+			strconv.FormatUint(uint64(author.ID), 10) + `}`
+		resp, err := http.Post("http://"+addr+"/api/v1/articles", "application/json", strings.NewReader(body))
+		if err != nil {
+			responses <- result{err: err}
+			return
+		}
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(resp.Body)
+		responses <- result{status: resp.StatusCode, body: string(data)}
+	}()
+
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("request did not reach the handler")
+	}
+
+	shutdownErr := make(chan error, 1)
+	go func() {
+		shutdownErr <- shutdown(app, worker.NewGroup(context.Background()), nil, 5*time.Second)
+	}()
+
+	// ننتظر إغلاق المستمع قبل إطلاق الطلب، حتى يجري CreateArticle أثناء الإغلاق فعلًا
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		conn, err := net.DialTimeout("tcp", addr, 100*time.Millisecond)
+		if err != nil {
+			break
+		}
+		conn.Close()
+		if time.Now().After(deadline) {
+			t.Fatal("listener still accepting connections after shutdown started")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	close(release)
+
+	res := <-responses
+	if res.err != nil {
+		t.Fatalf("in-flight request failed: %v", res.err)
+	}
+	if res.status != http.StatusCreated {
+		t.Fatalf("status = %d, want %d (body %s)", res.status, http.StatusCreated, res.body)
+	}
+	if err := <-shutdownErr; err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+
+	page, err := articleUseCase.GetAllArticles(ctx, &dto.ArticleListQuery{Status: "all"}, pagination.Request{Limit: 10}, true)
+	if err != nil {
+		t.Fatalf("GetAllArticles: %v", err)
+	}
+	if len(page.Data) != 1 || page.Data[0].Title != "Written during shutdown" {
+		t.Fatalf("stored articles = %+v, want the article created during shutdown", page.Data)
+	}
+}
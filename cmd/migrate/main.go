@@ -0,0 +1,103 @@
+// my-article-app/cmd/migrate/main.go
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"my-article-app/internal/config"
+	"my-article-app/internal/database"
+	"os"
+)
+
+const usage = `الاستخدام: migrate [أعلام الإعدادات] <up|down|status|redo>
+
+  up      تطبيق جميع الترحيلات المعلّقة
+  down    التراجع عن آخر ترحيل مطبّق
+  status  عرض حالة جميع الترحيلات
+  redo    التراجع عن آخر ترحيل ثم إعادة تطبيقه`
+
+func main() {
+	// الأمر يستخدم نفس الإعدادات التي يستخدمها الخادم (ملف، بيئة، أعلام)
+	cfg, err := config.Load(os.Args[1:])
+	if err != nil {
+		log.Fatalf("فشل تحميل الإعدادات: %v", err)
+	}
+
+	args := cfg.Args()
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	db, err := database.Open(cfg)
+	if err != nil {
+		log.Fatalf("فشل الاتصال بقاعدة البيانات: %v", err)
+	}
+	defer database.Close(db)
+
+	migrator, err := database.NewMigrator(db)
+	if err != nil {
+		log.Fatalf("فشل تحميل الترحيلات: %v", err)
+	}
+
+	if err := run(context.Background(), migrator, args[0]); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// run ينفّذ الأمر الفرعي المطلوب
+func run(ctx context.Context, migrator *database.Migrator, command string) error {
+	switch command {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, m := range applied {
+			fmt.Printf("تم تطبيق %04d_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			fmt.Println("لا توجد ترحيلات معلّقة.")
+		}
+
+	case "down":
+		m, err := migrator.Down(ctx)
+		if err != nil {
+			return err
+		}
+		if m == nil {
+			fmt.Println("لا توجد ترحيلات مطبّقة للتراجع عنها.")
+			return nil
+		}
+		fmt.Printf("تم التراجع عن %04d_%s\n", m.Version, m.Name)
+
+	case "redo":
+		m, err := migrator.Redo(ctx)
+		if err != nil {
+			return err
+		}
+		if m == nil {
+			fmt.Println("لا توجد ترحيلات مطبّقة لإعادة تطبيقها.")
+			return nil
+		}
+		fmt.Printf("تمت إعادة تطبيق %04d_%s\n", m.Version, m.Name)
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, s := range statuses {
+			state := "معلّق"
+			if s.AppliedAt != nil {
+				state = "مطبّق " + s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%04d_%-30s %s\n", s.Version, s.Name, state)
+		}
+
+	default:
+		return fmt.Errorf("أمر غير معروف %q\n%s", command, usage)
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+// my-article-app/internal/apperr/apperr.go
+package apperr
+
+import (
+	"context"
+	"errors"
+	"my-article-app/internal/i18n"
+)
+
+// Kind تصنيف الخطأ الذي يحدد كيفية عرضه للعميل (رمز HTTP) بغض النظر عن الطبقة التي أنتجته
+type Kind uint8
+
+const (
+	// KindInternal خطأ غير متوقع لا تُعرض تفاصيله للعميل
+	KindInternal Kind = iota
+	// KindValidation مدخلات غير صالحة (جسم الطلب أو معاملات الاستعلام أو كيان مرجعي غير موجود)
+	KindValidation
+	// KindNotFound المورد المطلوب غير موجود
+	KindNotFound
+	// KindConflict تعارض مع حالة البيانات الحالية، مثل التكرار أو وجود سجلات مرتبطة
+	KindConflict
+	// KindForbidden العملية غير مسموحة لصاحب الطلب
+	KindForbidden
+	// KindPreconditionFailed شرط الطلب (مثل If-Match) لا يطابق الحالة الحالية
+	KindPreconditionFailed
+	// KindPreconditionRequired الطلب يفتقد شرطًا مطلوبًا (مثل If-Match)
+	KindPreconditionRequired
+	// KindUnavailable الخدمة أو الطلب غير متاح حاليًا (مثل إلغاء الطلب)
+	KindUnavailable
+	// KindTimeout انتهت مهلة معالجة الطلب
+	KindTimeout
+	// KindAborted لم تُنفذ العملية لأن عملية أخرى تعتمد عليها فشلت (مثل عنصر في دفعة ذرية)
+	KindAborted
+)
+
+// Error خطأ مصنّف برمز ثابت يفهمه العميل، ورسالته تُبنى من فهرس الرسائل بلغة الطلب
+type Error struct {
+	Kind Kind
+	// Code رمز الخطأ الثابت (مثل article_not_found)، وهو أيضًا مفتاح الرسالة في فهارس i18n
+	Code string
+	// Args معاملات الرسالة بالترتيب الوارد في فهرس الرسائل
+	Args []any
+	// Err السبب الأصلي، لا يظهر في Error() حتى لا تتسرب تفاصيل قاعدة البيانات للعميل
+	Err error
+}
+
+// Error يعيد الرسالة الآمنة باللغة الافتراضية
+func (e *Error) Error() string {
+	return e.Message(i18n.Default)
+}
+
+// Message يعيد رسالة الخطأ باللغة المطلوبة
+func (e *Error) Message(lang string) string {
+	return i18n.T(lang, e.Code, e.Args...)
+}
+
+// Unwrap يتيح الوصول إلى السبب الأصلي عبر errors.Is و errors.As
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is يطابق الأخطاء المصنّفة بالرمز، فيتعرف errors.Is على الخطأ المعياري حتى مع اختلاف معاملاته
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// New ينشئ خطأ من تصنيف ورمز ومعاملات رسالته
+func New(kind Kind, code string, args ...any) *Error {
+	return &Error{Kind: kind, Code: code, Args: args}
+}
+
+// Wrap ينشئ خطأ مصنّفًا يحتفظ بالسبب الأصلي
+func Wrap(kind Kind, err error, code string, args ...any) *Error {
+	return &Error{Kind: kind, Code: code, Args: args, Err: err}
+}
+
+// Validation ينشئ خطأ مدخلات غير صالحة
+func Validation(code string, args ...any) *Error {
+	return New(KindValidation, code, args...)
+}
+
+// NotFound ينشئ خطأ مورد غير موجود
+func NotFound(code string, args ...any) *Error {
+	return New(KindNotFound, code, args...)
+}
+
+// Conflict ينشئ خطأ تعارض مع حالة البيانات
+func Conflict(code string, args ...any) *Error {
+	return New(KindConflict, code, args...)
+}
+
+// Forbidden ينشئ خطأ عملية غير مسموحة
+func Forbidden(code string, args ...any) *Error {
+	return New(KindForbidden, code, args...)
+}
+
+// PreconditionFailed ينشئ خطأ شرط غير متحقق
+func PreconditionFailed(code string, args ...any) *Error {
+	return New(KindPreconditionFailed, code, args...)
+}
+
+// PreconditionRequired ينشئ خطأ شرط مفقود
+func PreconditionRequired(code string, args ...any) *Error {
+	return New(KindPreconditionRequired, code, args...)
+}
+
+// Aborted ينشئ خطأ عملية أُلغيت بسبب فشل عملية مرتبطة بها
+func Aborted(code string, args ...any) *Error {
+	return New(KindAborted, code, args...)
+}
+
+// Unavailable ينشئ خطأ خدمة غير متاحة
+func Unavailable(code string, args ...any) *Error {
+	return New(KindUnavailable, code, args...)
+}
+
+// KindOf يستخرج تصنيف الخطأ من أي مستوى في سلسلة التغليف
+// أخطاء السياق تُصنّف تلقائيًا: انتهاء المهلة KindTimeout، والإلغاء KindUnavailable
+func KindOf(err error) Kind {
+	var e *Error
+	switch {
+	case err == nil:
+		return KindInternal
+	case errors.As(err, &e):
+		return e.Kind
+	case errors.Is(err, context.DeadlineExceeded):
+		return KindTimeout
+	case errors.Is(err, context.Canceled):
+		return KindUnavailable
+	}
+	return KindInternal
+}
+
+// Is يحدد ما إذا كان الخطأ من التصنيف المعطى
+func Is(err error, kind Kind) bool {
+	return err != nil && KindOf(err) == kind
+}
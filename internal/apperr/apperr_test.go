@@ -0,0 +1,65 @@
+// my-article-app/internal/apperr/apperr_test.go
+package apperr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestKindOf(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want Kind
+	}{
+		{"nil", nil, KindInternal},
+		{"plain error", errors.New("boom"), KindInternal},
+		{"classified", NotFound("article_not_found", 1), KindNotFound},
+		{"wrapped classified", fmt.Errorf("load: %w", Conflict("duplicate_title")), KindConflict},
+		{"deadline", context.DeadlineExceeded, KindTimeout},
+		{"wrapped deadline", fmt.Errorf("query: %w", context.DeadlineExceeded), KindTimeout},
+		{"canceled", context.Canceled, KindUnavailable},
+		// التصنيف الصريح يسبق تصنيف خطأ السياق الذي يغلّفه
+		{"classified wrapping deadline", Wrap(KindAborted, context.DeadlineExceeded, "bulk_aborted"), KindAborted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := KindOf(tt.err); got != tt.want {
+				t.Errorf("KindOf(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+			if tt.err != nil && !Is(tt.err, tt.want) {
+				t.Errorf("Is(%v, %d) = false", tt.err, tt.want)
+			}
+		})
+	}
+	if Is(nil, KindInternal) {
+		t.Error("Is(nil, KindInternal) = true, want false")
+	}
+}
+
+func TestErrorMatchesByCode(t *testing.T) {
+	err := fmt.Errorf("update: %w", NotFound("article_not_found", 7))
+	if !errors.Is(err, NotFound("article_not_found")) {
+		t.Error("errors.Is does not match the same code with other args")
+	}
+	if errors.Is(err, NotFound("author_not_found")) {
+		t.Error("errors.Is matches a different code")
+	}
+}
+
+func TestErrorHidesCause(t *testing.T) {
+	cause := errors.New(`pq: duplicate key value violates unique constraint "authors_email_key"`)
+	err := Wrap(KindConflict, cause, "author_email_taken", "a@example.com")
+	if strings.Contains(err.Error(), "authors_email_key") {
+		t.Errorf("Error() = %q leaks the database error", err.Error())
+	}
+	if !errors.Is(err, cause) {
+		t.Error("Wrap does not keep the cause for errors.Is")
+	}
+	if got := err.Message("en"); !strings.Contains(got, "a@example.com") {
+		t.Errorf("Message(en) = %q, want the email argument", got)
+	}
+}
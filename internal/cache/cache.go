@@ -0,0 +1,169 @@
+// my-article-app/internal/cache/cache.go
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"my-article-app/internal/config"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/singleflight"
+)
+
+// invalidationTimeout مهلة زيادة رقم الجيل بعد الكتابة، منفصلة عن سياق الطلب حتى لا يمنع إلغاؤه الإبطال
+const invalidationTimeout = 2 * time.Second
+
+// Cache ذاكرة تخزين مؤقت على Redis للقراءات (read-through)
+// كل المفاتيح تحمل رقم "جيل" يُزاد مع أي كتابة، فتُبطل كل القيم السابقة دفعة واحدة دون البحث عنها؛
+// ولأن المقال يتضمن مؤلفه والمؤلف يتضمن مقالاته فإن الجيل واحد للنوعين
+// وهذا يمنع أيضًا قارئًا بطيئًا من إعادة كتابة قيمة قديمة بعد الإبطال، لأنه يكتبها تحت الجيل السابق
+type Cache struct {
+	client  *redis.Client
+	prefix  string
+	itemTTL time.Duration
+	listTTL time.Duration
+	// group يجمع الطلبات المتزامنة على المفتاح نفسه في استعلام واحد عند عدم وجوده (حماية من التدافع)
+	group singleflight.Group
+
+	articles counter
+	authors  counter
+}
+
+// counter عدادات الإصابة والإخفاق لنوع واحد من السجلات
+type counter struct {
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+// Stats لقطة من عدادات نوع واحد
+type Stats struct {
+	Hits   int64 `json:"hits"`
+	Misses int64 `json:"misses"`
+}
+
+// New يتصل بـ Redis حسب الإعدادات ويتحقق من الاتصال قبل الإقلاع
+func New(cfg config.CacheConfig) (*Cache, error) {
+	client := redis.NewClient(&redis.Options{
+		Addr:     cfg.RedisAddr,
+		Password: cfg.RedisPassword,
+		DB:       cfg.RedisDB,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		_ = client.Close()
+		return nil, fmt.Errorf("فشل الاتصال بـ Redis على %s: %w", cfg.RedisAddr, err)
+	}
+
+	return &Cache{
+		client:  client,
+		prefix:  cfg.KeyPrefix,
+		itemTTL: cfg.ItemTTL,
+		listTTL: cfg.ListTTL,
+	}, nil
+}
+
+// Close يغلق اتصال Redis
+func (c *Cache) Close() error {
+	return c.client.Close()
+}
+
+// Stats يعيد عدادات الإصابة والإخفاق لكل نوع
+func (c *Cache) Stats() map[string]Stats {
+	return map[string]Stats{
+		"articles": c.articles.snapshot(),
+		"authors":  c.authors.snapshot(),
+	}
+}
+
+func (s *counter) snapshot() Stats {
+	return Stats{Hits: s.hits.Load(), Misses: s.misses.Load()}
+}
+
+// Invalidate يبطل كل القيم المخزنة بزيادة رقم الجيل
+func (c *Cache) Invalidate(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), invalidationTimeout)
+	defer cancel()
+	if err := c.client.Incr(ctx, c.prefix+"gen").Err(); err != nil {
+		// القيم القديمة تبقى حتى انتهاء صلاحيتها (TTL)، وهذا أقصى ما يمكن عند تعطل Redis
+		log.Printf("فشل إبطال التخزين المؤقت: %v", err)
+	}
+}
+
+// generation يقرأ رقم الجيل الحالي (0 إذا لم يُكتب شيء بعد)
+func (c *Cache) generation(ctx context.Context) (int64, error) {
+	gen, err := c.client.Get(ctx, c.prefix+"gen").Int64()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	return gen, err
+}
+
+// listKey يبني مفتاحًا ثابتًا لمعاملات قائمة (التصفية والترقيم) من بصمتها
+func listKey(kind string, params ...any) string {
+	raw, _ := json.Marshal(params)
+	sum := sha256.Sum256(raw)
+	return kind + ":list:" + hex.EncodeToString(sum[:12])
+}
+
+// idKey يبني مفتاح سجل واحد
+func idKey(kind string, id uint) string {
+	return kind + ":id:" + strconv.FormatUint(uint64(id), 10)
+}
+
+// readThrough يعيد القيمة من Redis إن وُجدت، وإلا يحمّلها بـ load ويخزنها لمدة ttl
+// أخطاء Redis لا تفشل الطلب بل تُسجّل ويُقرأ من المستودع مباشرة
+// النتيجة تُنقل بين الطلبات المتجمعة كـ JSON فيحصل كل طلب على نسخته الخاصة
+func readThrough[T any](ctx context.Context, c *Cache, stats *counter, key string, ttl time.Duration, load func() (T, error)) (T, error) {
+	var value T
+
+	gen, err := c.generation(ctx)
+	if err != nil {
+		log.Printf("تعذرت قراءة جيل التخزين المؤقت، القراءة من المستودع مباشرة: %v", err)
+		stats.misses.Add(1)
+		return load()
+	}
+	fullKey := c.prefix + strconv.FormatInt(gen, 10) + ":" + key
+
+	raw, err := c.client.Get(ctx, fullKey).Bytes()
+	if err == nil && json.Unmarshal(raw, &value) == nil {
+		stats.hits.Add(1)
+		return value, nil
+	}
+	if err != nil && !errors.Is(err, redis.Nil) {
+		log.Printf("فشل القراءة من التخزين المؤقت %s: %v", fullKey, err)
+	}
+	stats.misses.Add(1)
+
+	// الطلب الأول يحمّل القيمة ويخزنها، والطلبات المتزامنة معه تنتظر نتيجته؛ تستخدم سياقه هو
+	shared, err, _ := c.group.Do(fullKey, func() (any, error) {
+		loaded, err := load()
+		if err != nil {
+			return nil, err
+		}
+		raw, err := json.Marshal(loaded)
+		if err != nil {
+			return nil, fmt.Errorf("فشل ترميز القيمة للتخزين المؤقت: %w", err)
+		}
+		if err := c.client.Set(ctx, fullKey, raw, ttl).Err(); err != nil {
+			log.Printf("فشل الكتابة في التخزين المؤقت %s: %v", fullKey, err)
+		}
+		return raw, nil
+	})
+	if err != nil {
+		return value, err
+	}
+	if err := json.Unmarshal(shared.([]byte), &value); err != nil {
+		return value, fmt.Errorf("فشل فك القيمة من التخزين المؤقت: %w", err)
+	}
+	return value, nil
+}
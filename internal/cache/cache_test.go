@@ -0,0 +1,273 @@
+// my-article-app/internal/cache/cache_test.go
+package cache
+
+import (
+	"context"
+	"errors"
+	"my-article-app/internal/apperr"
+	"my-article-app/internal/config"
+	"my-article-app/internal/models"
+	"my-article-app/internal/repository"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+// newTestCache يشغّل Redis وهميًا داخل الاختبار ويتصل به
+func newTestCache(t *testing.T) (*Cache, *miniredis.Miniredis) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	c, err := New(config.CacheConfig{
+		RedisAddr: server.Addr(),
+		KeyPrefix: "test:",
+		ItemTTL:   time.Minute,
+		ListTTL:   time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	t.Cleanup(func() { _ = c.Close() })
+	return c, server
+}
+
+// countingArticles يعدّ قراءات FindByID التي تصل إلى المستودع، ويوقفها حتى يُغلق gate إن وُجد
+type countingArticles struct {
+	repository.ArticleRepository
+	calls atomic.Int64
+	gate  chan struct{}
+}
+
+func (r *countingArticles) FindByID(ctx context.Context, id uint) (*models.Article, error) {
+	r.calls.Add(1)
+	if r.gate != nil {
+		<-r.gate
+	}
+	return r.ArticleRepository.FindByID(ctx, id)
+}
+
+// seedArticle ينشئ مؤلفًا ومقالًا في التخزين في الذاكرة ويعيد المقال
+func seedArticle(t *testing.T, repos repository.Repositories) *models.Article {
+	t.Helper()
+	ctx := context.Background()
+	author := &models.Author{Name: "cache author", Email: "cache@example.com", Slug: "cache-author"}
+	if err := repos.Authors.Create(ctx, author); err != nil {
+		t.Fatalf("create author: %v", err)
+	}
+	article := &models.Article{Title: "Cached article", Slug: "cached-article", Content: "cached content", AuthorID: author.ID}
+	if err := repos.Articles.Create(ctx, article); err != nil {
+		t.Fatalf("create article: %v", err)
+	}
+	return article
+}
+
+func TestReadThroughCounters(t *testing.T) {
+	c, _ := newTestCache(t)
+	ctx := context.Background()
+	repos := repository.NewMemoryRepositories(repository.NewMemoryStore())
+	article := seedArticle(t, repos)
+	counting := &countingArticles{ArticleRepository: repos.Articles}
+	cached := NewArticleRepository(counting, c)
+
+	for range 3 {
+		got, err := cached.FindByID(ctx, article.ID)
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		if got.Title != article.Title {
+			t.Errorf("FindByID title = %q, want %q", got.Title, article.Title)
+		}
+	}
+	if got := c.Stats()["articles"]; got != (Stats{Hits: 2, Misses: 1}) {
+		t.Errorf("articles stats = %+v, want 2 hits 1 miss", got)
+	}
+	if n := counting.calls.Load(); n != 1 {
+		t.Errorf("repository reads = %d, want 1", n)
+	}
+
+	// الأخطاء لا تُخزن، فكل قراءة لمقال غير موجود تصل إلى المستودع
+	for range 2 {
+		if _, err := cached.FindByID(ctx, 999); !apperr.Is(err, apperr.KindNotFound) {
+			t.Fatalf("FindByID(999): err = %v, want not found", err)
+		}
+	}
+	if n := counting.calls.Load(); n != 3 {
+		t.Errorf("repository reads after misses = %d, want 3", n)
+	}
+	if got := c.Stats()["authors"]; got != (Stats{}) {
+		t.Errorf("authors stats = %+v, want zero", got)
+	}
+}
+
+func TestWriteInvalidates(t *testing.T) {
+	c, _ := newTestCache(t)
+	ctx := context.Background()
+	repos := repository.NewMemoryRepositories(repository.NewMemoryStore())
+	article := seedArticle(t, repos)
+	cached := NewArticleRepository(repos.Articles, c)
+
+	if _, err := cached.FindByID(ctx, article.ID); err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	article.Title = "Renamed article"
+	if err := cached.Update(ctx, article); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	got, err := cached.FindByID(ctx, article.ID)
+	if err != nil {
+		t.Fatalf("FindByID after Update: %v", err)
+	}
+	if got.Title != "Renamed article" {
+		t.Errorf("FindByID after Update title = %q, want the new title", got.Title)
+	}
+	if stats := c.Stats()["articles"]; stats.Misses != 2 {
+		t.Errorf("articles misses = %d, want 2 (the write invalidated the cached value)", stats.Misses)
+	}
+}
+
+func TestTxInvalidatesOnlyAfterCommit(t *testing.T) {
+	c, _ := newTestCache(t)
+	ctx := context.Background()
+	store := repository.NewMemoryStore()
+	repos := repository.NewMemoryRepositories(store)
+	article := seedArticle(t, repos)
+	cached := NewArticleRepository(repos.Articles, c)
+	tx := NewTxManager(repository.NewMemoryTxManager(store), c)
+
+	generation := func() int64 {
+		t.Helper()
+		gen, err := c.generation(ctx)
+		if err != nil {
+			t.Fatalf("generation: %v", err)
+		}
+		return gen
+	}
+	rename := func(title string, fail error) error {
+		return tx.WithinTx(ctx, func(repos repository.Repositories) error {
+			current, err := repos.Articles.FindByID(ctx, article.ID)
+			if err != nil {
+				return err
+			}
+			current.Title = title
+			if err := repos.Articles.Update(ctx, current); err != nil {
+				return err
+			}
+			// الكتابة نجحت لكن المعاملة لم تُثبَّت بعد، فلا يُبطل شيء
+			if gen := generation(); gen != 0 {
+				t.Errorf("generation inside tx = %d, want 0", gen)
+			}
+			return fail
+		})
+	}
+
+	if _, err := cached.FindByID(ctx, article.ID); err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+
+	errRollback := errors.New("rollback")
+	if err := rename("Rolled back title", errRollback); !errors.Is(err, errRollback) {
+		t.Fatalf("WithinTx: err = %v, want rollback error", err)
+	}
+	if gen := generation(); gen != 0 {
+		t.Errorf("generation after rollback = %d, want 0", gen)
+	}
+	got, err := cached.FindByID(ctx, article.ID)
+	if err != nil {
+		t.Fatalf("FindByID after rollback: %v", err)
+	}
+	if got.Title != article.Title {
+		t.Errorf("FindByID after rollback title = %q, want %q", got.Title, article.Title)
+	}
+
+	if err := rename("Committed title", nil); err != nil {
+		t.Fatalf("WithinTx: %v", err)
+	}
+	if gen := generation(); gen != 1 {
+		t.Errorf("generation after commit = %d, want 1", gen)
+	}
+	got, err = cached.FindByID(ctx, article.ID)
+	if err != nil {
+		t.Fatalf("FindByID after commit: %v", err)
+	}
+	if got.Title != "Committed title" {
+		t.Errorf("FindByID after commit title = %q, want the committed title", got.Title)
+	}
+
+	// معاملة لا تكتب شيئًا لا تبطل التخزين
+	if err := tx.WithinTx(ctx, func(repository.Repositories) error { return nil }); err != nil {
+		t.Fatalf("read-only WithinTx: %v", err)
+	}
+	if gen := generation(); gen != 1 {
+		t.Errorf("generation after read-only tx = %d, want 1", gen)
+	}
+}
+
+func TestSingleflightCollapsesConcurrentMisses(t *testing.T) {
+	c, _ := newTestCache(t)
+	ctx := context.Background()
+	repos := repository.NewMemoryRepositories(repository.NewMemoryStore())
+	article := seedArticle(t, repos)
+	counting := &countingArticles{ArticleRepository: repos.Articles, gate: make(chan struct{})}
+	cached := NewArticleRepository(counting, c)
+
+	const readers = 8
+	var wg sync.WaitGroup
+	titles := make([]string, readers)
+	errs := make([]error, readers)
+	for i := range readers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got, err := cached.FindByID(ctx, article.ID)
+			errs[i] = err
+			if err == nil {
+				titles[i] = got.Title
+			}
+		}()
+	}
+
+	// القراءة الأولى موقوفة عند المستودع حتى تسجل كل القراءات إخفاقها وتنتظر نتيجتها
+	deadline := time.Now().Add(5 * time.Second)
+	for c.Stats()["articles"].Misses < readers {
+		if time.Now().After(deadline) {
+			t.Fatalf("only %d of %d readers missed", c.Stats()["articles"].Misses, readers)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(counting.gate)
+	wg.Wait()
+
+	if n := counting.calls.Load(); n != 1 {
+		t.Errorf("repository reads = %d, want 1", n)
+	}
+	for i := range readers {
+		if errs[i] != nil || titles[i] != article.Title {
+			t.Errorf("reader %d got %q, %v", i, titles[i], errs[i])
+		}
+	}
+}
+
+func TestRedisFailureFallsBackToRepository(t *testing.T) {
+	c, server := newTestCache(t)
+	ctx := context.Background()
+	repos := repository.NewMemoryRepositories(repository.NewMemoryStore())
+	article := seedArticle(t, repos)
+	counting := &countingArticles{ArticleRepository: repos.Articles}
+	cached := NewArticleRepository(counting, c)
+
+	server.Close()
+	for range 2 {
+		if _, err := cached.FindByID(ctx, article.ID); err != nil {
+			t.Fatalf("FindByID with Redis down: %v", err)
+		}
+	}
+	if n := counting.calls.Load(); n != 2 {
+		t.Errorf("repository reads = %d, want 2", n)
+	}
+	if got := c.Stats()["articles"]; got != (Stats{Misses: 2}) {
+		t.Errorf("articles stats = %+v, want 2 misses", got)
+	}
+}
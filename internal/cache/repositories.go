@@ -0,0 +1,141 @@
+// my-article-app/internal/cache/repositories.go
+package cache
+
+import (
+	"context"
+	"my-article-app/internal/models"
+	"my-article-app/internal/pagination"
+	"my-article-app/internal/repository"
+	"time"
+)
+
+// articleRepository يغلّف ArticleRepository: يخزن FindByID و FindAll مؤقتًا ويبطل التخزين بعد كل كتابة ناجحة
+// بقية القراءات (البحث وسلة المحذوفات) تمر إلى المستودع الأصلي كما هي
+type articleRepository struct {
+	repository.ArticleRepository
+	cache *Cache
+}
+
+// NewArticleRepository يغلّف مستودع المقالات بالتخزين المؤقت
+func NewArticleRepository(next repository.ArticleRepository, c *Cache) repository.ArticleRepository {
+	return &articleRepository{ArticleRepository: next, cache: c}
+}
+
+// FindByID يقرأ المقال من التخزين المؤقت أو من المستودع
+func (r *articleRepository) FindByID(ctx context.Context, id uint) (*models.Article, error) {
+	return readThrough(ctx, r.cache, &r.cache.articles, idKey("article", id), r.cache.itemTTL, func() (*models.Article, error) {
+		return r.ArticleRepository.FindByID(ctx, id)
+	})
+}
+
+// FindAll يقرأ صفحة المقالات من التخزين المؤقت أو من المستودع
+func (r *articleRepository) FindAll(ctx context.Context, filter repository.ArticleFilter, req pagination.Request) (*pagination.Page[models.Article], error) {
+	return readThrough(ctx, r.cache, &r.cache.articles, listKey("article", filter, req), r.cache.listTTL, func() (*pagination.Page[models.Article], error) {
+		return r.ArticleRepository.FindAll(ctx, filter, req)
+	})
+}
+
+func (r *articleRepository) Create(ctx context.Context, article *models.Article) error {
+	return r.cache.afterWrite(ctx, r.ArticleRepository.Create(ctx, article))
+}
+
+func (r *articleRepository) CreateBatch(ctx context.Context, articles []*models.Article, batchSize int) error {
+	return r.cache.afterWrite(ctx, r.ArticleRepository.CreateBatch(ctx, articles, batchSize))
+}
+
+func (r *articleRepository) Update(ctx context.Context, article *models.Article) error {
+	return r.cache.afterWrite(ctx, r.ArticleRepository.Update(ctx, article))
+}
+
+func (r *articleRepository) Delete(ctx context.Context, id uint) error {
+	return r.cache.afterWrite(ctx, r.ArticleRepository.Delete(ctx, id))
+}
+
+func (r *articleRepository) Restore(ctx context.Context, article *models.Article) error {
+	return r.cache.afterWrite(ctx, r.ArticleRepository.Restore(ctx, article))
+}
+
+func (r *articleRepository) Purge(ctx context.Context, id uint) error {
+	return r.cache.afterWrite(ctx, r.ArticleRepository.Purge(ctx, id))
+}
+
+func (r *articleRepository) PurgeDeletedBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	n, err := r.ArticleRepository.PurgeDeletedBefore(ctx, cutoff)
+	return n, r.cache.afterBulkWrite(ctx, n, err)
+}
+
+func (r *articleRepository) DeleteByAuthor(ctx context.Context, authorID uint) (int64, error) {
+	n, err := r.ArticleRepository.DeleteByAuthor(ctx, authorID)
+	return n, r.cache.afterBulkWrite(ctx, n, err)
+}
+
+func (r *articleRepository) ReassignAuthor(ctx context.Context, fromID, toID uint) (int64, error) {
+	n, err := r.ArticleRepository.ReassignAuthor(ctx, fromID, toID)
+	return n, r.cache.afterBulkWrite(ctx, n, err)
+}
+
+func (r *articleRepository) PublishDue(ctx context.Context, now time.Time) (int64, error) {
+	n, err := r.ArticleRepository.PublishDue(ctx, now)
+	return n, r.cache.afterBulkWrite(ctx, n, err)
+}
+
+// authorRepository يغلّف AuthorRepository: يخزن FindByID و FindAll مؤقتًا
+// قراءات القفل (ForShare و ForUpdate) لا تُخزن لأنها تُستخدم للتحقق داخل المعاملات
+type authorRepository struct {
+	repository.AuthorRepository
+	cache *Cache
+}
+
+// NewAuthorRepository يغلّف مستودع المؤلفين بالتخزين المؤقت
+func NewAuthorRepository(next repository.AuthorRepository, c *Cache) repository.AuthorRepository {
+	return &authorRepository{AuthorRepository: next, cache: c}
+}
+
+// FindByID يقرأ المؤلف مع مقالاته من التخزين المؤقت أو من المستودع
+func (r *authorRepository) FindByID(ctx context.Context, id uint) (*models.Author, error) {
+	return readThrough(ctx, r.cache, &r.cache.authors, idKey("author", id), r.cache.itemTTL, func() (*models.Author, error) {
+		return r.AuthorRepository.FindByID(ctx, id)
+	})
+}
+
+// FindAll يقرأ صفحة المؤلفين من التخزين المؤقت أو من المستودع
+func (r *authorRepository) FindAll(ctx context.Context, filter repository.AuthorFilter, req pagination.Request) (*pagination.Page[models.Author], error) {
+	return readThrough(ctx, r.cache, &r.cache.authors, listKey("author", filter, req), r.cache.listTTL, func() (*pagination.Page[models.Author], error) {
+		return r.AuthorRepository.FindAll(ctx, filter, req)
+	})
+}
+
+// FindAllWithStats يقرأ صفحة المؤلفين مع إحصاءاتهم من التخزين المؤقت أو من المستودع
+func (r *authorRepository) FindAllWithStats(ctx context.Context, filter repository.AuthorFilter, req pagination.Request) (*pagination.Page[repository.AuthorWithStats], error) {
+	return readThrough(ctx, r.cache, &r.cache.authors, listKey("author-stats", filter, req), r.cache.listTTL, func() (*pagination.Page[repository.AuthorWithStats], error) {
+		return r.AuthorRepository.FindAllWithStats(ctx, filter, req)
+	})
+}
+
+func (r *authorRepository) Create(ctx context.Context, author *models.Author) error {
+	return r.cache.afterWrite(ctx, r.AuthorRepository.Create(ctx, author))
+}
+
+func (r *authorRepository) Update(ctx context.Context, author *models.Author) error {
+	return r.cache.afterWrite(ctx, r.AuthorRepository.Update(ctx, author))
+}
+
+func (r *authorRepository) Delete(ctx context.Context, id uint) error {
+	return r.cache.afterWrite(ctx, r.AuthorRepository.Delete(ctx, id))
+}
+
+// afterWrite يبطل التخزين المؤقت إذا نجحت الكتابة، ويعيد خطأها كما هو
+func (c *Cache) afterWrite(ctx context.Context, err error) error {
+	if err == nil {
+		c.Invalidate(ctx)
+	}
+	return err
+}
+
+// afterBulkWrite مثل afterWrite لكنه لا يبطل شيئًا إذا لم تؤثر الكتابة على أي سجل
+func (c *Cache) afterBulkWrite(ctx context.Context, affected int64, err error) error {
+	if err == nil && affected > 0 {
+		c.Invalidate(ctx)
+	}
+	return err
+}
@@ -0,0 +1,177 @@
+// my-article-app/internal/cache/tx.go
+package cache
+
+import (
+	"context"
+	"my-article-app/internal/models"
+	"my-article-app/internal/repository"
+	"sync/atomic"
+	"time"
+)
+
+// txManager يغلّف TxManager حتى تُبطل الكتابات التي تتم داخل المعاملات التخزين المؤقت بعد تثبيتها
+// المستودعات داخل المعاملة لا تقرأ من التخزين المؤقت، لأن قراءاتها يجب أن ترى تعديلات المعاملة نفسها
+type txManager struct {
+	next  repository.TxManager
+	cache *Cache
+}
+
+// NewTxManager يغلّف مدير المعاملات بإبطال التخزين المؤقت عند التثبيت
+func NewTxManager(next repository.TxManager, c *Cache) repository.TxManager {
+	return &txManager{next: next, cache: c}
+}
+
+// WithinTx يتتبع الكتابات داخل المعاملة ويبطل التخزين المؤقت مرة واحدة بعد تثبيتها؛
+// الإبطال قبل التثبيت قد يسمح لقارئ بإعادة تخزين البيانات القديمة قبل أن تصبح الجديدة مرئية
+func (m *txManager) WithinTx(ctx context.Context, fn func(repos repository.Repositories) error) error {
+	var dirty atomic.Bool
+	err := m.next.WithinTx(ctx, func(repos repository.Repositories) error {
+		// المراجعات وسجل المعرفات السابقة لا تُخزن مؤقتًا، فتمر كما هي؛
+		// أما الوسوم والتصنيفات فتُقرأ مع المقالات المخزنة، فتبطلها كتاباتها، وكذلك عدد التعليقات
+		return fn(repository.Repositories{
+			Articles:    &txArticleRepository{ArticleRepository: repos.Articles, dirty: &dirty},
+			Authors:     &txAuthorRepository{AuthorRepository: repos.Authors, dirty: &dirty},
+			Revisions:   repos.Revisions,
+			SlugHistory: repos.SlugHistory,
+			Tags:        &txTagRepository{TagRepository: repos.Tags, dirty: &dirty},
+			Categories:  &txCategoryRepository{CategoryRepository: repos.Categories, dirty: &dirty},
+			Comments:    &txCommentRepository{CommentRepository: repos.Comments, dirty: &dirty},
+		})
+	})
+	if err == nil && dirty.Load() {
+		m.cache.Invalidate(ctx)
+	}
+	return err
+}
+
+// txArticleRepository يسجل الكتابات الناجحة على المقالات داخل المعاملة
+type txArticleRepository struct {
+	repository.ArticleRepository
+	dirty *atomic.Bool
+}
+
+// mark يسجل الكتابة إذا نجحت ويعيد خطأها كما هو
+func mark(dirty *atomic.Bool, err error) error {
+	if err == nil {
+		dirty.Store(true)
+	}
+	return err
+}
+
+func (r *txArticleRepository) Create(ctx context.Context, article *models.Article) error {
+	return mark(r.dirty, r.ArticleRepository.Create(ctx, article))
+}
+
+func (r *txArticleRepository) CreateBatch(ctx context.Context, articles []*models.Article, batchSize int) error {
+	return mark(r.dirty, r.ArticleRepository.CreateBatch(ctx, articles, batchSize))
+}
+
+func (r *txArticleRepository) Update(ctx context.Context, article *models.Article) error {
+	return mark(r.dirty, r.ArticleRepository.Update(ctx, article))
+}
+
+func (r *txArticleRepository) Delete(ctx context.Context, id uint) error {
+	return mark(r.dirty, r.ArticleRepository.Delete(ctx, id))
+}
+
+func (r *txArticleRepository) Restore(ctx context.Context, article *models.Article) error {
+	return mark(r.dirty, r.ArticleRepository.Restore(ctx, article))
+}
+
+func (r *txArticleRepository) Purge(ctx context.Context, id uint) error {
+	return mark(r.dirty, r.ArticleRepository.Purge(ctx, id))
+}
+
+func (r *txArticleRepository) PurgeDeletedBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	n, err := r.ArticleRepository.PurgeDeletedBefore(ctx, cutoff)
+	return n, mark(r.dirty, err)
+}
+
+func (r *txArticleRepository) DeleteByAuthor(ctx context.Context, authorID uint) (int64, error) {
+	n, err := r.ArticleRepository.DeleteByAuthor(ctx, authorID)
+	return n, mark(r.dirty, err)
+}
+
+func (r *txArticleRepository) ReassignAuthor(ctx context.Context, fromID, toID uint) (int64, error) {
+	n, err := r.ArticleRepository.ReassignAuthor(ctx, fromID, toID)
+	return n, mark(r.dirty, err)
+}
+
+func (r *txArticleRepository) PublishDue(ctx context.Context, now time.Time) (int64, error) {
+	n, err := r.ArticleRepository.PublishDue(ctx, now)
+	return n, mark(r.dirty, err)
+}
+
+// txAuthorRepository يسجل الكتابات الناجحة على المؤلفين داخل المعاملة
+type txAuthorRepository struct {
+	repository.AuthorRepository
+	dirty *atomic.Bool
+}
+
+func (r *txAuthorRepository) Create(ctx context.Context, author *models.Author) error {
+	return mark(r.dirty, r.AuthorRepository.Create(ctx, author))
+}
+
+func (r *txAuthorRepository) Update(ctx context.Context, author *models.Author) error {
+	return mark(r.dirty, r.AuthorRepository.Update(ctx, author))
+}
+
+func (r *txAuthorRepository) Delete(ctx context.Context, id uint) error {
+	return mark(r.dirty, r.AuthorRepository.Delete(ctx, id))
+}
+
+// txTagRepository يسجل الكتابات الناجحة التي تغيّر وسوم المقالات داخل المعاملة؛
+// إنشاء وسم جديد لا يغيّر مقالاً مخزنًا فلا يُسجل
+type txTagRepository struct {
+	repository.TagRepository
+	dirty *atomic.Bool
+}
+
+func (r *txTagRepository) Update(ctx context.Context, tag *models.Tag) error {
+	return mark(r.dirty, r.TagRepository.Update(ctx, tag))
+}
+
+func (r *txTagRepository) Delete(ctx context.Context, id uint) error {
+	return mark(r.dirty, r.TagRepository.Delete(ctx, id))
+}
+
+func (r *txTagRepository) ReplaceArticleTags(ctx context.Context, articleID uint, tagIDs []uint) error {
+	return mark(r.dirty, r.TagRepository.ReplaceArticleTags(ctx, articleID, tagIDs))
+}
+
+func (r *txTagRepository) LinkArticles(ctx context.Context, links []models.ArticleTag, batchSize int) error {
+	return mark(r.dirty, r.TagRepository.LinkArticles(ctx, links, batchSize))
+}
+
+// txCategoryRepository يسجل الكتابات الناجحة التي تغيّر تصنيفات المقالات داخل المعاملة
+type txCategoryRepository struct {
+	repository.CategoryRepository
+	dirty *atomic.Bool
+}
+
+func (r *txCategoryRepository) Update(ctx context.Context, category *models.Category) error {
+	return mark(r.dirty, r.CategoryRepository.Update(ctx, category))
+}
+
+func (r *txCategoryRepository) Delete(ctx context.Context, id uint) error {
+	return mark(r.dirty, r.CategoryRepository.Delete(ctx, id))
+}
+
+func (r *txCategoryRepository) ReplaceArticleCategories(ctx context.Context, articleID uint, categoryIDs []uint) error {
+	return mark(r.dirty, r.CategoryRepository.ReplaceArticleCategories(ctx, articleID, categoryIDs))
+}
+
+func (r *txCategoryRepository) LinkArticles(ctx context.Context, links []models.ArticleCategory, batchSize int) error {
+	return mark(r.dirty, r.CategoryRepository.LinkArticles(ctx, links, batchSize))
+}
+
+// txCommentRepository يسجل تحديث عدد تعليقات المقال داخل المعاملة؛
+// التعليقات نفسها لا تُخزن مؤقتًا، فلا تُسجل كتاباتها الأخرى
+type txCommentRepository struct {
+	repository.CommentRepository
+	dirty *atomic.Bool
+}
+
+func (r *txCommentRepository) RefreshArticleCount(ctx context.Context, articleID uint) error {
+	return mark(r.dirty, r.CommentRepository.RefreshArticleCount(ctx, articleID))
+}
@@ -0,0 +1,516 @@
+// my-article-app/internal/config/config.go
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config يجمع كل إعدادات التطبيق المطلوبة عند الإقلاع
+// ترتيب الأولوية: القيم الافتراضية ← ملف الإعدادات ← متغيرات البيئة ← أعلام سطر الأوامر
+type Config struct {
+	// Storage مكان حفظ البيانات: postgres (قاعدة بيانات GORM حسب database.driver، و database اسم بديل لها)
+	// أو memory (بلا أي بنية تحتية، وتضيع البيانات عند الإغلاق)
+	Storage   string          `yaml:"storage"`
+	Server    ServerConfig    `yaml:"server"`
+	Database  DatabaseConfig  `yaml:"database"`
+	Log       LogConfig       `yaml:"log"`
+	Features  FeaturesConfig  `yaml:"features"`
+	Auth      AuthConfig      `yaml:"auth"`
+	Trash     TrashConfig     `yaml:"trash"`
+	Cache     CacheConfig     `yaml:"cache"`
+	Scheduler SchedulerConfig `yaml:"scheduler"`
+	Comments  CommentsConfig  `yaml:"comments"`
+
+	// args هي الوسائط المتبقية بعد تحليل الأعلام (مثل أوامر فرعية)
+	args []string
+}
+
+// ServerConfig إعدادات خادم Fiber
+type ServerConfig struct {
+	Addr         string        `yaml:"addr"`
+	ReadTimeout  time.Duration `yaml:"read_timeout"`
+	WriteTimeout time.Duration `yaml:"write_timeout"`
+	IdleTimeout  time.Duration `yaml:"idle_timeout"`
+	// ShutdownTimeout أقصى مدة لانتظار الطلبات الجارية عند الإغلاق
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+	// QueryTimeouts مهلات استعلامات قاعدة البيانات لكل فئة من المسارات
+	QueryTimeouts QueryTimeoutsConfig `yaml:"query_timeouts"`
+}
+
+// QueryTimeoutsConfig مهلة الاستعلام لكل فئة من المسارات، والقيمة الصفرية تعني استخدام Default
+type QueryTimeoutsConfig struct {
+	Default time.Duration `yaml:"default"`
+	Read    time.Duration `yaml:"read"`
+	List    time.Duration `yaml:"list"`
+	Search  time.Duration `yaml:"search"`
+	Write   time.Duration `yaml:"write"`
+	// Bulk مهلة العمليات المجمّعة على المقالات، وهي أطول لأن الطلب الواحد قد يحمل مئات العناصر
+	Bulk time.Duration `yaml:"bulk"`
+}
+
+// Effective يعيد نسخة تُستبدل فيها القيم الصفرية بالمهلة الافتراضية
+func (q QueryTimeoutsConfig) Effective() QueryTimeoutsConfig {
+	orDefault := func(d time.Duration) time.Duration {
+		if d > 0 {
+			return d
+		}
+		return q.Default
+	}
+	return QueryTimeoutsConfig{
+		Default: q.Default,
+		Read:    orDefault(q.Read),
+		List:    orDefault(q.List),
+		Search:  orDefault(q.Search),
+		Write:   orDefault(q.Write),
+		Bulk:    orDefault(q.Bulk),
+	}
+}
+
+// DatabaseConfig أجزاء سلسلة الاتصال بقاعدة البيانات وإعدادات مجمع الاتصالات
+type DatabaseConfig struct {
+	// Driver نوع قاعدة البيانات: postgres أو mysql أو sqlite
+	Driver string `yaml:"driver"`
+	// Path مسار ملف SQLite، أو ":memory:" لقاعدة بيانات في الذاكرة
+	Path string `yaml:"path"`
+	// MigrateOnStart يطبّق الترحيلات المعلّقة عند الإقلاع بدلاً من رفض التشغيل
+	MigrateOnStart bool `yaml:"migrate_on_start"`
+
+	Host            string        `yaml:"host"`
+	Port            int           `yaml:"port"`
+	User            string        `yaml:"user"`
+	Password        string        `yaml:"password"`
+	Name            string        `yaml:"name"`
+	SSLMode         string        `yaml:"sslmode"`
+	TimeZone        string        `yaml:"timezone"`
+	MaxOpenConns    int           `yaml:"max_open_conns"`
+	MaxIdleConns    int           `yaml:"max_idle_conns"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime"`
+}
+
+// LogConfig إعدادات السجلات
+type LogConfig struct {
+	Level string `yaml:"level"`
+}
+
+// FeaturesConfig مفاتيح تشغيل/إيقاف الميزات الاختيارية
+type FeaturesConfig struct {
+	RequestLogging bool `yaml:"request_logging"`
+	// RequireIfMatch يرفض تحديثات PUT التي لا تحمل ترويسة If-Match (428)، وإلا تبقى اختيارية
+	RequireIfMatch bool `yaml:"require_if_match"`
+}
+
+// AuthConfig إعدادات صلاحيات العمليات الإدارية
+type AuthConfig struct {
+	// AdminToken الرمز الذي يرسله المشرف في Authorization: Bearer، والقيمة الفارغة تعطّل العمليات الإدارية
+	AdminToken string `yaml:"admin_token"`
+}
+
+// TrashConfig إعدادات سلة المقالات المحذوفة منطقيًا
+type TrashConfig struct {
+	// RetentionDays عدد الأيام التي يبقى فيها المقال المحذوف قبل حذفه نهائيًا، والصفر يعطّل الحذف التلقائي
+	RetentionDays int `yaml:"retention_days"`
+	// PurgeInterval الفاصل بين دورات مهمة الحذف النهائي
+	PurgeInterval time.Duration `yaml:"purge_interval"`
+}
+
+// SchedulerConfig إعدادات مهمة نشر المقالات المجدولة
+type SchedulerConfig struct {
+	// PublishInterval الفاصل بين دورات نشر المقالات التي حان موعدها، والصفر يعطّل المهمة في هذا التطبيق
+	// تشغيلها في عدة تطبيقات معًا آمن لأن كل مقال ينتقل إلى published بتحديث شرطي واحد
+	PublishInterval time.Duration `yaml:"publish_interval"`
+}
+
+// CommentsConfig إعدادات تعليقات القراء
+type CommentsConfig struct {
+	// EditWindow المدة التي يستطيع فيها كاتب التعليق تعديله بعد إنشائه، والصفر يمنع التعديل
+	EditWindow time.Duration `yaml:"edit_window"`
+}
+
+// CacheConfig إعدادات التخزين المؤقت للقراءات على Redis
+type CacheConfig struct {
+	// RedisAddr عنوان Redis (host:port)، والقيمة الفارغة تعطّل التخزين المؤقت
+	RedisAddr     string `yaml:"redis_addr"`
+	RedisPassword string `yaml:"redis_password"`
+	RedisDB       int    `yaml:"redis_db"`
+	// KeyPrefix بادئة كل المفاتيح حتى تتشارك عدة تطبيقات خادم Redis نفسه
+	KeyPrefix string `yaml:"key_prefix"`
+	// ItemTTL مدة بقاء السجل الواحد (مقال أو مؤلف)
+	ItemTTL time.Duration `yaml:"item_ttl"`
+	// ListTTL مدة بقاء صفحات القوائم، وهي أقصر لأنها تتغير مع أي كتابة
+	ListTTL time.Duration `yaml:"list_ttl"`
+}
+
+// Enabled يعيد true إذا حُدد عنوان Redis
+func (c CacheConfig) Enabled() bool {
+	return c.RedisAddr != ""
+}
+
+// أنواع التخزين المدعومة؛ StorageDatabase اسم بديل يحوّله Validate إلى StoragePostgres
+const (
+	StoragePostgres = "postgres"
+	StorageDatabase = "database"
+	StorageMemory   = "memory"
+)
+
+// أنواع قواعد البيانات المدعومة
+const (
+	DriverPostgres = "postgres"
+	DriverMySQL    = "mysql"
+	DriverSQLite   = "sqlite"
+)
+
+// SQLiteMemory قيمة Path التي تعني قاعدة SQLite في الذاكرة
+const SQLiteMemory = ":memory:"
+
+// مستويات السجل المقبولة
+const (
+	LogLevelDebug = "debug"
+	LogLevelInfo  = "info"
+	LogLevelWarn  = "warn"
+	LogLevelError = "error"
+)
+
+// Default يعيد الإعدادات الافتراضية المناسبة لبيئة التطوير المحلية
+func Default() *Config {
+	return &Config{
+		Storage: StoragePostgres,
+		Server: ServerConfig{
+			Addr:         ":3000",
+			ReadTimeout:  10 * time.Second,
+			WriteTimeout: 10 * time.Second,
+			IdleTimeout:  60 * time.Second,
+
+			ShutdownTimeout: 15 * time.Second,
+			QueryTimeouts: QueryTimeoutsConfig{
+				Default: 5 * time.Second,
+				Search:  10 * time.Second,
+				Bulk:    30 * time.Second,
+			},
+		},
+		Database: DatabaseConfig{
+			Driver:          DriverPostgres,
+			Path:            "article.db",
+			Host:            "localhost",
+			Port:            5432,
+			User:            "postgres",
+			Name:            "article_db",
+			SSLMode:         "disable",
+			TimeZone:        "Asia/Shanghai",
+			MaxOpenConns:    25,
+			MaxIdleConns:    5,
+			ConnMaxLifetime: 30 * time.Minute,
+		},
+		Log: LogConfig{Level: LogLevelInfo},
+		Trash: TrashConfig{
+			RetentionDays: 30,
+			PurgeInterval: time.Hour,
+		},
+		Cache: CacheConfig{
+			KeyPrefix: "my-article-app:",
+			ItemTTL:   5 * time.Minute,
+			ListTTL:   30 * time.Second,
+		},
+		Scheduler: SchedulerConfig{PublishInterval: time.Minute},
+		Comments:  CommentsConfig{EditWindow: 15 * time.Minute},
+	}
+}
+
+// DSN يبني سلسلة الاتصال المناسبة لنوع قاعدة البيانات من الأجزاء المنفصلة
+func (d DatabaseConfig) DSN() string {
+	switch d.Driver {
+	case DriverMySQL:
+		// multiStatements مطلوب لتنفيذ ملفات الترحيل التي تحتوي على أكثر من جملة
+		return fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?charset=utf8mb4&parseTime=True&loc=UTC&multiStatements=true",
+			d.User, d.Password, d.Host, d.Port, d.Name)
+	case DriverSQLite:
+		if d.IsSQLiteMemory() {
+			return "file::memory:?_foreign_keys=on"
+		}
+		return fmt.Sprintf("file:%s?_foreign_keys=on&_busy_timeout=5000", d.Path)
+	default:
+		return d.postgresDSN()
+	}
+}
+
+// IsSQLiteMemory يحدد ما إذا كانت الإعدادات تشير إلى قاعدة SQLite في الذاكرة
+func (d DatabaseConfig) IsSQLiteMemory() bool {
+	return d.Driver == DriverSQLite && d.Path == SQLiteMemory
+}
+
+// postgresDSN يبني سلسلة اتصال PostgreSQL
+// تُحاط القيم بعلامات اقتباس حتى لا تفسد القيم الفارغة أو المحتوية على مسافات بقية السلسلة
+func (d DatabaseConfig) postgresDSN() string {
+	return fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%d sslmode=%s TimeZone=%s",
+		quoteDSN(d.Host), quoteDSN(d.User), quoteDSN(d.Password), quoteDSN(d.Name),
+		d.Port, quoteDSN(d.SSLMode), quoteDSN(d.TimeZone))
+}
+
+// quoteDSN يقتبس قيمة بصيغة libpq (key='value')
+func quoteDSN(v string) string {
+	v = strings.ReplaceAll(v, `\`, `\\`)
+	v = strings.ReplaceAll(v, `'`, `\'`)
+	return "'" + v + "'"
+}
+
+// Args يعيد الوسائط المتبقية بعد تحليل أعلام سطر الأوامر
+func (c *Config) Args() []string {
+	return c.args
+}
+
+// binding يربط حقلاً في الإعدادات بمتغير بيئة وعلم في سطر الأوامر
+type binding struct {
+	env    string
+	flag   string
+	usage  string
+	target any
+}
+
+func bindings(c *Config) []binding {
+	return []binding{
+		{"STORAGE", "storage", "مكان حفظ البيانات (postgres|memory)", &c.Storage},
+		{"SERVER_ADDR", "addr", "عنوان الاستماع للخادم", &c.Server.Addr},
+		{"SERVER_READ_TIMEOUT", "read-timeout", "مهلة قراءة الطلب", &c.Server.ReadTimeout},
+		{"SERVER_WRITE_TIMEOUT", "write-timeout", "مهلة كتابة الاستجابة", &c.Server.WriteTimeout},
+		{"SERVER_IDLE_TIMEOUT", "idle-timeout", "مهلة الاتصالات الخاملة", &c.Server.IdleTimeout},
+		{"SERVER_SHUTDOWN_TIMEOUT", "shutdown-timeout", "مهلة تصريف الطلبات عند الإغلاق", &c.Server.ShutdownTimeout},
+		{"QUERY_TIMEOUT_DEFAULT", "query-timeout", "المهلة الافتراضية لاستعلامات قاعدة البيانات", &c.Server.QueryTimeouts.Default},
+		{"QUERY_TIMEOUT_READ", "query-timeout-read", "مهلة استعلامات جلب عنصر واحد", &c.Server.QueryTimeouts.Read},
+		{"QUERY_TIMEOUT_LIST", "query-timeout-list", "مهلة استعلامات القوائم", &c.Server.QueryTimeouts.List},
+		{"QUERY_TIMEOUT_SEARCH", "query-timeout-search", "مهلة استعلامات البحث", &c.Server.QueryTimeouts.Search},
+		{"QUERY_TIMEOUT_WRITE", "query-timeout-write", "مهلة عمليات الكتابة", &c.Server.QueryTimeouts.Write},
+		{"QUERY_TIMEOUT_BULK", "query-timeout-bulk", "مهلة العمليات المجمّعة", &c.Server.QueryTimeouts.Bulk},
+		{"DB_DRIVER", "db-driver", "نوع قاعدة البيانات (postgres|mysql|sqlite)", &c.Database.Driver},
+		{"DB_PATH", "db-path", "مسار ملف SQLite أو :memory:", &c.Database.Path},
+		{"DB_MIGRATE_ON_START", "db-migrate-on-start", "تطبيق الترحيلات المعلّقة عند الإقلاع", &c.Database.MigrateOnStart},
+		{"DB_HOST", "db-host", "مضيف قاعدة البيانات", &c.Database.Host},
+		{"DB_PORT", "db-port", "منفذ قاعدة البيانات", &c.Database.Port},
+		{"DB_USER", "db-user", "مستخدم قاعدة البيانات", &c.Database.User},
+		{"DB_PASSWORD", "db-password", "كلمة مرور قاعدة البيانات", &c.Database.Password},
+		{"DB_NAME", "db-name", "اسم قاعدة البيانات", &c.Database.Name},
+		{"DB_SSLMODE", "db-sslmode", "وضع SSL للاتصال", &c.Database.SSLMode},
+		{"DB_TIMEZONE", "db-timezone", "المنطقة الزمنية للاتصال", &c.Database.TimeZone},
+		{"DB_MAX_OPEN_CONNS", "db-max-open-conns", "الحد الأقصى للاتصالات المفتوحة", &c.Database.MaxOpenConns},
+		{"DB_MAX_IDLE_CONNS", "db-max-idle-conns", "الحد الأقصى للاتصالات الخاملة", &c.Database.MaxIdleConns},
+		{"DB_CONN_MAX_LIFETIME", "db-conn-max-lifetime", "أقصى عمر للاتصال", &c.Database.ConnMaxLifetime},
+		{"LOG_LEVEL", "log-level", "مستوى السجل (debug|info|warn|error)", &c.Log.Level},
+		{"FEATURE_REQUEST_LOGGING", "feature-request-logging", "تفعيل تسجيل الطلبات", &c.Features.RequestLogging},
+		{"FEATURE_REQUIRE_IF_MATCH", "feature-require-if-match", "اشتراط ترويسة If-Match في طلبات التحديث", &c.Features.RequireIfMatch},
+		{"ADMIN_TOKEN", "admin-token", "رمز المشرف للعمليات الإدارية مثل الحذف النهائي", &c.Auth.AdminToken},
+		{"TRASH_RETENTION_DAYS", "trash-retention-days", "أيام الاحتفاظ بالمقالات المحذوفة قبل حذفها نهائيًا (0 للتعطيل)", &c.Trash.RetentionDays},
+		{"TRASH_PURGE_INTERVAL", "trash-purge-interval", "الفاصل بين دورات الحذف النهائي لسلة المحذوفات", &c.Trash.PurgeInterval},
+		{"REDIS_ADDR", "redis-addr", "عنوان Redis للتخزين المؤقت (فارغ للتعطيل)", &c.Cache.RedisAddr},
+		{"REDIS_PASSWORD", "redis-password", "كلمة مرور Redis", &c.Cache.RedisPassword},
+		{"REDIS_DB", "redis-db", "رقم قاعدة Redis", &c.Cache.RedisDB},
+		{"CACHE_KEY_PREFIX", "cache-key-prefix", "بادئة مفاتيح التخزين المؤقت", &c.Cache.KeyPrefix},
+		{"CACHE_ITEM_TTL", "cache-item-ttl", "مدة بقاء المقال أو المؤلف في التخزين المؤقت", &c.Cache.ItemTTL},
+		{"CACHE_LIST_TTL", "cache-list-ttl", "مدة بقاء صفحات القوائم في التخزين المؤقت", &c.Cache.ListTTL},
+		{"SCHEDULER_PUBLISH_INTERVAL", "scheduler-publish-interval", "الفاصل بين دورات نشر المقالات المجدولة (0 للتعطيل)", &c.Scheduler.PublishInterval},
+		{"COMMENT_EDIT_WINDOW", "comment-edit-window", "مدة السماح بتعديل التعليق بعد إنشائه (0 لمنع التعديل)", &c.Comments.EditWindow},
+	}
+}
+
+// Load يحمّل الإعدادات من الملف ومتغيرات البيئة وأعلام سطر الأوامر ثم يتحقق من صحتها
+// مسار الملف الاختياري يُحدد بالعلم --config أو بمتغير البيئة CONFIG_FILE
+func Load(args []string) (*Config, error) {
+	cfg := Default()
+
+	fs := flag.NewFlagSet("my-article-app", flag.ContinueOnError)
+	configPath := fs.String("config", os.Getenv("CONFIG_FILE"), "مسار ملف الإعدادات (YAML)")
+
+	// نجمع قيم الأعلام أولاً ثم نطبقها بعد الملف والبيئة للحفاظ على ترتيب الأولوية
+	flagValues := map[string]string{}
+	for _, b := range bindings(cfg) {
+		name := b.flag
+		fs.Func(name, b.usage, func(v string) error {
+			flagValues[name] = v
+			return nil
+		})
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if *configPath != "" {
+		if err := loadFile(cfg, *configPath); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, b := range bindings(cfg) {
+		if v, ok := os.LookupEnv(b.env); ok {
+			if err := setValue(b.target, v); err != nil {
+				return nil, fmt.Errorf("قيمة غير صالحة لمتغير البيئة %s: %w", b.env, err)
+			}
+		}
+	}
+
+	for _, b := range bindings(cfg) {
+		if v, ok := flagValues[b.flag]; ok {
+			if err := setValue(b.target, v); err != nil {
+				return nil, fmt.Errorf("قيمة غير صالحة للعلم --%s: %w", b.flag, err)
+			}
+		}
+	}
+
+	cfg.args = fs.Args()
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// loadFile يقرأ ملف YAML ويدمجه فوق القيم الحالية
+// المفتاح غير المعروف (مثل خطأ إملائي في databse) يفشل الإقلاع بدل تجاهله والعمل بالقيم الافتراضية
+func loadFile(cfg *Config, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("فشل قراءة ملف الإعدادات %s: %w", path, err)
+	}
+	defer f.Close()
+
+	decoder := yaml.NewDecoder(f)
+	decoder.KnownFields(true)
+	// الملف الفارغ لا يغيّر شيئًا
+	if err := decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("فشل تحليل ملف الإعدادات %s: %w", path, err)
+	}
+	return nil
+}
+
+// setValue يحوّل القيمة النصية إلى نوع الحقل المستهدف
+func setValue(target any, raw string) error {
+	switch t := target.(type) {
+	case *string:
+		*t = raw
+	case *int:
+		v, err := strconv.Atoi(raw)
+		if err != nil {
+			return err
+		}
+		*t = v
+	case *bool:
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		*t = v
+	case *time.Duration:
+		v, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		*t = v
+	default:
+		return fmt.Errorf("نوع حقل غير مدعوم %T", target)
+	}
+	return nil
+}
+
+// Validate يتحقق من صحة الإعدادات ويجمع كل الأخطاء في رسالة واحدة
+func (c *Config) Validate() error {
+	var errs []error
+
+	if c.Server.Addr == "" {
+		errs = append(errs, errors.New("server.addr مطلوب"))
+	}
+	if c.Server.ReadTimeout < 0 || c.Server.WriteTimeout < 0 || c.Server.IdleTimeout < 0 || c.Server.ShutdownTimeout < 0 {
+		errs = append(errs, errors.New("مهلات الخادم لا يمكن أن تكون سالبة"))
+	}
+	qt := c.Server.QueryTimeouts
+	if qt.Default < 0 || qt.Read < 0 || qt.List < 0 || qt.Search < 0 || qt.Write < 0 || qt.Bulk < 0 {
+		errs = append(errs, errors.New("مهلات الاستعلامات لا يمكن أن تكون سالبة"))
+	}
+
+	switch c.Storage {
+	case StoragePostgres, StorageDatabase:
+		c.Storage = StoragePostgres
+		errs = append(errs, c.Database.validate()...)
+	case StorageMemory:
+		// التخزين في الذاكرة لا يستخدم إعدادات قاعدة البيانات
+	default:
+		errs = append(errs, fmt.Errorf("storage غير مدعوم: %q", c.Storage))
+	}
+
+	if c.Trash.RetentionDays < 0 {
+		errs = append(errs, errors.New("trash.retention_days لا يمكن أن يكون سالبًا"))
+	}
+	if c.Trash.RetentionDays > 0 && c.Trash.PurgeInterval <= 0 {
+		errs = append(errs, errors.New("trash.purge_interval يجب أن يكون موجبًا عند تفعيل الحذف التلقائي"))
+	}
+
+	if c.Scheduler.PublishInterval < 0 {
+		errs = append(errs, errors.New("scheduler.publish_interval لا يمكن أن يكون سالبًا"))
+	}
+	if c.Comments.EditWindow < 0 {
+		errs = append(errs, errors.New("comments.edit_window لا يمكن أن يكون سالبًا"))
+	}
+
+	if c.Cache.Enabled() {
+		if c.Cache.ItemTTL <= 0 || c.Cache.ListTTL <= 0 {
+			errs = append(errs, errors.New("cache.item_ttl و cache.list_ttl يجب أن تكون موجبة عند تفعيل التخزين المؤقت"))
+		}
+		if c.Cache.RedisDB < 0 {
+			errs = append(errs, errors.New("cache.redis_db لا يمكن أن يكون سالبًا"))
+		}
+	}
+
+	switch strings.ToLower(c.Log.Level) {
+	case LogLevelDebug, LogLevelInfo, LogLevelWarn, LogLevelError:
+		c.Log.Level = strings.ToLower(c.Log.Level)
+	default:
+		errs = append(errs, fmt.Errorf("log.level غير صالح: %q", c.Log.Level))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("إعدادات غير صالحة: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// validate يتحقق من إعدادات قاعدة البيانات، ولا يُستدعى إلا مع storage=postgres
+func (d DatabaseConfig) validate() []error {
+	var errs []error
+
+	switch d.Driver {
+	case DriverSQLite:
+		if d.Path == "" {
+			errs = append(errs, errors.New("database.path مطلوب مع sqlite"))
+		}
+	case DriverPostgres, DriverMySQL:
+		if d.Host == "" {
+			errs = append(errs, errors.New("database.host مطلوب"))
+		}
+		if d.Port <= 0 || d.Port > 65535 {
+			errs = append(errs, fmt.Errorf("database.port غير صالح: %d", d.Port))
+		}
+		if d.User == "" {
+			errs = append(errs, errors.New("database.user مطلوب"))
+		}
+		if d.Name == "" {
+			errs = append(errs, errors.New("database.name مطلوب"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("database.driver غير مدعوم: %q", d.Driver))
+	}
+	if d.Driver == DriverPostgres {
+		switch d.SSLMode {
+		case "disable", "allow", "prefer", "require", "verify-ca", "verify-full":
+		default:
+			errs = append(errs, fmt.Errorf("database.sslmode غير صالح: %q", d.SSLMode))
+		}
+	}
+	if d.MaxOpenConns < 0 || d.MaxIdleConns < 0 {
+		errs = append(errs, errors.New("أعداد اتصالات قاعدة البيانات لا يمكن أن تكون سالبة"))
+	}
+	return errs
+}
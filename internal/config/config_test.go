@@ -0,0 +1,223 @@
+// my-article-app/internal/config/config_test.go
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// clearEnv يزيل متغيرات البيئة التي يقرؤها Load حتى لا تؤثر بيئة الجهاز في الاختبار، ويعيدها بعده
+func clearEnv(t *testing.T) {
+	t.Helper()
+	names := []string{"CONFIG_FILE"}
+	for _, b := range bindings(Default()) {
+		names = append(names, b.env)
+	}
+	for _, name := range names {
+		if old, ok := os.LookupEnv(name); ok {
+			t.Setenv(name, old)
+			os.Unsetenv(name)
+		}
+	}
+}
+
+// writeFile يكتب ملف إعدادات مؤقتًا ويعيد مساره
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadDefaults(t *testing.T) {
+	clearEnv(t)
+
+	cfg, err := Load(nil)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	want := Default()
+	if cfg.Server.Addr != want.Server.Addr || cfg.Database.Driver != want.Database.Driver || cfg.Storage != want.Storage {
+		t.Errorf("Load() = %+v, want defaults %+v", cfg, want)
+	}
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	clearEnv(t)
+	path := writeFile(t, `
+server:
+  addr: ":4000"
+  read_timeout: 3s
+database:
+  host: file-host
+  name: file-db
+log:
+  level: warn
+`)
+	t.Setenv("SERVER_ADDR", ":5000")
+	t.Setenv("DB_HOST", "env-host")
+
+	cfg, err := Load([]string{"--config", path, "--addr", ":6000"})
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{"flag over env and file", cfg.Server.Addr, ":6000"},
+		{"env over file", cfg.Database.Host, "env-host"},
+		{"file over default", cfg.Database.Name, "file-db"},
+		{"file duration", cfg.Server.ReadTimeout, 3 * time.Second},
+		{"file level", cfg.Log.Level, LogLevelWarn},
+		{"default kept", cfg.Database.Port, 5432},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestLoadConfigFileFromEnv(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("CONFIG_FILE", writeFile(t, "server:\n  addr: \":4100\"\n"))
+
+	cfg, err := Load(nil)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Server.Addr != ":4100" {
+		t.Errorf("Server.Addr = %q, want %q", cfg.Server.Addr, ":4100")
+	}
+}
+
+func TestLoadExampleFile(t *testing.T) {
+	clearEnv(t)
+
+	if _, err := Load([]string{"--config", "../../config.example.yaml"}); err != nil {
+		t.Fatalf("config.example.yaml: %v", err)
+	}
+}
+
+func TestLoadEmptyFile(t *testing.T) {
+	clearEnv(t)
+
+	cfg, err := Load([]string{"--config", writeFile(t, "")})
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Server.Addr != Default().Server.Addr {
+		t.Errorf("Server.Addr = %q, want default", cfg.Server.Addr)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		env     map[string]string
+		args    []string
+		wantErr string
+	}{
+		{name: "unknown top-level key", file: "databse:\n  host: x\n", wantErr: "databse"},
+		{name: "unknown nested key", file: "server:\n  adr: \":1\"\n", wantErr: "adr"},
+		{name: "malformed yaml", file: "server: [", wantErr: "فشل تحليل ملف الإعدادات"},
+		{name: "missing file", args: []string{"--config", "/nonexistent/config.yaml"}, wantErr: "فشل قراءة ملف الإعدادات"},
+		{name: "bad env value", env: map[string]string{"DB_PORT": "abc"}, wantErr: "DB_PORT"},
+		{name: "bad flag value", args: []string{"--read-timeout", "soon"}, wantErr: "--read-timeout"},
+		{name: "unknown flag", args: []string{"--no-such-flag"}, wantErr: "no-such-flag"},
+		{name: "unsupported storage", args: []string{"--storage", "disk"}, wantErr: "storage غير مدعوم"},
+		{name: "unsupported driver", args: []string{"--db-driver", "oracle"}, wantErr: "database.driver غير مدعوم"},
+		{name: "sqlite without path", args: []string{"--db-driver", "sqlite", "--db-path", ""}, wantErr: "database.path"},
+		{name: "bad port", args: []string{"--db-port", "70000"}, wantErr: "database.port"},
+		{name: "bad sslmode", args: []string{"--db-sslmode", "sometimes"}, wantErr: "database.sslmode"},
+		{name: "bad log level", args: []string{"--log-level", "loud"}, wantErr: "log.level"},
+		{name: "negative timeout", args: []string{"--idle-timeout", "-1s"}, wantErr: "مهلات الخادم"},
+		{name: "negative query timeout", args: []string{"--query-timeout-bulk", "-1s"}, wantErr: "مهلات الاستعلامات"},
+		{name: "negative edit window", args: []string{"--comment-edit-window", "-1m"}, wantErr: "comments.edit_window"},
+		{name: "cache without ttl", args: []string{"--redis-addr", "localhost:6379", "--cache-item-ttl", "0s"}, wantErr: "cache.item_ttl"},
+		{name: "purge without interval", args: []string{"--trash-purge-interval", "0s"}, wantErr: "trash.purge_interval"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv(t)
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			args := tt.args
+			if tt.file != "" {
+				args = append([]string{"--config", writeFile(t, tt.file)}, args...)
+			}
+
+			_, err := Load(args)
+			if err == nil {
+				t.Fatalf("Load(%v) succeeded, want error containing %q", args, tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Load(%v) error = %q, want it to contain %q", args, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateCollectsAllErrors(t *testing.T) {
+	cfg := Default()
+	cfg.Server.Addr = ""
+	cfg.Log.Level = "loud"
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("Validate succeeded, want error")
+	}
+	for _, want := range []string{"server.addr", "log.level"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Validate error = %q, want it to contain %q", err, want)
+		}
+	}
+}
+
+func TestValidateNormalizesLogLevel(t *testing.T) {
+	cfg := Default()
+	cfg.Log.Level = "DEBUG"
+
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	if cfg.Log.Level != LogLevelDebug {
+		t.Errorf("Log.Level = %q, want %q", cfg.Log.Level, LogLevelDebug)
+	}
+}
+
+func TestLoadStorage(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{nil, StoragePostgres},
+		{[]string{"--storage=postgres"}, StoragePostgres},
+		// database اسم بديل لـ postgres
+		{[]string{"--storage=database"}, StoragePostgres},
+		// التخزين في الذاكرة لا يتحقق من إعدادات قاعدة البيانات
+		{[]string{"--storage=memory", "--db-driver=oracle"}, StorageMemory},
+	}
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			clearEnv(t)
+			cfg, err := Load(tt.args)
+			if err != nil {
+				t.Fatalf("Load(%v): %v", tt.args, err)
+			}
+			if cfg.Storage != tt.want {
+				t.Errorf("Storage = %q, want %q", cfg.Storage, tt.want)
+			}
+		})
+	}
+}
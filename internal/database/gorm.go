@@ -2,31 +2,115 @@
 package database
 
 import (
+	"context"
 	"fmt"
 	"log"
-	"my-article-app/internal/models" 
+	"my-article-app/internal/config"
 
+	"gorm.io/driver/mysql"
 	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
 )
 
-// InitGORMDB تهيئ اتصال GORM بقاعدة بيانات PostgreSQL
-func InitGORMDB() (*gorm.DB, error) {
-	// هنا نضع سلسلة الاتصال مباشرةً (للتطبيق التعليمي فقط، في الإنتاج استخدم متغيرات البيئة)
-	dsn := "host=localhost user=postgres password=311 dbname=article_db port=5432 sslmode=disable TimeZone=Asia/Shanghai"
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+// dialector يختار مشغّل GORM المناسب لنوع قاعدة البيانات في الإعدادات
+func dialector(cfg config.DatabaseConfig) (gorm.Dialector, error) {
+	switch cfg.Driver {
+	case config.DriverPostgres:
+		return postgres.Open(cfg.DSN()), nil
+	case config.DriverMySQL:
+		return mysql.Open(cfg.DSN()), nil
+	case config.DriverSQLite:
+		return sqlite.Open(cfg.DSN()), nil
+	default:
+		return nil, fmt.Errorf("نوع قاعدة بيانات غير مدعوم: %q", cfg.Driver)
+	}
+}
+
+// Open يفتح اتصال GORM بقاعدة البيانات المحددة في الإعدادات ويضبط مجمع الاتصالات دون تعديل المخطط
+func Open(cfg *config.Config) (*gorm.DB, error) {
+	dial, err := dialector(cfg.Database)
+	if err != nil {
+		return nil, err
+	}
+	db, err := gorm.Open(dial, &gorm.Config{
+		Logger: logger.Default.LogMode(gormLogLevel(cfg.Log.Level)),
+		// توحيد أخطاء القيود بين المشغّلات (gorm.ErrDuplicatedKey و gorm.ErrForeignKeyViolated)
+		TranslateError: true,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("فشل الاتصال بقاعدة البيانات باستخدام GORM: %w", err)
 	}
 
-	// ترحيل (Migrate) مخطط قاعدة البيانات لإنشاء/تحديث جدول Articles
-	// AutoMigrate سيقوم بإنشاء الجدول بناءً على بنية Article إذا لم يكن موجودًا.
-	// وسيقوم بتحديث الأعمدة إذا أضفت حقولًا جديدة.
-	err = db.AutoMigrate(&models.Article{},&models.Author{})
+	// ضبط مجمع الاتصالات الأساسي
+	sqlDB, err := db.DB()
 	if err != nil {
-		return nil, fmt.Errorf("فشل ترحيل قاعدة البيانات لجدول Articles: %w", err)
+		return nil, fmt.Errorf("فشل الحصول على اتصال قاعدة البيانات الأساسي: %w", err)
+	}
+	if cfg.Database.IsSQLiteMemory() {
+		// كل اتصال بقاعدة SQLite في الذاكرة يرى قاعدة مستقلة، لذا نقتصر على اتصال واحد دائم
+		sqlDB.SetMaxOpenConns(1)
+		sqlDB.SetMaxIdleConns(1)
+		sqlDB.SetConnMaxLifetime(0)
+	} else {
+		sqlDB.SetMaxOpenConns(cfg.Database.MaxOpenConns)
+		sqlDB.SetMaxIdleConns(cfg.Database.MaxIdleConns)
+		sqlDB.SetConnMaxLifetime(cfg.Database.ConnMaxLifetime)
 	}
 
-	log.Println("تم الاتصال بقاعدة البيانات وترحيل جداول GORM بنجاح!")
 	return db, nil
-}
\ No newline at end of file
+}
+
+// InitGORMDB تهيئ اتصال GORM بقاعدة البيانات وترفض الإقلاع إذا كان المخطط متأخرًا عن الترحيلات
+// الترحيلات لا تُطبّق هنا إلا إذا فُعّل MigrateOnStart أو كانت القاعدة SQLite في الذاكرة،
+// وفي غير ذلك تُطبّق عبر الأمر: go run ./cmd/migrate up
+func InitGORMDB(cfg *config.Config) (*gorm.DB, error) {
+	db, err := Open(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		_ = Close(db)
+		return nil, err
+	}
+	if cfg.Database.MigrateOnStart || cfg.Database.IsSQLiteMemory() {
+		if _, err := migrator.Up(context.Background()); err != nil {
+			_ = Close(db)
+			return nil, err
+		}
+	}
+	if err := migrator.EnsureUpToDate(context.Background()); err != nil {
+		_ = Close(db)
+		return nil, err
+	}
+
+	log.Println("تم الاتصال بقاعدة البيانات والتحقق من أن المخطط محدّث!")
+	return db, nil
+}
+
+// gormLogLevel يحوّل مستوى السجل في الإعدادات إلى مستوى سجل GORM
+func gormLogLevel(level string) logger.LogLevel {
+	switch level {
+	case config.LogLevelDebug:
+		return logger.Info
+	case config.LogLevelInfo, config.LogLevelWarn:
+		return logger.Warn
+	default:
+		return logger.Error
+	}
+}
+
+// Close يغلق مجمع الاتصالات الأساسي (*sql.DB) الخاص باتصال GORM
+func Close(db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("فشل الحصول على اتصال قاعدة البيانات الأساسي: %w", err)
+	}
+	if err := sqlDB.Close(); err != nil {
+		return fmt.Errorf("فشل إغلاق اتصال قاعدة البيانات: %w", err)
+	}
+	return nil
+}
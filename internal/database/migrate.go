@@ -0,0 +1,262 @@
+// my-article-app/internal/database/migrate.go
+package database
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ملفات الترحيل مضمّنة في الملف التنفيذي ومقسّمة حسب نوع قاعدة البيانات
+// الأرقام مشتركة بين الأنواع، ويجوز أن يغيب رقم عن نوع لا يحتاجه: 0002_article_search
+// (عمود tsvector وفهرس GIN) خاص بـ PostgreSQL، وMySQL وSQLite يستخدمان بحث LIKE البديل بلا ترحيل
+//
+//go:embed migrations
+var migrationsFS embed.FS
+
+// اسم ملف الترحيل: <رقم>_<اسم>.(up|down).sql
+var migrationFileRe = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// ErrSchemaBehind يُرجع عندما تكون هناك ترحيلات لم تُطبّق بعد على قاعدة البيانات
+var ErrSchemaBehind = errors.New("مخطط قاعدة البيانات غير محدّث")
+
+// Migration ترحيل واحد بنسختيه الصاعدة والنازلة
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus حالة ترحيل واحد في قاعدة البيانات
+type MigrationStatus struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+// schemaMigration سجل في جدول schema_migrations
+type schemaMigration struct {
+	Version   int64  `gorm:"primaryKey;autoIncrement:false"`
+	Name      string `gorm:"not null"`
+	AppliedAt time.Time
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// Migrator يطبّق الترحيلات المرقّمة ويتتبعها في جدول schema_migrations
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+// NewMigrator ينشئ Migrator يحمّل ترحيلات نوع قاعدة البيانات المستخدمة
+func NewMigrator(db *gorm.DB) (*Migrator, error) {
+	migrations, err := loadMigrations(db.Dialector.Name())
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// loadMigrations يقرأ ملفات الترحيل المضمّنة لنوع قاعدة بيانات محدد ويرتبها حسب الرقم
+func loadMigrations(dialect string) ([]Migration, error) {
+	dir := path.Join("migrations", dialect)
+	entries, err := fs.ReadDir(migrationsFS, dir)
+	if err != nil {
+		return nil, fmt.Errorf("لا توجد ترحيلات لقاعدة البيانات %q: %w", dialect, err)
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, entry := range entries {
+		match := migrationFileRe.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("رقم ترحيل غير صالح في %s: %w", entry.Name(), err)
+		}
+		content, err := fs.ReadFile(migrationsFS, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("فشل قراءة ملف الترحيل %s: %w", entry.Name(), err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		} else if m.Name != match[2] {
+			return nil, fmt.Errorf("اسمان مختلفان للترحيل رقم %d: %q و %q", version, m.Name, match[2])
+		}
+		if match[3] == "up" {
+			m.Up = string(content)
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("الترحيل رقم %d (%s) لا يحتوي على ملف up", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// ensureTable ينشئ جدول تتبع الترحيلات إذا لم يكن موجودًا
+func (m *Migrator) ensureTable(ctx context.Context) error {
+	if err := m.db.WithContext(ctx).AutoMigrate(&schemaMigration{}); err != nil {
+		return fmt.Errorf("فشل إنشاء جدول schema_migrations: %w", err)
+	}
+	return nil
+}
+
+// applied يعيد الترحيلات المطبّقة مفهرسة حسب الرقم
+func (m *Migrator) applied(ctx context.Context) (map[int64]schemaMigration, error) {
+	if err := m.ensureTable(ctx); err != nil {
+		return nil, err
+	}
+	var rows []schemaMigration
+	if err := m.db.WithContext(ctx).Order("version").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("فشل قراءة الترحيلات المطبّقة: %w", err)
+	}
+	result := make(map[int64]schemaMigration, len(rows))
+	for _, row := range rows {
+		result[row.Version] = row
+	}
+	return result, nil
+}
+
+// Status يعيد حالة جميع الترحيلات المعروفة
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	for _, mig := range m.migrations {
+		status := MigrationStatus{Migration: mig}
+		if row, ok := applied[mig.Version]; ok {
+			appliedAt := row.AppliedAt
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// Pending يعيد الترحيلات التي لم تُطبّق بعد
+func (m *Migrator) Pending(ctx context.Context) ([]Migration, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var pending []Migration
+	for _, mig := range m.migrations {
+		if _, ok := applied[mig.Version]; !ok {
+			pending = append(pending, mig)
+		}
+	}
+	return pending, nil
+}
+
+// Up يطبّق جميع الترحيلات المعلّقة بالترتيب، كل ترحيل داخل معاملة مستقلة
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	pending, err := m.Pending(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var done []Migration
+	for _, mig := range pending {
+		if err := m.apply(ctx, mig); err != nil {
+			return done, err
+		}
+		done = append(done, mig)
+	}
+	return done, nil
+}
+
+// apply يطبّق ترحيلاً واحدًا ويسجّله داخل نفس المعاملة
+func (m *Migrator) apply(ctx context.Context, mig Migration) error {
+	err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(mig.Up).Error; err != nil {
+			return err
+		}
+		return tx.Create(&schemaMigration{Version: mig.Version, Name: mig.Name, AppliedAt: time.Now()}).Error
+	})
+	if err != nil {
+		return fmt.Errorf("فشل تطبيق الترحيل %04d_%s: %w", mig.Version, mig.Name, err)
+	}
+	return nil
+}
+
+// Down يتراجع عن آخر ترحيل مطبّق ويعيده، أو يعيد nil إذا لم يكن هناك ما يُتراجع عنه
+func (m *Migrator) Down(ctx context.Context) (*Migration, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var last *Migration
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		if _, ok := applied[m.migrations[i].Version]; ok {
+			last = &m.migrations[i]
+			break
+		}
+	}
+	if last == nil {
+		return nil, nil
+	}
+	if last.Down == "" {
+		return nil, fmt.Errorf("الترحيل %04d_%s لا يدعم التراجع", last.Version, last.Name)
+	}
+
+	err = m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(last.Down).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&schemaMigration{}, last.Version).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("فشل التراجع عن الترحيل %04d_%s: %w", last.Version, last.Name, err)
+	}
+	return last, nil
+}
+
+// Redo يتراجع عن آخر ترحيل ثم يعيد تطبيقه
+func (m *Migrator) Redo(ctx context.Context) (*Migration, error) {
+	last, err := m.Down(ctx)
+	if err != nil || last == nil {
+		return last, err
+	}
+	if err := m.apply(ctx, *last); err != nil {
+		return nil, err
+	}
+	return last, nil
+}
+
+// EnsureUpToDate يعيد ErrSchemaBehind إذا كانت هناك ترحيلات معلّقة
+func (m *Migrator) EnsureUpToDate(ctx context.Context) error {
+	pending, err := m.Pending(ctx)
+	if err != nil {
+		return err
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("%w: %d ترحيل معلّق (أولها %04d_%s)، شغّل: go run ./cmd/migrate up",
+			ErrSchemaBehind, len(pending), pending[0].Version, pending[0].Name)
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+// my-article-app/internal/database/migrate_test.go
+package database
+
+import (
+	"context"
+	"errors"
+	"my-article-app/internal/config"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// openSQLite يفتح قاعدة SQLite فارغة في الذاكرة دون تطبيق أي ترحيل
+func openSQLite(t *testing.T) *gorm.DB {
+	t.Helper()
+	cfg := config.Default()
+	cfg.Database.Driver = config.DriverSQLite
+	cfg.Database.Path = config.SQLiteMemory
+	cfg.Log.Level = config.LogLevelError
+
+	db, err := Open(cfg)
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	t.Cleanup(func() { _ = Close(db) })
+	return db
+}
+
+func newTestMigrator(t *testing.T, db *gorm.DB) *Migrator {
+	t.Helper()
+	m, err := NewMigrator(db)
+	if err != nil {
+		t.Fatalf("NewMigrator: %v", err)
+	}
+	return m
+}
+
+func TestLoadMigrations(t *testing.T) {
+	versions := map[string][]int64{}
+	for _, dialect := range []string{"postgres", "mysql", "sqlite"} {
+		migrations, err := loadMigrations(dialect)
+		if err != nil {
+			t.Fatalf("loadMigrations(%q): %v", dialect, err)
+		}
+		for i, m := range migrations {
+			if m.Down == "" {
+				t.Errorf("%s %04d_%s has no down migration", dialect, m.Version, m.Name)
+			}
+			if i > 0 && migrations[i-1].Version >= m.Version {
+				t.Errorf("%s migrations out of order at %d", dialect, m.Version)
+			}
+			versions[dialect] = append(versions[dialect], m.Version)
+		}
+	}
+
+	// الأنواع تتشارك الأرقام، والفرق الوحيد 0002 الخاص بالبحث النصي في PostgreSQL
+	for _, dialect := range []string{"mysql", "sqlite"} {
+		var want []int64
+		for _, v := range versions["postgres"] {
+			if v != 2 {
+				want = append(want, v)
+			}
+		}
+		if len(versions[dialect]) != len(want) {
+			t.Fatalf("%s versions = %v, want %v", dialect, versions[dialect], want)
+		}
+		for i := range want {
+			if versions[dialect][i] != want[i] {
+				t.Fatalf("%s versions = %v, want %v", dialect, versions[dialect], want)
+			}
+		}
+	}
+
+	if _, err := loadMigrations("oracle"); err == nil {
+		t.Error("loadMigrations(oracle) succeeded, want error")
+	}
+}
+
+func TestMigratorRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+	m := newTestMigrator(t, db)
+	total := len(m.migrations)
+
+	if err := m.EnsureUpToDate(ctx); !errors.Is(err, ErrSchemaBehind) {
+		t.Fatalf("EnsureUpToDate on empty db: err = %v, want ErrSchemaBehind", err)
+	}
+
+	done, err := m.Up(ctx)
+	if err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+	if len(done) != total {
+		t.Fatalf("Up applied %d migrations, want %d", len(done), total)
+	}
+	if err := m.EnsureUpToDate(ctx); err != nil {
+		t.Fatalf("EnsureUpToDate after Up: %v", err)
+	}
+	if again, err := m.Up(ctx); err != nil || len(again) != 0 {
+		t.Fatalf("second Up = %d migrations, %v; want none", len(again), err)
+	}
+
+	redone, err := m.Redo(ctx)
+	if err != nil {
+		t.Fatalf("Redo: %v", err)
+	}
+	if redone.Version != m.migrations[total-1].Version {
+		t.Errorf("Redo reapplied %d, want the last migration", redone.Version)
+	}
+
+	// التراجع عن كل الترحيلات واحدًا واحدًا من الأحدث إلى الأقدم
+	for i := total - 1; i >= 0; i-- {
+		down, err := m.Down(ctx)
+		if err != nil {
+			t.Fatalf("Down: %v", err)
+		}
+		if down == nil || down.Version != m.migrations[i].Version {
+			t.Fatalf("Down reverted %v, want %d", down, m.migrations[i].Version)
+		}
+	}
+	if down, err := m.Down(ctx); down != nil || err != nil {
+		t.Fatalf("Down with nothing applied = %v, %v; want nil, nil", down, err)
+	}
+	if db.Migrator().HasTable("articles") || db.Migrator().HasTable("authors") {
+		t.Error("tables remain after reverting every migration")
+	}
+
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	for _, s := range statuses {
+		if s.AppliedAt != nil {
+			t.Errorf("migration %d still marked applied", s.Version)
+		}
+	}
+
+	if _, err := m.Up(ctx); err != nil {
+		t.Fatalf("Up after full rollback: %v", err)
+	}
+}
+
+// TestAuthorNotNullMigrationBackfills يتحقق أن فرض author_id لا يفشل على مقالات قديمة بلا مؤلف
+func TestAuthorNotNullMigrationBackfills(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+	m := newTestMigrator(t, db)
+	all := m.migrations
+
+	var before []Migration
+	for _, mig := range all {
+		if mig.Version < 6 {
+			before = append(before, mig)
+		}
+	}
+	m.migrations = before
+	if _, err := m.Up(ctx); err != nil {
+		t.Fatalf("Up to 0005: %v", err)
+	}
+	err := db.Exec(`INSERT INTO authors (name, email) VALUES ('kept', 'kept@example.com');
+		INSERT INTO articles (title, content, author_id) VALUES ('owned', 'x', 1), ('orphan', 'x', NULL), ('another orphan', 'x', NULL);`).Error
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	m.migrations = all
+	if _, err := m.Up(ctx); err != nil {
+		t.Fatalf("Up from 0005: %v", err)
+	}
+
+	var rows []struct {
+		Title string
+		Email string
+	}
+	err = db.Raw(`SELECT articles.title, authors.email FROM articles JOIN authors ON authors.id = articles.author_id ORDER BY articles.id`).
+		Scan(&rows).Error
+	if err != nil {
+		t.Fatalf("read articles: %v", err)
+	}
+	want := []string{"kept@example.com", "unknown-author@invalid", "unknown-author@invalid"}
+	if len(rows) != len(want) {
+		t.Fatalf("articles after migration = %+v, want 3 with authors", rows)
+	}
+	for i, row := range rows {
+		if row.Email != want[i] {
+			t.Errorf("article %q author = %q, want %q", row.Title, row.Email, want[i])
+		}
+	}
+}
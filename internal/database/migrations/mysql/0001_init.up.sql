@@ -0,0 +1,23 @@
+-- الجداول الأساسية للمؤلفين والمقالات
+CREATE TABLE IF NOT EXISTS authors (
+    id         BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    created_at DATETIME(3) NULL,
+    updated_at DATETIME(3) NULL,
+    deleted_at DATETIME(3) NULL,
+    name       LONGTEXT,
+    email      VARCHAR(191) NOT NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT uni_authors_email UNIQUE (email),
+    INDEX idx_authors_deleted_at (deleted_at)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
+
+CREATE TABLE IF NOT EXISTS articles (
+    id         BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    title      LONGTEXT,
+    content    LONGTEXT,
+    author_id  BIGINT UNSIGNED,
+    created_at DATETIME(3) NULL,
+    updated_at DATETIME(3) NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_authors_articles FOREIGN KEY (author_id) REFERENCES authors (id)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
@@ -0,0 +1,4 @@
+DROP INDEX idx_authors_name_normalized ON authors;
+DROP INDEX idx_articles_title_normalized ON articles;
+ALTER TABLE authors DROP COLUMN name_normalized;
+ALTER TABLE articles DROP COLUMN content_normalized, DROP COLUMN title_normalized;
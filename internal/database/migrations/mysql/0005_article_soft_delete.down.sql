@@ -0,0 +1,3 @@
+-- التراجع يحذف المقالات الموجودة في سلة المحذوفات نهائيًا لأن المخطط السابق لا يميزها
+DELETE FROM articles WHERE deleted_at IS NOT NULL;
+ALTER TABLE articles DROP INDEX idx_articles_deleted_at, DROP COLUMN deleted_at;
@@ -0,0 +1,2 @@
+-- الحذف المنطقي للمقالات: الحذف يملأ deleted_at بدل إزالة الصف، وتُحذف نهائيًا بعد مدة الاحتفاظ
+ALTER TABLE articles ADD COLUMN deleted_at DATETIME(3) NULL, ADD INDEX idx_articles_deleted_at (deleted_at);
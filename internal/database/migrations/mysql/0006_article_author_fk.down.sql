@@ -0,0 +1,5 @@
+ALTER TABLE articles DROP FOREIGN KEY fk_authors_articles;
+ALTER TABLE articles
+    MODIFY author_id BIGINT UNSIGNED NULL,
+    ADD CONSTRAINT fk_authors_articles FOREIGN KEY (author_id) REFERENCES authors (id);
+ALTER TABLE articles DROP INDEX idx_articles_author_id;
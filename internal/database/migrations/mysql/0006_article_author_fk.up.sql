@@ -0,0 +1,21 @@
+-- كل مقال يجب أن ينتمي إلى مؤلف: author_id إلزامي ومفهرس، والقيد يمنع الحذف الفعلي لمؤلف له مقالات
+-- (حذف المؤلف في التطبيق منطقي، وسياسة المقالات عند حذفه تطبقها طبقة منطق العمل داخل معاملة)
+-- المقالات التي بلا مؤلف (author_id فارغ أو يشير إلى مؤلف غير موجود) كانت ستفشل القيد الجديد،
+-- فتُنقل إلى مؤلف بديل يُنشأ عند الحاجة فقط، ويمكن بعدها نقلها إلى مؤلفها الصحيح
+INSERT INTO authors (name, email, created_at, updated_at)
+SELECT 'مؤلف غير معروف', 'unknown-author@invalid', NOW(3), NOW(3) FROM DUAL
+WHERE EXISTS (SELECT 1 FROM articles WHERE author_id IS NULL OR author_id NOT IN (SELECT id FROM authors))
+  AND NOT EXISTS (SELECT 1 FROM authors WHERE email = 'unknown-author@invalid');
+UPDATE articles SET author_id = (SELECT id FROM authors WHERE email = 'unknown-author@invalid')
+WHERE author_id IS NULL OR author_id NOT IN (SELECT id FROM authors);
+
+ALTER TABLE articles DROP FOREIGN KEY fk_authors_articles;
+ALTER TABLE articles
+    MODIFY author_id BIGINT UNSIGNED NOT NULL,
+    ADD INDEX idx_articles_author_id (author_id),
+    ADD CONSTRAINT fk_authors_articles
+        FOREIGN KEY (author_id) REFERENCES authors (id) ON UPDATE CASCADE ON DELETE RESTRICT;
+
+-- المقالات التي بقيت تشير إلى مؤلفين محذوفين منطقيًا تنتقل إلى سلة المحذوفات
+UPDATE articles SET deleted_at = NOW(3)
+WHERE deleted_at IS NULL AND author_id IN (SELECT id FROM authors WHERE deleted_at IS NOT NULL);
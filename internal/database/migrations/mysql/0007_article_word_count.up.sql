@@ -0,0 +1,8 @@
+-- عدد كلمات المحتوى لإحصاءات المؤلفين (GET /authors?include=stats)
+-- يملؤه التطبيق عند كل حفظ (عدد المقاطع المفصولة بمسافات)، والتعبئة هنا للسجلات الموجودة
+ALTER TABLE articles ADD COLUMN word_count INT UNSIGNED NOT NULL DEFAULT 0;
+
+UPDATE articles
+SET word_count = CHAR_LENGTH(REGEXP_REPLACE(TRIM(content), '[[:space:]]+', ' '))
+               - CHAR_LENGTH(REPLACE(REGEXP_REPLACE(TRIM(content), '[[:space:]]+', ' '), ' ', '')) + 1
+WHERE TRIM(COALESCE(content, '')) <> '';
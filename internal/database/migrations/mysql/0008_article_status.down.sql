@@ -0,0 +1,2 @@
+DROP INDEX idx_articles_status_publish_at ON articles;
+ALTER TABLE articles DROP COLUMN published_at, DROP COLUMN publish_at, DROP COLUMN status;
@@ -0,0 +1,21 @@
+-- سجل تعديلات المقالات: كل إنشاء أو تحديث أو استرجاع يضيف مراجعة لا تُعدّل
+-- revision رقم متسلسل لكل مقال، والمراجعات تُحذف مع الحذف النهائي لمقالها
+CREATE TABLE IF NOT EXISTS article_revisions (
+    id              BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    article_id      BIGINT UNSIGNED NOT NULL,
+    revision        BIGINT UNSIGNED NOT NULL,
+    title           LONGTEXT NOT NULL,
+    content         LONGTEXT NOT NULL,
+    edited_by       VARCHAR(100) NOT NULL DEFAULT '',
+    reverted_from   BIGINT UNSIGNED NULL,
+    article_version BIGINT UNSIGNED NOT NULL,
+    created_at      DATETIME(3) NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_articles_revisions FOREIGN KEY (article_id) REFERENCES articles (id) ON DELETE CASCADE,
+    CONSTRAINT uni_article_revisions_article_revision UNIQUE (article_id, revision)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
+
+-- المقالات الموجودة تبدأ سجلها بمحتواها الحالي كمراجعة أولى
+INSERT INTO article_revisions (article_id, revision, title, content, article_version, created_at)
+SELECT id, 1, COALESCE(title, ''), COALESCE(content, ''), version, COALESCE(updated_at, created_at)
+FROM articles;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS article_slug_history;
+ALTER TABLE authors DROP INDEX uni_authors_slug, DROP COLUMN slug;
+ALTER TABLE articles DROP INDEX uni_articles_slug, DROP COLUMN slug;
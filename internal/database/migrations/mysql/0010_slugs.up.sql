@@ -0,0 +1,22 @@
+-- معرفات نصية فريدة للمقالات والمؤلفين تُولَّد من العنوان أو الاسم عند الحفظ (مع نقل الحروف العربية إلى اللاتينية)
+-- السجلات الموجودة تأخذ معرفًا مبنيًا على رقمها لأن النقل الحرفي لا يمكن في SQL، ويتغير مع أول تعديل للعنوان أو الاسم
+ALTER TABLE articles ADD COLUMN slug VARCHAR(100) NULL;
+ALTER TABLE authors ADD COLUMN slug VARCHAR(100) NULL;
+
+UPDATE articles SET slug = CONCAT('article-', id);
+UPDATE authors SET slug = CONCAT('author-', id);
+
+ALTER TABLE articles MODIFY slug VARCHAR(100) NOT NULL, ADD CONSTRAINT uni_articles_slug UNIQUE (slug);
+ALTER TABLE authors MODIFY slug VARCHAR(100) NOT NULL, ADD CONSTRAINT uni_authors_slug UNIQUE (slug);
+
+-- المعرفات السابقة للمقالات بعد تغيير عناوينها، تبقى محجوزة لمقالها ويُعاد توجيهها إلى معرفه الحالي (301)
+CREATE TABLE IF NOT EXISTS article_slug_history (
+    id         BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    article_id BIGINT UNSIGNED NOT NULL,
+    slug       VARCHAR(100) NOT NULL,
+    created_at DATETIME(3) NULL,
+    PRIMARY KEY (id),
+    INDEX idx_article_slug_history_article_id (article_id),
+    CONSTRAINT fk_articles_slug_history FOREIGN KEY (article_id) REFERENCES articles (id) ON DELETE CASCADE,
+    CONSTRAINT uni_article_slug_history_slug UNIQUE (slug)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
@@ -0,0 +1,43 @@
+-- الوسوم: تُعرَّف بمعرفها النصي فيكون "Go" و"go" وسمًا واحدًا
+CREATE TABLE IF NOT EXISTS tags (
+    id         BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    name       VARCHAR(50) NOT NULL,
+    slug       VARCHAR(100) NOT NULL,
+    created_at DATETIME(3) NULL,
+    updated_at DATETIME(3) NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT uni_tags_slug UNIQUE (slug)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
+
+-- التصنيفات شجرة: parent_id يشير إلى التصنيف الأب، ولا يُحذف تصنيف له تصنيفات فرعية
+CREATE TABLE IF NOT EXISTS categories (
+    id         BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    name       VARCHAR(50) NOT NULL,
+    slug       VARCHAR(100) NOT NULL,
+    parent_id  BIGINT UNSIGNED NULL,
+    created_at DATETIME(3) NULL,
+    updated_at DATETIME(3) NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT uni_categories_slug UNIQUE (slug),
+    INDEX idx_categories_parent_id (parent_id),
+    CONSTRAINT fk_categories_parent FOREIGN KEY (parent_id) REFERENCES categories (id)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
+
+-- جدولا الربط؛ صفوفهما تُحذف مع المقال أو الوسم أو التصنيف
+CREATE TABLE IF NOT EXISTS article_tags (
+    article_id BIGINT UNSIGNED NOT NULL,
+    tag_id     BIGINT UNSIGNED NOT NULL,
+    PRIMARY KEY (article_id, tag_id),
+    INDEX idx_article_tags_tag_id (tag_id),
+    CONSTRAINT fk_article_tags_article FOREIGN KEY (article_id) REFERENCES articles (id) ON DELETE CASCADE,
+    CONSTRAINT fk_article_tags_tag FOREIGN KEY (tag_id) REFERENCES tags (id) ON DELETE CASCADE
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
+
+CREATE TABLE IF NOT EXISTS article_categories (
+    article_id  BIGINT UNSIGNED NOT NULL,
+    category_id BIGINT UNSIGNED NOT NULL,
+    PRIMARY KEY (article_id, category_id),
+    INDEX idx_article_categories_category_id (category_id),
+    CONSTRAINT fk_article_categories_article FOREIGN KEY (article_id) REFERENCES articles (id) ON DELETE CASCADE,
+    CONSTRAINT fk_article_categories_category FOREIGN KEY (category_id) REFERENCES categories (id) ON DELETE CASCADE
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
@@ -0,0 +1,29 @@
+-- تعليقات القراء على المقالات: الردود تشير إلى التعليق الأب، و thread_id إلى التعليق الجذري لسلسلتها
+-- (NULL للتعليق الجذري نفسه) فتُجلب السلسلة كاملة باستعلام واحد؛ التعليقات تُحذف مع الحذف النهائي لمقالها
+CREATE TABLE IF NOT EXISTS comments (
+    id              BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    article_id      BIGINT UNSIGNED NOT NULL,
+    parent_id       BIGINT UNSIGNED NULL,
+    thread_id       BIGINT UNSIGNED NULL,
+    depth           INT UNSIGNED NOT NULL DEFAULT 0,
+    author_name     VARCHAR(100) NOT NULL,
+    body            LONGTEXT NOT NULL,
+    status          VARCHAR(20) NOT NULL DEFAULT 'pending',
+    edit_token_hash VARCHAR(64) NOT NULL,
+    edited_at       DATETIME(3) NULL,
+    moderated_at    DATETIME(3) NULL,
+    moderated_by    VARCHAR(100) NOT NULL DEFAULT '',
+    created_at      DATETIME(3) NULL,
+    updated_at      DATETIME(3) NULL,
+    deleted_at      DATETIME(3) NULL,
+    PRIMARY KEY (id),
+    INDEX idx_comments_article_id (article_id),
+    INDEX idx_comments_thread_id (thread_id),
+    INDEX idx_comments_status (status),
+    INDEX idx_comments_deleted_at (deleted_at),
+    CONSTRAINT fk_articles_comments FOREIGN KEY (article_id) REFERENCES articles (id) ON DELETE CASCADE,
+    CONSTRAINT fk_comments_parent FOREIGN KEY (parent_id) REFERENCES comments (id) ON DELETE CASCADE
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
+
+-- عدد التعليقات المعتمدة غير المحذوفة لكل مقال، يحدّثه مستودع التعليقات بعد كل تغيير عليها
+ALTER TABLE articles ADD COLUMN comment_count INT UNSIGNED NOT NULL DEFAULT 0;
@@ -0,0 +1,8 @@
+-- صيغة محتوى المقال (plain أو markdown أو html) ونسخته المحوَّلة إلى HTML منقّى، يملؤهما التطبيق عند كل حفظ
+-- المقالات الموجودة نص عادي، و content_html يبقى فارغًا لها فيُحوَّل المحتوى عند قراءته حتى أول تعديل
+ALTER TABLE articles
+    ADD COLUMN content_format VARCHAR(20) NOT NULL DEFAULT 'plain',
+    ADD COLUMN content_html LONGTEXT NULL;
+
+-- صيغة المحتوى في كل مراجعة حتى يستعيدها الاسترجاع مع المحتوى
+ALTER TABLE article_revisions ADD COLUMN content_format VARCHAR(20) NOT NULL DEFAULT 'plain';
@@ -0,0 +1,23 @@
+-- الجداول الأساسية للمؤلفين والمقالات
+-- IF NOT EXISTS يسمح بتبنّي قواعد البيانات التي أنشأها AutoMigrate سابقًا دون فقدان البيانات
+CREATE TABLE IF NOT EXISTS authors (
+    id         BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+    name       TEXT,
+    email      TEXT NOT NULL,
+    CONSTRAINT uni_authors_email UNIQUE (email)
+);
+
+CREATE INDEX IF NOT EXISTS idx_authors_deleted_at ON authors (deleted_at);
+
+CREATE TABLE IF NOT EXISTS articles (
+    id         BIGSERIAL PRIMARY KEY,
+    title      TEXT,
+    content    TEXT,
+    author_id  BIGINT,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT fk_authors_articles FOREIGN KEY (author_id) REFERENCES authors (id)
+);
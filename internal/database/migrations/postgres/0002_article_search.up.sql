@@ -0,0 +1,9 @@
+-- عمود tsvector مولّد للبحث النصي الكامل، العنوان بوزن A أعلى من المحتوى بوزن B
+-- نستخدم إعداد 'simple' لأنه لا يعتمد على قواعد اشتقاق لغة معينة ويناسب النصوص العربية
+ALTER TABLE articles
+    ADD COLUMN IF NOT EXISTS search_vector TSVECTOR GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', coalesce(title, '')), 'A') ||
+        setweight(to_tsvector('simple', coalesce(content, '')), 'B')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_articles_search_vector ON articles USING GIN (search_vector);
@@ -0,0 +1,14 @@
+DROP INDEX IF EXISTS idx_articles_search_vector;
+ALTER TABLE articles DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE articles
+    ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', coalesce(title, '')), 'A') ||
+        setweight(to_tsvector('simple', coalesce(content, '')), 'B')
+    ) STORED;
+CREATE INDEX idx_articles_search_vector ON articles USING GIN (search_vector);
+
+DROP INDEX IF EXISTS idx_authors_name_normalized;
+DROP INDEX IF EXISTS idx_articles_title_normalized;
+ALTER TABLE authors DROP COLUMN IF EXISTS name_normalized;
+ALTER TABLE articles DROP COLUMN IF EXISTS content_normalized;
+ALTER TABLE articles DROP COLUMN IF EXISTS title_normalized;
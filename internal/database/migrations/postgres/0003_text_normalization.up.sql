@@ -0,0 +1,23 @@
+-- أعمدة النص الموحد (بدون تشكيل/تطويل، مع توحيد الألف والهمزات والتاء المربوطة والألف المقصورة والأرقام)
+-- يملؤها التطبيق عند كل حفظ عبر الحزمة textnorm، والتعبئة هنا للسجلات الموجودة تقريب SQL لنفس القواعد
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS title_normalized TEXT NOT NULL DEFAULT '';
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS content_normalized TEXT NOT NULL DEFAULT '';
+ALTER TABLE authors ADD COLUMN IF NOT EXISTS name_normalized TEXT NOT NULL DEFAULT '';
+
+UPDATE articles SET
+    title_normalized = TRANSLATE(LOWER(COALESCE(title, '')), 'أإآٱؤئةى٠١٢٣٤٥٦٧٨٩۰۱۲۳۴۵۶۷۸۹ًٌٍَُِّْٰٕٖٜٟٓٔٗ٘ٙٚٛٝٞـ', 'ااااويهي01234567890123456789'),
+    content_normalized = TRANSLATE(LOWER(COALESCE(content, '')), 'أإآٱؤئةى٠١٢٣٤٥٦٧٨٩۰۱۲۳۴۵۶۷۸۹ًٌٍَُِّْٰٕٖٜٟٓٔٗ٘ٙٚٛٝٞـ', 'ااااويهي01234567890123456789');
+UPDATE authors SET name_normalized = TRANSLATE(LOWER(COALESCE(name, '')), 'أإآٱؤئةى٠١٢٣٤٥٦٧٨٩۰۱۲۳۴۵۶۷۸۹ًٌٍَُِّْٰٕٖٜٟٓٔٗ٘ٙٚٛٝٞـ', 'ااااويهي01234567890123456789');
+
+CREATE INDEX IF NOT EXISTS idx_articles_title_normalized ON articles (title_normalized);
+CREATE INDEX IF NOT EXISTS idx_authors_name_normalized ON authors (name_normalized);
+
+-- إعادة بناء عمود البحث من النص الموحد حتى يطابق البحث الكلمات بغض النظر عن التشكيل
+DROP INDEX IF EXISTS idx_articles_search_vector;
+ALTER TABLE articles DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE articles
+    ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', title_normalized), 'A') ||
+        setweight(to_tsvector('simple', content_normalized), 'B')
+    ) STORED;
+CREATE INDEX idx_articles_search_vector ON articles USING GIN (search_vector);
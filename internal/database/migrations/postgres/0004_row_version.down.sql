@@ -0,0 +1,2 @@
+ALTER TABLE authors DROP COLUMN IF EXISTS version;
+ALTER TABLE articles DROP COLUMN IF EXISTS version;
@@ -0,0 +1,4 @@
+-- رقم نسخة السجل للتحكم المتفائل في التزامن: يزداد مع كل تحديث،
+-- ويُرفض التحديث إذا تغيّر الرقم منذ قراءة السجل (UPDATE ... WHERE version = ?)
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE authors ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
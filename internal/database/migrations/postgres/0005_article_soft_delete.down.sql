@@ -0,0 +1,4 @@
+-- التراجع يحذف المقالات الموجودة في سلة المحذوفات نهائيًا لأن المخطط السابق لا يميزها
+DELETE FROM articles WHERE deleted_at IS NOT NULL;
+DROP INDEX IF EXISTS idx_articles_deleted_at;
+ALTER TABLE articles DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,3 @@
+-- الحذف المنطقي للمقالات: الحذف يملأ deleted_at بدل إزالة الصف، وتُحذف نهائيًا بعد مدة الاحتفاظ
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+CREATE INDEX IF NOT EXISTS idx_articles_deleted_at ON articles (deleted_at);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_articles_author_id;
+ALTER TABLE articles DROP CONSTRAINT IF EXISTS fk_authors_articles;
+ALTER TABLE articles ADD CONSTRAINT fk_authors_articles FOREIGN KEY (author_id) REFERENCES authors (id);
+ALTER TABLE articles ALTER COLUMN author_id DROP NOT NULL;
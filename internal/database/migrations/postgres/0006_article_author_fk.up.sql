@@ -0,0 +1,20 @@
+-- كل مقال يجب أن ينتمي إلى مؤلف: author_id إلزامي ومفهرس، والقيد يمنع الحذف الفعلي لمؤلف له مقالات
+-- (حذف المؤلف في التطبيق منطقي، وسياسة المقالات عند حذفه تطبقها طبقة منطق العمل داخل معاملة)
+-- المقالات التي بلا مؤلف (author_id فارغ أو يشير إلى مؤلف غير موجود) كانت ستفشل القيد الجديد،
+-- فتُنقل إلى مؤلف بديل يُنشأ عند الحاجة فقط، ويمكن بعدها نقلها إلى مؤلفها الصحيح
+INSERT INTO authors (name, email, created_at, updated_at)
+SELECT 'مؤلف غير معروف', 'unknown-author@invalid', NOW(), NOW()
+WHERE EXISTS (SELECT 1 FROM articles WHERE author_id IS NULL OR author_id NOT IN (SELECT id FROM authors))
+  AND NOT EXISTS (SELECT 1 FROM authors WHERE email = 'unknown-author@invalid');
+UPDATE articles SET author_id = (SELECT id FROM authors WHERE email = 'unknown-author@invalid')
+WHERE author_id IS NULL OR author_id NOT IN (SELECT id FROM authors);
+
+ALTER TABLE articles ALTER COLUMN author_id SET NOT NULL;
+ALTER TABLE articles DROP CONSTRAINT IF EXISTS fk_authors_articles;
+ALTER TABLE articles ADD CONSTRAINT fk_authors_articles
+    FOREIGN KEY (author_id) REFERENCES authors (id) ON UPDATE CASCADE ON DELETE RESTRICT;
+CREATE INDEX IF NOT EXISTS idx_articles_author_id ON articles (author_id);
+
+-- المقالات التي بقيت تشير إلى مؤلفين محذوفين منطقيًا تنتقل إلى سلة المحذوفات
+UPDATE articles SET deleted_at = NOW()
+WHERE deleted_at IS NULL AND author_id IN (SELECT id FROM authors WHERE deleted_at IS NOT NULL);
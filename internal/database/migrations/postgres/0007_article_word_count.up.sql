@@ -0,0 +1,6 @@
+-- عدد كلمات المحتوى لإحصاءات المؤلفين (GET /authors?include=stats)
+-- يملؤه التطبيق عند كل حفظ (عدد المقاطع المفصولة بمسافات)، والتعبئة هنا للسجلات الموجودة
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS word_count INTEGER NOT NULL DEFAULT 0;
+
+UPDATE articles SET word_count = COALESCE(array_length(regexp_split_to_array(btrim(content), '\s+'), 1), 0)
+WHERE btrim(COALESCE(content, '')) <> '';
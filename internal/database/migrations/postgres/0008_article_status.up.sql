@@ -0,0 +1,11 @@
+-- دورة حياة المقال: draft أو scheduled أو published أو archived
+-- publish_at موعد النشر المجدول، و published_at وقت النشر الفعلي الذي تعتمد عليه الإحصاءات
+-- المقالات الموجودة كانت عامة منذ إنشائها، فتُعد منشورة في تاريخ إنشائها
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'draft';
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS publish_at TIMESTAMPTZ NULL;
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS published_at TIMESTAMPTZ NULL;
+
+UPDATE articles SET status = 'published', published_at = created_at;
+
+-- القوائم العامة تصفّي بالحالة، والمجدول يبحث عن المقالات المستحقة بالحالة والموعد
+CREATE INDEX IF NOT EXISTS idx_articles_status_publish_at ON articles (status, publish_at);
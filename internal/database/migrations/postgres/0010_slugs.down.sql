@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS article_slug_history;
+ALTER TABLE authors DROP COLUMN IF EXISTS slug;
+ALTER TABLE articles DROP COLUMN IF EXISTS slug;
@@ -0,0 +1,24 @@
+-- معرفات نصية فريدة للمقالات والمؤلفين تُولَّد من العنوان أو الاسم عند الحفظ (مع نقل الحروف العربية إلى اللاتينية)
+-- السجلات الموجودة تأخذ معرفًا مبنيًا على رقمها لأن النقل الحرفي لا يمكن في SQL، ويتغير مع أول تعديل للعنوان أو الاسم
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS slug VARCHAR(100);
+ALTER TABLE authors ADD COLUMN IF NOT EXISTS slug VARCHAR(100);
+
+UPDATE articles SET slug = 'article-' || id;
+UPDATE authors SET slug = 'author-' || id;
+
+ALTER TABLE articles ALTER COLUMN slug SET NOT NULL;
+ALTER TABLE authors ALTER COLUMN slug SET NOT NULL;
+ALTER TABLE articles ADD CONSTRAINT uni_articles_slug UNIQUE (slug);
+ALTER TABLE authors ADD CONSTRAINT uni_authors_slug UNIQUE (slug);
+
+-- المعرفات السابقة للمقالات بعد تغيير عناوينها، تبقى محجوزة لمقالها ويُعاد توجيهها إلى معرفه الحالي (301)
+CREATE TABLE IF NOT EXISTS article_slug_history (
+    id         BIGSERIAL PRIMARY KEY,
+    article_id BIGINT NOT NULL,
+    slug       VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ,
+    CONSTRAINT fk_articles_slug_history FOREIGN KEY (article_id) REFERENCES articles (id) ON DELETE CASCADE,
+    CONSTRAINT uni_article_slug_history_slug UNIQUE (slug)
+);
+
+CREATE INDEX IF NOT EXISTS idx_article_slug_history_article_id ON article_slug_history (article_id);
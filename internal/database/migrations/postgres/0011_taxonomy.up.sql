@@ -0,0 +1,41 @@
+-- الوسوم: تُعرَّف بمعرفها النصي فيكون "Go" و"go" وسمًا واحدًا
+CREATE TABLE IF NOT EXISTS tags (
+    id         BIGSERIAL PRIMARY KEY,
+    name       VARCHAR(50) NOT NULL,
+    slug       VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT uni_tags_slug UNIQUE (slug)
+);
+
+-- التصنيفات شجرة: parent_id يشير إلى التصنيف الأب، ولا يُحذف تصنيف له تصنيفات فرعية
+CREATE TABLE IF NOT EXISTS categories (
+    id         BIGSERIAL PRIMARY KEY,
+    name       VARCHAR(50) NOT NULL,
+    slug       VARCHAR(100) NOT NULL,
+    parent_id  BIGINT NULL,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT uni_categories_slug UNIQUE (slug),
+    CONSTRAINT fk_categories_parent FOREIGN KEY (parent_id) REFERENCES categories (id)
+);
+CREATE INDEX IF NOT EXISTS idx_categories_parent_id ON categories (parent_id);
+
+-- جدولا الربط؛ صفوفهما تُحذف مع المقال أو الوسم أو التصنيف
+CREATE TABLE IF NOT EXISTS article_tags (
+    article_id BIGINT NOT NULL,
+    tag_id     BIGINT NOT NULL,
+    PRIMARY KEY (article_id, tag_id),
+    CONSTRAINT fk_article_tags_article FOREIGN KEY (article_id) REFERENCES articles (id) ON DELETE CASCADE,
+    CONSTRAINT fk_article_tags_tag FOREIGN KEY (tag_id) REFERENCES tags (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_article_tags_tag_id ON article_tags (tag_id);
+
+CREATE TABLE IF NOT EXISTS article_categories (
+    article_id  BIGINT NOT NULL,
+    category_id BIGINT NOT NULL,
+    PRIMARY KEY (article_id, category_id),
+    CONSTRAINT fk_article_categories_article FOREIGN KEY (article_id) REFERENCES articles (id) ON DELETE CASCADE,
+    CONSTRAINT fk_article_categories_category FOREIGN KEY (category_id) REFERENCES categories (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_article_categories_category_id ON article_categories (category_id);
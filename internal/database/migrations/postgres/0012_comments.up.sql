@@ -0,0 +1,28 @@
+-- تعليقات القراء على المقالات: الردود تشير إلى التعليق الأب، و thread_id إلى التعليق الجذري لسلسلتها
+-- (NULL للتعليق الجذري نفسه) فتُجلب السلسلة كاملة باستعلام واحد؛ التعليقات تُحذف مع الحذف النهائي لمقالها
+CREATE TABLE IF NOT EXISTS comments (
+    id              BIGSERIAL PRIMARY KEY,
+    article_id      BIGINT NOT NULL,
+    parent_id       BIGINT NULL,
+    thread_id       BIGINT NULL,
+    depth           INTEGER NOT NULL DEFAULT 0,
+    author_name     VARCHAR(100) NOT NULL,
+    body            TEXT NOT NULL,
+    status          VARCHAR(20) NOT NULL DEFAULT 'pending',
+    edit_token_hash VARCHAR(64) NOT NULL,
+    edited_at       TIMESTAMPTZ NULL,
+    moderated_at    TIMESTAMPTZ NULL,
+    moderated_by    VARCHAR(100) NOT NULL DEFAULT '',
+    created_at      TIMESTAMPTZ,
+    updated_at      TIMESTAMPTZ,
+    deleted_at      TIMESTAMPTZ NULL,
+    CONSTRAINT fk_articles_comments FOREIGN KEY (article_id) REFERENCES articles (id) ON DELETE CASCADE,
+    CONSTRAINT fk_comments_parent FOREIGN KEY (parent_id) REFERENCES comments (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_comments_article_id ON comments (article_id);
+CREATE INDEX IF NOT EXISTS idx_comments_thread_id ON comments (thread_id);
+CREATE INDEX IF NOT EXISTS idx_comments_status ON comments (status);
+CREATE INDEX IF NOT EXISTS idx_comments_deleted_at ON comments (deleted_at);
+
+-- عدد التعليقات المعتمدة غير المحذوفة لكل مقال، يحدّثه مستودع التعليقات بعد كل تغيير عليها
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS comment_count INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+ALTER TABLE article_revisions DROP COLUMN IF EXISTS content_format;
+ALTER TABLE articles DROP COLUMN IF EXISTS content_html;
+ALTER TABLE articles DROP COLUMN IF EXISTS content_format;
@@ -0,0 +1,22 @@
+-- الجداول الأساسية للمؤلفين والمقالات
+CREATE TABLE IF NOT EXISTS authors (
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    created_at DATETIME,
+    updated_at DATETIME,
+    deleted_at DATETIME,
+    name       TEXT,
+    email      TEXT NOT NULL,
+    CONSTRAINT uni_authors_email UNIQUE (email)
+);
+
+CREATE INDEX IF NOT EXISTS idx_authors_deleted_at ON authors (deleted_at);
+
+CREATE TABLE IF NOT EXISTS articles (
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    title      TEXT,
+    content    TEXT,
+    author_id  INTEGER,
+    created_at DATETIME,
+    updated_at DATETIME,
+    CONSTRAINT fk_authors_articles FOREIGN KEY (author_id) REFERENCES authors (id)
+);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_authors_name_normalized;
+DROP INDEX IF EXISTS idx_articles_title_normalized;
+ALTER TABLE authors DROP COLUMN name_normalized;
+ALTER TABLE articles DROP COLUMN content_normalized;
+ALTER TABLE articles DROP COLUMN title_normalized;
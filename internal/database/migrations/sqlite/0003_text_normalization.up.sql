@@ -0,0 +1,42 @@
+-- أعمدة النص الموحد (بدون تشكيل/تطويل، مع توحيد الألف والهمزات والتاء المربوطة والألف المقصورة والأرقام)
+-- يملؤها التطبيق عند كل حفظ عبر الحزمة textnorm، والتعبئة هنا للسجلات الموجودة تقريب SQL لنفس القواعد
+-- (على دفعات من REPLACE لأن التداخل العميق يتجاوز حدود محلل SQLite)
+ALTER TABLE articles ADD COLUMN title_normalized TEXT NOT NULL DEFAULT '';
+ALTER TABLE articles ADD COLUMN content_normalized TEXT NOT NULL DEFAULT '';
+ALTER TABLE authors ADD COLUMN name_normalized TEXT NOT NULL DEFAULT '';
+
+UPDATE articles SET title_normalized = LOWER(COALESCE(title, '')), content_normalized = LOWER(COALESCE(content, ''));
+UPDATE articles SET
+    title_normalized = REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(title_normalized, 'أ', 'ا'), 'إ', 'ا'), 'آ', 'ا'), 'ٱ', 'ا'), 'ؤ', 'و'), 'ئ', 'ي'), 'ة', 'ه'), 'ى', 'ي'), '٠', '0'), '١', '1'),
+    content_normalized = REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(content_normalized, 'أ', 'ا'), 'إ', 'ا'), 'آ', 'ا'), 'ٱ', 'ا'), 'ؤ', 'و'), 'ئ', 'ي'), 'ة', 'ه'), 'ى', 'ي'), '٠', '0'), '١', '1');
+UPDATE articles SET
+    title_normalized = REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(title_normalized, '٢', '2'), '٣', '3'), '٤', '4'), '٥', '5'), '٦', '6'), '٧', '7'), '٨', '8'), '٩', '9'), '۰', '0'), '۱', '1'),
+    content_normalized = REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(content_normalized, '٢', '2'), '٣', '3'), '٤', '4'), '٥', '5'), '٦', '6'), '٧', '7'), '٨', '8'), '٩', '9'), '۰', '0'), '۱', '1');
+UPDATE articles SET
+    title_normalized = REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(title_normalized, '۲', '2'), '۳', '3'), '۴', '4'), '۵', '5'), '۶', '6'), '۷', '7'), '۸', '8'), '۹', '9'), 'ً', ''), 'ٌ', ''),
+    content_normalized = REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(content_normalized, '۲', '2'), '۳', '3'), '۴', '4'), '۵', '5'), '۶', '6'), '۷', '7'), '۸', '8'), '۹', '9'), 'ً', ''), 'ٌ', '');
+UPDATE articles SET
+    title_normalized = REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(title_normalized, 'ٍ', ''), 'َ', ''), 'ُ', ''), 'ِ', ''), 'ّ', ''), 'ْ', ''), 'ٓ', ''), 'ٔ', ''), 'ٕ', ''), 'ٖ', ''),
+    content_normalized = REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(content_normalized, 'ٍ', ''), 'َ', ''), 'ُ', ''), 'ِ', ''), 'ّ', ''), 'ْ', ''), 'ٓ', ''), 'ٔ', ''), 'ٕ', ''), 'ٖ', '');
+UPDATE articles SET
+    title_normalized = REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(title_normalized, 'ٗ', ''), '٘', ''), 'ٙ', ''), 'ٚ', ''), 'ٛ', ''), 'ٜ', ''), 'ٝ', ''), 'ٞ', ''), 'ٟ', ''), 'ٰ', ''),
+    content_normalized = REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(content_normalized, 'ٗ', ''), '٘', ''), 'ٙ', ''), 'ٚ', ''), 'ٛ', ''), 'ٜ', ''), 'ٝ', ''), 'ٞ', ''), 'ٟ', ''), 'ٰ', '');
+UPDATE articles SET
+    title_normalized = REPLACE(title_normalized, 'ـ', ''),
+    content_normalized = REPLACE(content_normalized, 'ـ', '');
+UPDATE authors SET name_normalized = LOWER(COALESCE(name, ''));
+UPDATE authors SET
+    name_normalized = REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(name_normalized, 'أ', 'ا'), 'إ', 'ا'), 'آ', 'ا'), 'ٱ', 'ا'), 'ؤ', 'و'), 'ئ', 'ي'), 'ة', 'ه'), 'ى', 'ي'), '٠', '0'), '١', '1');
+UPDATE authors SET
+    name_normalized = REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(name_normalized, '٢', '2'), '٣', '3'), '٤', '4'), '٥', '5'), '٦', '6'), '٧', '7'), '٨', '8'), '٩', '9'), '۰', '0'), '۱', '1');
+UPDATE authors SET
+    name_normalized = REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(name_normalized, '۲', '2'), '۳', '3'), '۴', '4'), '۵', '5'), '۶', '6'), '۷', '7'), '۸', '8'), '۹', '9'), 'ً', ''), 'ٌ', '');
+UPDATE authors SET
+    name_normalized = REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(name_normalized, 'ٍ', ''), 'َ', ''), 'ُ', ''), 'ِ', ''), 'ّ', ''), 'ْ', ''), 'ٓ', ''), 'ٔ', ''), 'ٕ', ''), 'ٖ', '');
+UPDATE authors SET
+    name_normalized = REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(REPLACE(name_normalized, 'ٗ', ''), '٘', ''), 'ٙ', ''), 'ٚ', ''), 'ٛ', ''), 'ٜ', ''), 'ٝ', ''), 'ٞ', ''), 'ٟ', ''), 'ٰ', '');
+UPDATE authors SET
+    name_normalized = REPLACE(name_normalized, 'ـ', '');
+
+CREATE INDEX idx_articles_title_normalized ON articles (title_normalized);
+CREATE INDEX idx_authors_name_normalized ON authors (name_normalized);
@@ -0,0 +1,22 @@
+CREATE TABLE articles_old (
+    id                 INTEGER PRIMARY KEY AUTOINCREMENT,
+    title              TEXT,
+    content            TEXT,
+    author_id          INTEGER,
+    created_at         DATETIME,
+    updated_at         DATETIME,
+    title_normalized   TEXT NOT NULL DEFAULT '',
+    content_normalized TEXT NOT NULL DEFAULT '',
+    version            INTEGER NOT NULL DEFAULT 1,
+    deleted_at         DATETIME,
+    CONSTRAINT fk_authors_articles FOREIGN KEY (author_id) REFERENCES authors (id)
+);
+INSERT INTO articles_old (id, title, content, author_id, created_at, updated_at,
+                          title_normalized, content_normalized, version, deleted_at)
+SELECT id, title, content, author_id, created_at, updated_at,
+       title_normalized, content_normalized, version, deleted_at
+FROM articles;
+DROP TABLE articles;
+ALTER TABLE articles_old RENAME TO articles;
+CREATE INDEX idx_articles_title_normalized ON articles (title_normalized);
+CREATE INDEX idx_articles_deleted_at ON articles (deleted_at);
@@ -0,0 +1,40 @@
+-- كل مقال يجب أن ينتمي إلى مؤلف: author_id إلزامي ومفهرس، والقيد يمنع الحذف الفعلي لمؤلف له مقالات
+-- (حذف المؤلف في التطبيق منطقي، وسياسة المقالات عند حذفه تطبقها طبقة منطق العمل داخل معاملة)
+-- المقالات التي بلا مؤلف (author_id فارغ أو يشير إلى مؤلف غير موجود) كانت ستفشل القيد الجديد،
+-- فتُنقل إلى مؤلف بديل يُنشأ عند الحاجة فقط، ويمكن بعدها نقلها إلى مؤلفها الصحيح
+INSERT INTO authors (name, email, created_at, updated_at)
+SELECT 'مؤلف غير معروف', 'unknown-author@invalid', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
+WHERE EXISTS (SELECT 1 FROM articles WHERE author_id IS NULL OR author_id NOT IN (SELECT id FROM authors))
+  AND NOT EXISTS (SELECT 1 FROM authors WHERE email = 'unknown-author@invalid');
+UPDATE articles SET author_id = (SELECT id FROM authors WHERE email = 'unknown-author@invalid')
+WHERE author_id IS NULL OR author_id NOT IN (SELECT id FROM authors);
+
+-- SQLite لا يسمح بتعديل القيود، لذا يُعاد بناء الجدول مع الحفاظ على البيانات والمعرفات
+CREATE TABLE articles_new (
+    id                 INTEGER PRIMARY KEY AUTOINCREMENT,
+    title              TEXT,
+    content            TEXT,
+    author_id          INTEGER NOT NULL,
+    created_at         DATETIME,
+    updated_at         DATETIME,
+    title_normalized   TEXT NOT NULL DEFAULT '',
+    content_normalized TEXT NOT NULL DEFAULT '',
+    version            INTEGER NOT NULL DEFAULT 1,
+    deleted_at         DATETIME,
+    CONSTRAINT fk_authors_articles FOREIGN KEY (author_id) REFERENCES authors (id)
+        ON UPDATE CASCADE ON DELETE RESTRICT
+);
+INSERT INTO articles_new (id, title, content, author_id, created_at, updated_at,
+                          title_normalized, content_normalized, version, deleted_at)
+SELECT id, title, content, author_id, created_at, updated_at,
+       title_normalized, content_normalized, version, deleted_at
+FROM articles;
+DROP TABLE articles;
+ALTER TABLE articles_new RENAME TO articles;
+CREATE INDEX idx_articles_title_normalized ON articles (title_normalized);
+CREATE INDEX idx_articles_deleted_at ON articles (deleted_at);
+CREATE INDEX idx_articles_author_id ON articles (author_id);
+
+-- المقالات التي بقيت تشير إلى مؤلفين محذوفين منطقيًا تنتقل إلى سلة المحذوفات
+UPDATE articles SET deleted_at = CURRENT_TIMESTAMP
+WHERE deleted_at IS NULL AND author_id IN (SELECT id FROM authors WHERE deleted_at IS NOT NULL);
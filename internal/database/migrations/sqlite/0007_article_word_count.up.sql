@@ -0,0 +1,14 @@
+-- عدد كلمات المحتوى لإحصاءات المؤلفين (GET /authors?include=stats)
+-- يملؤه التطبيق عند كل حفظ (عدد المقاطع المفصولة بمسافات)، والتعبئة هنا للسجلات الموجودة
+-- تقريبية لأن SQLite بلا تعابير نمطية: الأسطر والجدولة تُعد مسافات، والمسافات المتتالية تُطوى على مراحل
+ALTER TABLE articles ADD COLUMN word_count INTEGER NOT NULL DEFAULT 0;
+
+UPDATE articles SET word_count = length(c) - length(REPLACE(c, ' ', '')) + 1
+FROM (
+    SELECT id AS cid,
+           REPLACE(REPLACE(REPLACE(REPLACE(TRIM(REPLACE(REPLACE(REPLACE(content, char(13), ' '), char(10), ' '), char(9), ' ')),
+               '    ', ' '), '   ', ' '), '  ', ' '), '  ', ' ') AS c
+    FROM articles
+    WHERE TRIM(COALESCE(content, '')) <> ''
+)
+WHERE articles.id = cid;
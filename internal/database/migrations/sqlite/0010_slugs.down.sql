@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS article_slug_history;
+DROP INDEX IF EXISTS uni_authors_slug;
+DROP INDEX IF EXISTS uni_articles_slug;
+ALTER TABLE authors DROP COLUMN slug;
+ALTER TABLE articles DROP COLUMN slug;
@@ -0,0 +1,22 @@
+-- معرفات نصية فريدة للمقالات والمؤلفين تُولَّد من العنوان أو الاسم عند الحفظ (مع نقل الحروف العربية إلى اللاتينية)
+-- السجلات الموجودة تأخذ معرفًا مبنيًا على رقمها لأن النقل الحرفي لا يمكن في SQL، ويتغير مع أول تعديل للعنوان أو الاسم
+ALTER TABLE articles ADD COLUMN slug TEXT NOT NULL DEFAULT '';
+ALTER TABLE authors ADD COLUMN slug TEXT NOT NULL DEFAULT '';
+
+UPDATE articles SET slug = 'article-' || id;
+UPDATE authors SET slug = 'author-' || id;
+
+CREATE UNIQUE INDEX uni_articles_slug ON articles (slug);
+CREATE UNIQUE INDEX uni_authors_slug ON authors (slug);
+
+-- المعرفات السابقة للمقالات بعد تغيير عناوينها، تبقى محجوزة لمقالها ويُعاد توجيهها إلى معرفه الحالي (301)
+CREATE TABLE IF NOT EXISTS article_slug_history (
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    article_id INTEGER NOT NULL,
+    slug       TEXT NOT NULL,
+    created_at DATETIME,
+    CONSTRAINT fk_articles_slug_history FOREIGN KEY (article_id) REFERENCES articles (id) ON DELETE CASCADE,
+    CONSTRAINT uni_article_slug_history_slug UNIQUE (slug)
+);
+
+CREATE INDEX IF NOT EXISTS idx_article_slug_history_article_id ON article_slug_history (article_id);
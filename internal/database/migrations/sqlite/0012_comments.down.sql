@@ -0,0 +1,2 @@
+ALTER TABLE articles DROP COLUMN comment_count;
+DROP TABLE IF EXISTS comments;
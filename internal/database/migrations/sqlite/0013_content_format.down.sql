@@ -0,0 +1,3 @@
+ALTER TABLE article_revisions DROP COLUMN content_format;
+ALTER TABLE articles DROP COLUMN content_html;
+ALTER TABLE articles DROP COLUMN content_format;
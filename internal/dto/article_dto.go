@@ -8,20 +8,83 @@ type CreateArticleRequest struct {
 	Title    string `json:"title" validate:"required,min=5,max=200"`
 	Content  string `json:"content" validate:"required,min=10"`
 	AuthorID uint   `json:"author_id" validate:"required"`
+	// ContentFormat صيغة المحتوى، والافتراضي plain؛ المحتوى بصيغة html يُنقّى من الوسوم والسمات غير المسموحة
+	ContentFormat string `json:"content_format" validate:"omitempty,oneof=plain markdown html"`
+	// Status الحالة الأولى للمقال، والافتراضي draft؛ scheduled يتطلب publish_at في المستقبل
+	Status    string     `json:"status" validate:"omitempty,oneof=draft published scheduled"`
+	PublishAt *time.Time `json:"publish_at"`
+	// Tags أسماء الوسوم، ويُنشأ منها ما لم يوجد؛ Go و go وسم واحد
+	Tags        []string `json:"tags" validate:"omitempty,max=20,dive,required,max=50"`
+	CategoryIDs []uint   `json:"category_ids" validate:"omitempty,max=10,dive,required"`
 }
 
 // UpdateArticleRequest هو DTO لطلب تحديث مقال
 type UpdateArticleRequest struct {
 	Title   string `json:"title" validate:"omitempty,min=5,max=200"`
 	Content string `json:"content" validate:"omitempty,min=10"`
+	// ContentFormat يغيّر صيغة المحتوى عند إرسالها، وإلا تبقى صيغته الحالية
+	ContentFormat string `json:"content_format" validate:"omitempty,oneof=plain markdown html"`
+	// Tags و CategoryIDs تستبدل وسوم المقال وتصنيفاته عند إرسالها، و [] تزيلها كلها
+	Tags        []string `json:"tags" validate:"omitempty,max=20,dive,required,max=50"`
+	CategoryIDs []uint   `json:"category_ids" validate:"omitempty,max=10,dive,required"`
+}
+
+// ScheduleArticleRequest هو DTO لطلب جدولة نشر مقال
+type ScheduleArticleRequest struct {
+	PublishAt *time.Time `json:"publish_at" validate:"required"`
 }
 
 // ArticleResponse هو DTO لإرجاع بيانات المقال
 type ArticleResponse struct {
-	ID        uint           `json:"id"`
-	Title     string         `json:"title"`
-	Content   string         `json:"content"`
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
-	Author    AuthorResponse `json:"author"`
-}
\ No newline at end of file
+	ID      uint   `json:"id"`
+	Title   string `json:"title"`
+	Slug    string `json:"slug"` // المعرف النصي للرابط GET /articles/by-slug/:slug
+	Content string `json:"content"`
+	// ContentFormat صيغة المحتوى، و ContentHTML المحتوى محوَّلًا إلى HTML منقّى ويظهر فقط مع ?render=html
+	ContentFormat string     `json:"content_format"`
+	ContentHTML   string     `json:"content_html,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+	DeletedAt     *time.Time `json:"deleted_at,omitempty"` // يظهر فقط للمقالات الموجودة في سلة المحذوفات
+	Version       uint       `json:"version"`              // يُرسل أيضًا في ترويسة ETag ويُعاد في If-Match عند التحديث
+	// Status حالة المقال: draft أو scheduled أو published أو archived
+	Status      string         `json:"status"`
+	PublishAt   *time.Time     `json:"publish_at,omitempty"`   // موعد النشر للمقال المجدول
+	PublishedAt *time.Time     `json:"published_at,omitempty"` // وقت النشر الفعلي
+	Author      AuthorResponse `json:"author"`
+	// Tags و Categories مرتبة حسب المعرف النصي، وتكون [] للمقال غير المصنف
+	Tags       []TagResponse      `json:"tags"`
+	Categories []CategoryResponse `json:"categories"`
+	// CommentCount عدد التعليقات المعتمدة الظاهرة للقراء
+	CommentCount int `json:"comment_count"`
+}
+
+// ArticleListQuery هو DTO لمعاملات تصفية وترتيب قائمة المقالات كما يرسلها العميل
+// مثال: ?author_id=1&created_after=2024-01-01&title=go&tag=go&category=backend&sort=-created_at,title
+// category يشمل مقالات التصنيفات الفرعية للتصنيف المطلوب
+// status افتراضيه published، وغيره (draft و scheduled و archived و all) للمشرف فقط
+type ArticleListQuery struct {
+	Status        string `query:"status"`
+	AuthorID      string `query:"author_id"`
+	CreatedAfter  string `query:"created_after"`
+	CreatedBefore string `query:"created_before"`
+	UpdatedSince  string `query:"updated_since"`
+	Title         string `query:"title"`
+	Tag           string `query:"tag"`      // المعرف النصي للوسم
+	Category      string `query:"category"` // المعرف النصي للتصنيف
+	Sort          string `query:"sort"`
+}
+
+// ArticleReadQuery هو DTO لمعاملات قراءة مقال واحد كما يرسلها العميل
+// مثال: ?render=html يضيف content_html، و raw (الافتراضي) يعيد المحتوى بصيغته فقط
+type ArticleReadQuery struct {
+	Render string `query:"render"`
+}
+
+// ArticleSearchResponse هو DTO لنتيجة بحث واحدة: المقال مع درجة الصلة ومقتطفات مميزة بوسم <mark>
+type ArticleSearchResponse struct {
+	Article        ArticleResponse `json:"article"`
+	Rank           float64         `json:"rank"`
+	TitleHighlight string          `json:"title_highlight"`
+	Snippet        string          `json:"snippet"`
+}
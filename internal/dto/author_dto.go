@@ -17,16 +17,45 @@ type UpdateAuthorRequest struct {
 
 // AuthorResponse هو DTO القياسي لإرجاع بيانات المؤلف
 type AuthorResponse struct {
-	ID    uint   `json:"id"`
-	Name  string `json:"name"`
-	Email string `json:"email"`
+	ID      uint   `json:"id"`
+	Name    string `json:"name"`
+	Slug    string `json:"slug"` // المعرف النصي للرابط GET /authors/by-slug/:slug
+	Email   string `json:"email"`
+	Version uint   `json:"version"` // يُرسل أيضًا في ترويسة ETag ويُعاد في If-Match عند التحديث
+	// Stats إحصاءات مقالات المؤلف، تظهر فقط في القائمة مع ?include=stats
+	Stats *AuthorStatsResponse `json:"stats,omitempty"`
+}
+
+// AuthorStatsResponse هو DTO لإحصاءات مقالات المؤلف غير المحذوفة
+type AuthorStatsResponse struct {
+	ArticleCount     int64      `json:"article_count"`
+	FirstPublishedAt *time.Time `json:"first_published_at"` // null للمؤلف الذي لا مقالات له
+	LastPublishedAt  *time.Time `json:"last_published_at"`
+	TotalWords       int64      `json:"total_words"`
 }
 
 // AuthorDetailResponse هو DTO لإرجاع بيانات المؤلف مع مقالاته
 type AuthorDetailResponse struct {
 	ID        uint              `json:"id"`
 	Name      string            `json:"name"`
+	Slug      string            `json:"slug"`
 	Email     string            `json:"email"`
 	CreatedAt time.Time         `json:"created_at"`
+	Version   uint              `json:"version"`
 	Articles  []ArticleResponse `json:"articles,omitempty"`
-}
\ No newline at end of file
+}
+
+// AuthorListQuery هو DTO لمعاملات تصفية وترتيب قائمة المؤلفين
+// مثال: ?name=أحمد&include=stats&sort=-article_count,name
+type AuthorListQuery struct {
+	Name    string `query:"name"`
+	Include string `query:"include"` // stats لإرفاق إحصاءات المقالات بكل مؤلف
+	Sort    string `query:"sort"`
+}
+
+// DeleteAuthorQuery هو DTO لسياسة التعامل مع مقالات المؤلف عند حذفه
+// مثال: ?on_articles=reassign&to=7
+type DeleteAuthorQuery struct {
+	OnArticles string `query:"on_articles"` // block (الافتراضي) أو cascade أو reassign
+	To         uint   `query:"to"`          // المؤلف الذي تنتقل إليه المقالات مع reassign
+}
@@ -0,0 +1,60 @@
+// my-article-app/internal/dto/bulk_dto.go
+package dto
+
+// أوضاع تنفيذ العمليات المجمّعة
+const (
+	// BulkModeAtomic كل العناصر في معاملة واحدة: فشل أي عنصر يلغي الدفعة كاملة (الوضع الافتراضي)
+	BulkModeAtomic = "atomic"
+	// BulkModeBestEffort تُنفذ العناصر الصالحة ويُبلَّغ عن الفاشلة دون إلغاء غيرها
+	BulkModeBestEffort = "best_effort"
+)
+
+// BulkCreateArticlesRequest هو DTO لطلب إنشاء عدة مقالات، وكل عنصر يُتحقق منه بمفرده
+type BulkCreateArticlesRequest struct {
+	Mode  string                 `json:"mode" validate:"omitempty,oneof=atomic best_effort"`
+	Items []CreateArticleRequest `json:"items" validate:"required,min=1,max=1000"`
+}
+
+// BulkUpdateArticleItem عنصر واحد في طلب التحديث المجمّع
+type BulkUpdateArticleItem struct {
+	ID uint `json:"id" validate:"required"`
+	// Version يقوم مقام If-Match للعنصر: إذا أُرسل لا يُحفظ التعديل إلا إذا طابق النسخة الحالية
+	Version *uint  `json:"version"`
+	Title   string `json:"title" validate:"omitempty,min=5,max=200"`
+	Content string `json:"content" validate:"omitempty,min=10"`
+	// ContentFormat و Tags و CategoryIDs كما في UpdateArticleRequest
+	ContentFormat string   `json:"content_format" validate:"omitempty,oneof=plain markdown html"`
+	Tags          []string `json:"tags" validate:"omitempty,max=20,dive,required,max=50"`
+	CategoryIDs   []uint   `json:"category_ids" validate:"omitempty,max=10,dive,required"`
+}
+
+// BulkUpdateArticlesRequest هو DTO لطلب تحديث عدة مقالات
+type BulkUpdateArticlesRequest struct {
+	Mode  string                  `json:"mode" validate:"omitempty,oneof=atomic best_effort"`
+	Items []BulkUpdateArticleItem `json:"items" validate:"required,min=1,max=1000"`
+}
+
+// BulkDeleteArticlesRequest هو DTO لطلب نقل عدة مقالات إلى سلة المحذوفات
+type BulkDeleteArticlesRequest struct {
+	Mode string `json:"mode" validate:"omitempty,oneof=atomic best_effort"`
+	IDs  []uint `json:"ids" validate:"required,min=1,max=1000"`
+}
+
+// BulkItemResult نتيجة عنصر واحد بترتيبه في الطلب: المقال عند النجاح، أو الخطأ بنفس شكل استجابة الأخطاء
+type BulkItemResult struct {
+	Index   int              `json:"index"`
+	ID      uint             `json:"id,omitempty"`
+	Status  int              `json:"status"`
+	Article *ArticleResponse `json:"article,omitempty"`
+	Error   *ErrorResponse   `json:"error,omitempty"`
+	// Err الخطأ كما أعادته الطبقات الداخلية، ويحوّله المعالج إلى Status و Error بلغة الطلب
+	Err error `json:"-"`
+}
+
+// BulkResponse تقرير العملية المجمّعة
+type BulkResponse struct {
+	Mode      string           `json:"mode"`
+	Succeeded int              `json:"succeeded"`
+	Failed    int              `json:"failed"`
+	Results   []BulkItemResult `json:"results"`
+}
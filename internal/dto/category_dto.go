@@ -0,0 +1,30 @@
+// my-article-app/internal/dto/category_dto.go
+package dto
+
+// CreateCategoryRequest هو DTO لطلب إنشاء تصنيف، ومعرفه النصي يُشتق من اسمه
+type CreateCategoryRequest struct {
+	Name     string `json:"name" validate:"required,max=50"`
+	ParentID *uint  `json:"parent_id"` // التصنيف الأب، وغيابه يجعل التصنيف في الجذر
+}
+
+// UpdateCategoryRequest هو DTO لطلب تحديث تصنيف
+// الحقل الغائب يبقى كما هو، و parent_id بقيمة 0 ينقل التصنيف إلى الجذر
+type UpdateCategoryRequest struct {
+	Name     string `json:"name" validate:"omitempty,max=50"`
+	ParentID *uint  `json:"parent_id"`
+}
+
+// CategoryResponse هو DTO لإرجاع بيانات التصنيف
+type CategoryResponse struct {
+	ID       uint   `json:"id"`
+	Name     string `json:"name"`
+	Slug     string `json:"slug"` // يُستخدم في التصفية GET /articles?category=slug
+	ParentID *uint  `json:"parent_id"`
+	// Children التصنيفات الفرعية، تظهر فقط في شجرة GET /categories
+	Children []CategoryResponse `json:"children,omitempty"`
+}
+
+// CategoryTreeResponse هو DTO لشجرة التصنيفات: التصنيفات الجذرية وتحت كل منها فروعه
+type CategoryTreeResponse struct {
+	Data []CategoryResponse `json:"data"`
+}
@@ -0,0 +1,48 @@
+// my-article-app/internal/dto/comment_dto.go
+package dto
+
+import "time"
+
+// CreateCommentRequest هو DTO لطلب إضافة تعليق على مقال أو رد على تعليق فيه
+type CreateCommentRequest struct {
+	AuthorName string `json:"author_name" validate:"required,max=100"`
+	Body       string `json:"body" validate:"required,max=5000"`
+	// ParentID التعليق المعتمد الذي يُرد عليه، ويُترك فارغًا للتعليق الجذري
+	ParentID *uint `json:"parent_id"`
+}
+
+// UpdateCommentRequest هو DTO لطلب تعديل نص التعليق، ويُرسل معه رمز التعديل في ترويسة X-Comment-Token
+type UpdateCommentRequest struct {
+	Body string `json:"body" validate:"required,max=5000"`
+}
+
+// CommentResponse هو DTO لإرجاع تعليق مع ردوده الظاهرة
+type CommentResponse struct {
+	ID         uint   `json:"id"`
+	ArticleID  uint   `json:"article_id"`
+	ParentID   *uint  `json:"parent_id"`
+	AuthorName string `json:"author_name"`
+	Body       string `json:"body"`
+	// Status حالة المراجعة: pending أو approved أو rejected
+	Status string `json:"status"`
+	// Deleted تعليق محذوف يبقى مكانًا لردوده، ويُعاد دون كاتبه ونصه
+	Deleted   bool       `json:"deleted,omitempty"`
+	EditedAt  *time.Time `json:"edited_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	// Replies الردود المعتمدة مرتبة من الأقدم، وتظهر فقط في قائمة تعليقات المقال
+	Replies []CommentResponse `json:"replies,omitempty"`
+}
+
+// CommentCreatedResponse هو DTO لإرجاع التعليق بعد إنشائه مع رمز تعديله
+type CommentCreatedResponse struct {
+	CommentResponse
+	// EditToken يُعطى مرة واحدة فقط، ويُرسل في ترويسة X-Comment-Token لتعديل التعليق أو حذفه
+	EditToken string `json:"edit_token"`
+}
+
+// CommentQueueQuery هو DTO لمعاملات طابور مراجعة التعليقات
+// مثال: ?status=rejected (الافتراضي pending)
+type CommentQueueQuery struct {
+	Status string `query:"status"`
+}
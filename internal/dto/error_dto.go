@@ -0,0 +1,19 @@
+// my-article-app/internal/dto/error_dto.go
+package dto
+
+// ErrorResponse جسم الخطأ الموحّد لجميع المسارات
+type ErrorResponse struct {
+	// Code رمز ثابت يعتمد عليه العميل بدل نص الرسالة (مثل article_not_found)
+	Code string `json:"code"`
+	// Message الرسالة بلغة الطلب حسب Accept-Language
+	Message     string       `json:"message"`
+	FieldErrors []FieldError `json:"field_errors"`
+	RequestID   string       `json:"request_id,omitempty"`
+}
+
+// FieldError خطأ تحقق في حقل واحد من جسم الطلب
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
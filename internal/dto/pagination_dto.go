@@ -0,0 +1,17 @@
+// my-article-app/internal/dto/pagination_dto.go
+package dto
+
+// PageMeta بيانات الترقيم المرفقة مع استجابات القوائم
+type PageMeta struct {
+	Total      int64  `json:"total"`
+	Limit      int    `json:"limit"`
+	Offset     int    `json:"offset"`
+	HasMore    bool   `json:"has_more"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// PageResponse هو DTO عام لإرجاع صفحة من العناصر مع بيانات الترقيم
+type PageResponse[T any] struct {
+	Data []T      `json:"data"`
+	Meta PageMeta `json:"meta"`
+}
@@ -0,0 +1,44 @@
+// my-article-app/internal/dto/revision_dto.go
+package dto
+
+import "time"
+
+// ArticleRevisionSummary هو DTO لمراجعة في قائمة مراجعات المقال، دون المحتوى
+type ArticleRevisionSummary struct {
+	Revision       uint      `json:"revision"`
+	ArticleVersion uint      `json:"article_version"` // رقم نسخة المقال (ETag) بعد هذا التعديل
+	Title          string    `json:"title"`
+	EditedBy       string    `json:"edited_by,omitempty"`
+	RevertedFrom   *uint     `json:"reverted_from,omitempty"` // رقم المراجعة التي استُرجعت إن كانت هذه المراجعة استرجاعًا
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// ArticleRevisionResponse هو DTO لمراجعة واحدة مع محتواها الكامل
+type ArticleRevisionResponse struct {
+	ArticleRevisionSummary
+	Content       string `json:"content"`
+	ContentFormat string `json:"content_format"`
+}
+
+// RevisionDiffQuery هو DTO لمعاملات المقارنة بين مراجعتين كما يرسلها العميل
+// مثال: ?from=2&to=5&granularity=word (الافتراضي: to آخر مراجعة، from التي قبلها، granularity=line)
+type RevisionDiffQuery struct {
+	From        string `query:"from"`
+	To          string `query:"to"`
+	Granularity string `query:"granularity"`
+}
+
+// DiffSegment مقطع متصل من النص: equal مشترك، insert مضاف في to، delete محذوف من from
+type DiffSegment struct {
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+// RevisionDiffResponse هو DTO لنتيجة المقارنة بين مراجعتين للعنوان والمحتوى
+type RevisionDiffResponse struct {
+	From        uint          `json:"from"`
+	To          uint          `json:"to"`
+	Granularity string        `json:"granularity"`
+	Title       []DiffSegment `json:"title"`
+	Content     []DiffSegment `json:"content"`
+}
@@ -0,0 +1,28 @@
+// my-article-app/internal/dto/tag_dto.go
+package dto
+
+// CreateTagRequest هو DTO لطلب إنشاء وسم، ومعرفه النصي يُشتق من اسمه
+type CreateTagRequest struct {
+	Name string `json:"name" validate:"required,max=50"`
+}
+
+// UpdateTagRequest هو DTO لطلب إعادة تسمية وسم
+type UpdateTagRequest struct {
+	Name string `json:"name" validate:"required,max=50"`
+}
+
+// TagResponse هو DTO لإرجاع بيانات الوسم
+type TagResponse struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"` // يُستخدم في التصفية GET /articles?tag=slug
+	// ArticleCount عدد المقالات المنشورة التي تحمل الوسم، يظهر فقط في قائمة الوسوم لبناء سحابة الوسوم
+	ArticleCount *int64 `json:"article_count,omitempty"`
+}
+
+// TagListQuery هو DTO لمعاملات تصفية وترتيب قائمة الوسوم
+// مثال: ?name=go&sort=-article_count,name
+type TagListQuery struct {
+	Name string `query:"name"`
+	Sort string `query:"sort"`
+}
@@ -0,0 +1,32 @@
+// my-article-app/internal/handlers/admin.go
+package handlers
+
+import (
+	"crypto/subtle"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// adminLocalKey مفتاح c.Locals الذي يحدد ما إذا كان الطلب من مشرف
+const adminLocalKey = "is_admin"
+
+// IdentifyAdmin يميّز طلبات المشرف بمقارنة Authorization: Bearer <token> مع رمز المشرف في الإعدادات
+// لا يرفض أي طلب بنفسه، بل تتحقق المعالجات من isAdmin قبل العمليات الإدارية؛ الرمز الفارغ يعطّلها تمامًا
+func IdentifyAdmin(token string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if token != "" {
+			bearer, ok := strings.CutPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+			if ok && subtle.ConstantTimeCompare([]byte(strings.TrimSpace(bearer)), []byte(token)) == 1 {
+				c.Locals(adminLocalKey, true)
+			}
+		}
+		return c.Next()
+	}
+}
+
+// isAdmin يحدد ما إذا كان الطلب الحالي قد عُرّف كطلب مشرف
+func isAdmin(c *fiber.Ctx) bool {
+	admin, _ := c.Locals(adminLocalKey).(bool)
+	return admin
+}
@@ -0,0 +1,121 @@
+// my-article-app/internal/handlers/article_bulk.go
+package handlers
+
+import (
+	"my-article-app/internal/apperr"
+	"my-article-app/internal/dto"
+	"my-article-app/internal/i18n"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// BulkCreateArticles يتعامل مع POST /articles/bulk لإنشاء عدة مقالات في طلب واحد
+func (h *articleHandler) BulkCreateArticles(c *fiber.Ctx) error {
+	req := new(dto.BulkCreateArticlesRequest)
+	if err := c.BodyParser(req); err != nil {
+		return errInvalidBody
+	}
+	if err := validate.Struct(req); err != nil {
+		return validationError(err)
+	}
+
+	rejected := validateItems(req.Items, nil)
+	resp, err := h.articleUseCase.BulkCreateArticles(c.UserContext(), req.Mode, req.Items, rejected)
+	if err != nil {
+		return err
+	}
+	return sendBulk(c, resp, fiber.StatusCreated)
+}
+
+// BulkUpdateArticles يتعامل مع PATCH /articles/bulk لتحديث عدة مقالات في طلب واحد
+func (h *articleHandler) BulkUpdateArticles(c *fiber.Ctx) error {
+	req := new(dto.BulkUpdateArticlesRequest)
+	if err := c.BodyParser(req); err != nil {
+		return errInvalidBody
+	}
+	if err := validate.Struct(req); err != nil {
+		return validationError(err)
+	}
+
+	// مع اشتراط If-Match يجب أن يحمل كل عنصر رقم نسخته، لأن الترويسة الواحدة لا تصف عدة سجلات
+	var requireVersion func(item *dto.BulkUpdateArticleItem) error
+	if itemVersionRequired(c) {
+		requireVersion = func(item *dto.BulkUpdateArticleItem) error {
+			if item.Version == nil {
+				return apperr.PreconditionRequired("item_version_required")
+			}
+			return nil
+		}
+	}
+
+	rejected := validateItems(req.Items, requireVersion)
+	resp, err := h.articleUseCase.BulkUpdateArticles(c.UserContext(), req.Mode, req.Items, rejected)
+	if err != nil {
+		return err
+	}
+	return sendBulk(c, resp, fiber.StatusOK)
+}
+
+// BulkDeleteArticles يتعامل مع DELETE /articles/bulk لنقل عدة مقالات إلى سلة المحذوفات
+func (h *articleHandler) BulkDeleteArticles(c *fiber.Ctx) error {
+	req := new(dto.BulkDeleteArticlesRequest)
+	if err := c.BodyParser(req); err != nil {
+		return errInvalidBody
+	}
+	if err := validate.Struct(req); err != nil {
+		return validationError(err)
+	}
+
+	rejected := make(map[int]error)
+	for i, id := range req.IDs {
+		if id == 0 {
+			rejected[i] = apperr.Validation("invalid_article_id")
+		}
+	}
+	resp, err := h.articleUseCase.BulkDeleteArticles(c.UserContext(), req.Mode, req.IDs, rejected)
+	if err != nil {
+		return err
+	}
+	return sendBulk(c, resp, fiber.StatusNoContent)
+}
+
+// validateItems يتحقق من كل عنصر بمفرده بـ validate.Struct، ثم بالفحص الإضافي extra إن وُجد،
+// ويعيد أخطاء العناصر المرفوضة مفهرسة بترتيبها حتى تظهر في التقرير بدل رفض الطلب كله
+func validateItems[T any](items []T, extra func(item *T) error) map[int]error {
+	rejected := make(map[int]error)
+	for i := range items {
+		if err := validate.Struct(&items[i]); err != nil {
+			rejected[i] = validationError(err)
+			continue
+		}
+		if extra != nil {
+			if err := extra(&items[i]); err != nil {
+				rejected[i] = err
+			}
+		}
+	}
+	return rejected
+}
+
+// sendBulk يحوّل خطأ كل عنصر إلى رمز HTTP وجسم خطأ مترجم كما يفعل ErrorHandler،
+// ويعيد 200 إذا نجحت كل العناصر أو 207 Multi-Status إذا فشل بعضها
+func sendBulk(c *fiber.Ctx, resp *dto.BulkResponse, itemStatus int) error {
+	lang := i18n.Negotiate(c.Get(fiber.HeaderAcceptLanguage))
+	c.Vary(fiber.HeaderAcceptLanguage)
+
+	for i := range resp.Results {
+		item := &resp.Results[i]
+		if item.Err == nil {
+			item.Status = itemStatus
+			continue
+		}
+		status, body := errorResponse(c, item.Err, lang)
+		item.Status, item.Error = status, &body
+	}
+
+	status := fiber.StatusOK
+	if resp.Failed > 0 {
+		status = fiber.StatusMultiStatus
+	}
+	return c.Status(status).JSON(resp)
+}
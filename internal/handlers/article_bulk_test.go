@@ -0,0 +1,122 @@
+// my-article-app/internal/handlers/article_bulk_test.go
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"my-article-app/internal/dto"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// sendBulkRequest يرسل طلبًا مجمّعًا ويفك تقريره
+func sendBulkRequest(t *testing.T, app *fiber.App, method, body string) (int, dto.BulkResponse) {
+	t.Helper()
+	req := httptest.NewRequest(method, "/articles/bulk", strings.NewReader(body))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	req.Header.Set(fiber.HeaderAcceptLanguage, "en")
+	status, respBody := doRequest(t, app, req)
+	var resp dto.BulkResponse
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		t.Fatalf("decode %s: %v", respBody, err)
+	}
+	return status, resp
+}
+
+// wantItemStatuses يقارن رمز كل عنصر في التقرير ورمز خطئه بالمتوقع بترتيبه
+func wantItemStatuses(t *testing.T, resp dto.BulkResponse, statuses []int, codes []string) {
+	t.Helper()
+	if len(resp.Results) != len(statuses) {
+		t.Fatalf("results = %+v, want %d items", resp.Results, len(statuses))
+	}
+	for i, item := range resp.Results {
+		if item.Status != statuses[i] {
+			t.Errorf("item %d status = %d, want %d", i, item.Status, statuses[i])
+		}
+		if codes[i] == "" {
+			if item.Error != nil {
+				t.Errorf("item %d error = %+v, want none", i, item.Error)
+			}
+			continue
+		}
+		// كل عنصر فاشل يحمل جسم الخطأ الموحّد نفسه برسالة بلغة الطلب
+		if item.Error == nil || item.Error.Code != codes[i] || item.Error.Message == "" || item.Error.FieldErrors == nil {
+			t.Errorf("item %d error = %+v, want envelope with code %q", i, item.Error, codes[i])
+		}
+	}
+}
+
+func TestBulkCreateHandler(t *testing.T) {
+	store := newTestStore()
+	author := store.createArticle(t, "Seed article title", "").Author
+	app := newTestApp()
+	handler := NewArticleHandler(store.articles)
+	app.Post("/articles/bulk", handler.BulkCreateArticles)
+
+	items := func(mode string) string {
+		return fmt.Sprintf(`{"mode":%q,"items":[
+			{"title":"First bulk article","content":"bulk article content","author_id":%d},
+			{"title":"bad","content":"bulk article content","author_id":%[2]d},
+			{"title":"Second bulk article","content":"bulk article content","author_id":%[2]d}]}`, mode, author.ID)
+	}
+
+	status, resp := sendBulkRequest(t, app, fiber.MethodPost, items(dto.BulkModeAtomic))
+	if status != fiber.StatusMultiStatus || resp.Succeeded != 0 || resp.Failed != 3 {
+		t.Fatalf("atomic = %d %+v, want 207 with every item failed", status, resp)
+	}
+	wantItemStatuses(t, resp,
+		[]int{fiber.StatusFailedDependency, fiber.StatusBadRequest, fiber.StatusFailedDependency},
+		[]string{"bulk_aborted", "validation_failed", "bulk_aborted"})
+	if fe := resp.Results[1].Error.FieldErrors; len(fe) != 1 || fe[0].Field != "title" {
+		t.Errorf("rejected item field_errors = %+v, want title", fe)
+	}
+
+	status, resp = sendBulkRequest(t, app, fiber.MethodPost, items(dto.BulkModeBestEffort))
+	if status != fiber.StatusMultiStatus || resp.Succeeded != 2 || resp.Failed != 1 {
+		t.Fatalf("best_effort = %d %+v, want 207 with 2 of 3 created", status, resp)
+	}
+	wantItemStatuses(t, resp,
+		[]int{fiber.StatusCreated, fiber.StatusBadRequest, fiber.StatusCreated},
+		[]string{"", "validation_failed", ""})
+
+	status, resp = sendBulkRequest(t, app, fiber.MethodPost, fmt.Sprintf(`{"items":[
+		{"title":"Third bulk article","content":"bulk article content","author_id":%d}]}`, author.ID))
+	if status != fiber.StatusOK || resp.Mode != dto.BulkModeAtomic || resp.Succeeded != 1 {
+		t.Errorf("all succeeded = %d %+v, want 200 in atomic mode", status, resp)
+	}
+}
+
+// TestBulkUpdateItemVersions يمر بالمسار كما يسجله cmd/api مع اشتراط If-Match
+func TestBulkUpdateItemVersions(t *testing.T) {
+	store := newTestStore()
+	first := store.createArticle(t, "First versioned article", "")
+	second := store.createArticle(t, "Second versioned article", "")
+	third := store.createArticle(t, "Third versioned article", "")
+	app := newTestApp()
+	app.Patch("/articles/bulk", RequireItemVersions(), NewArticleHandler(store.articles).BulkUpdateArticles)
+
+	status, resp := sendBulkRequest(t, app, fiber.MethodPatch, fmt.Sprintf(`{"mode":"best_effort","items":[
+		{"id":%d,"version":1,"content":"updated with version"},
+		{"id":%d,"content":"updated without version"},
+		{"id":%d,"version":7,"content":"updated with stale version"}]}`, first.ID, second.ID, third.ID))
+	if status != fiber.StatusMultiStatus {
+		t.Fatalf("status = %d, want 207", status)
+	}
+	wantItemStatuses(t, resp,
+		[]int{fiber.StatusOK, fiber.StatusPreconditionRequired, fiber.StatusPreconditionFailed},
+		[]string{"", "item_version_required", "version_mismatch"})
+	if got := resp.Results[0].Article; got == nil || got.Version != 2 {
+		t.Errorf("updated article = %+v, want version 2", got)
+	}
+
+	// بدون RequireItemVersions يبقى version اختياريًا
+	app = newTestApp()
+	app.Patch("/articles/bulk", NewArticleHandler(store.articles).BulkUpdateArticles)
+	status, resp = sendBulkRequest(t, app, fiber.MethodPatch, fmt.Sprintf(`{"items":[{"id":%d,"content":"updated without version"}]}`, second.ID))
+	if status != fiber.StatusOK || resp.Succeeded != 1 {
+		t.Errorf("optional version = %d %+v, want 200", status, resp)
+	}
+}
@@ -2,26 +2,35 @@
 package handlers
 
 import (
-	"fmt"
-	"log"
+	"my-article-app/internal/apperr"
 	"my-article-app/internal/dto"
 	"my-article-app/internal/usecase"
 	"strconv"
 
-	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 )
 
-// إعادة استخدام نفس المتغير العام
-var validate = validator.New()
-
-
 type ArticleHandler interface {
 	CreateArticle(c *fiber.Ctx) error
 	GetAllArticles(c *fiber.Ctx) error
+	SearchArticles(c *fiber.Ctx) error
 	GetArticleByID(c *fiber.Ctx) error
+	GetArticleBySlug(c *fiber.Ctx) error
 	UpdateArticle(c *fiber.Ctx) error
 	DeleteArticle(c *fiber.Ctx) error
+	GetTrash(c *fiber.Ctx) error
+	RestoreArticle(c *fiber.Ctx) error
+	BulkCreateArticles(c *fiber.Ctx) error
+	BulkUpdateArticles(c *fiber.Ctx) error
+	BulkDeleteArticles(c *fiber.Ctx) error
+	PublishArticle(c *fiber.Ctx) error
+	UnpublishArticle(c *fiber.Ctx) error
+	ArchiveArticle(c *fiber.Ctx) error
+	ScheduleArticle(c *fiber.Ctx) error
+	GetArticleRevisions(c *fiber.Ctx) error
+	GetArticleRevision(c *fiber.Ctx) error
+	DiffArticleRevisions(c *fiber.Ctx) error
+	RevertArticle(c *fiber.Ctx) error
 }
 
 type articleHandler struct {
@@ -37,46 +46,103 @@ func (h *articleHandler) CreateArticle(c *fiber.Ctx) error {
 	req := new(dto.CreateArticleRequest)
 
 	if err := c.BodyParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "جسم الطلب غير صالح."})
+		return errInvalidBody
 	}
 
 	if err := validate.Struct(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "خطأ في التحقق من صحة البيانات.", "details": err.Error()})
+		return validationError(err)
 	}
 
-	articleResponse, err := h.articleUseCase.CreateArticle(req)
+	articleResponse, err := h.articleUseCase.CreateArticle(c.UserContext(), req)
 	if err != nil {
-		log.Printf("خطأ في إنشاء المقال: %v", err)
-		// قد يكون الخطأ لأن المؤلف غير موجود
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		return err
 	}
 
+	setVersionETag(c, articleResponse.Version)
 	return c.Status(fiber.StatusCreated).JSON(articleResponse)
 }
 
-// GetAllArticles يجلب جميع المقالات
+// GetAllArticles يجلب صفحة من المقالات (?limit=&offset= أو ?cursor=) مع التصفية والترتيب؛
+// ?status= بغير published للمشرف فقط
 func (h *articleHandler) GetAllArticles(c *fiber.Ctx) error {
-	articles, err := h.articleUseCase.GetAllArticles()
+	pageReq, err := parsePageRequest(c)
+	if err != nil {
+		return err
+	}
+
+	query := new(dto.ArticleListQuery)
+	if err := c.QueryParser(query); err != nil {
+		return errInvalidQueryParams
+	}
+
+	articles, err := h.articleUseCase.GetAllArticles(c.UserContext(), query, pageReq, isAdmin(c))
 	if err != nil {
-		log.Printf("خطأ في جلب المقالات: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "فشل جلب المقالات."})
+		return err
 	}
+
+	setPageLinks(c, pageReq, articles.Meta)
 	return c.JSON(articles)
 }
 
-// GetArticleByID يجلب مقالًا واحدًا حسب ID
+// SearchArticles يبحث في المقالات حسب ?q= مع الترقيم بالإزاحة
+func (h *articleHandler) SearchArticles(c *fiber.Ctx) error {
+	pageReq, err := parsePageRequest(c)
+	if err != nil {
+		return err
+	}
+
+	results, err := h.articleUseCase.SearchArticles(c.UserContext(), c.Query("q"), pageReq)
+	if err != nil {
+		return err
+	}
+
+	setPageLinks(c, pageReq, results.Meta)
+	return c.JSON(results)
+}
+
+// GetArticleByID يجلب مقالًا واحدًا حسب ID؛ المقال غير المنشور يظهر للمشرف فقط
+// ?render=html يضيف content_html، و raw (الافتراضي) يعيد المحتوى بصيغته فقط
 func (h *articleHandler) GetArticleByID(c *fiber.Ctx) error {
 	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "معرف المقال غير صالح."})
+		return apperr.Validation("invalid_article_id")
+	}
+
+	query := new(dto.ArticleReadQuery)
+	if err := c.QueryParser(query); err != nil {
+		return errInvalidQueryParams
+	}
+
+	article, err := h.articleUseCase.GetArticleByID(c.UserContext(), uint(id), query, isAdmin(c))
+	if err != nil {
+		return err
+	}
+
+	setVersionETag(c, article.Version)
+	return c.JSON(article)
+}
+
+// GetArticleBySlug يجلب مقالًا بمعرفه النصي، ويعيد التوجيه (301) من معرف سابق إلى معرفه الحالي
+func (h *articleHandler) GetArticleBySlug(c *fiber.Ctx) error {
+	slug, err := slugParam(c)
+	if err != nil {
+		return err
+	}
+
+	query := new(dto.ArticleReadQuery)
+	if err := c.QueryParser(query); err != nil {
+		return errInvalidQueryParams
 	}
 
-	article, err := h.articleUseCase.GetArticleByID(uint(id))
+	article, moved, err := h.articleUseCase.GetArticleBySlug(c.UserContext(), slug, query, isAdmin(c))
 	if err != nil {
-		log.Printf("خطأ في جلب المقال: %v", err)
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": fmt.Sprintf("المقال بالمعرف %d غير موجود.", id)})
+		return err
+	}
+	if moved {
+		return c.Redirect(slugLocation(c, article.Slug), fiber.StatusMovedPermanently)
 	}
 
+	setVersionETag(c, article.Version)
 	return c.JSON(article)
 }
 
@@ -84,38 +150,91 @@ func (h *articleHandler) GetArticleByID(c *fiber.Ctx) error {
 func (h *articleHandler) UpdateArticle(c *fiber.Ctx) error {
 	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "معرف المقال غير صالح."})
+		return apperr.Validation("invalid_article_id")
 	}
 
 	req := new(dto.UpdateArticleRequest)
 	if err := c.BodyParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "جسم الطلب غير صالح."})
+		return errInvalidBody
 	}
 
 	if err := validate.Struct(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "خطأ في التحقق من صحة البيانات.", "details": err.Error()})
+		return validationError(err)
+	}
+
+	expectedVersion, err := ifMatchVersion(c)
+	if err != nil {
+		return err
 	}
 
-	articleResponse, err := h.articleUseCase.UpdateArticle(uint(id), req)
+	articleResponse, err := h.articleUseCase.UpdateArticle(c.UserContext(), uint(id), req, expectedVersion)
 	if err != nil {
-		log.Printf("خطأ في تحديث المقال: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "فشل تحديث المقال."})
+		return err
 	}
 
+	setVersionETag(c, articleResponse.Version)
 	return c.JSON(articleResponse)
 }
 
-// DeleteArticle يحذف مقالًا
+// DeleteArticle ينقل المقال إلى سلة المحذوفات، أو يحذفه نهائيًا مع ?purge=true (للمشرف فقط)
 func (h *articleHandler) DeleteArticle(c *fiber.Ctx) error {
 	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "معرف المقال غير صالح."})
+		return apperr.Validation("invalid_article_id")
 	}
 
-	if err := h.articleUseCase.DeleteArticle(uint(id)); err != nil {
-		log.Printf("خطأ في حذف المقال: %v", err)
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": fmt.Sprintf("المقال بالمعرف %d غير موجود أو فشلت عملية الحذف.", id)})
+	remove := h.articleUseCase.DeleteArticle
+	if c.QueryBool("purge") {
+		if !isAdmin(c) {
+			return apperr.Forbidden("purge_forbidden")
+		}
+		remove = h.articleUseCase.PurgeArticle
+	}
+
+	if err := remove(c.UserContext(), uint(id)); err != nil {
+		return err
 	}
 
 	return c.SendStatus(fiber.StatusNoContent)
-}
\ No newline at end of file
+}
+
+// GetTrash يجلب صفحة من المقالات المحذوفة منطقيًا (?limit=&offset= أو ?cursor=، للمشرف فقط)
+// السلة تضم المسودات والمقالات المجدولة، فلا تُعرض لغير المشرف
+func (h *articleHandler) GetTrash(c *fiber.Ctx) error {
+	if !isAdmin(c) {
+		return apperr.Forbidden("trash_forbidden")
+	}
+
+	pageReq, err := parsePageRequest(c)
+	if err != nil {
+		return err
+	}
+
+	page, err := h.articleUseCase.GetTrash(c.UserContext(), pageReq)
+	if err != nil {
+		return err
+	}
+
+	setPageLinks(c, pageReq, page.Meta)
+	return c.JSON(page)
+}
+
+// RestoreArticle يعيد مقالاً من سلة المحذوفات (للمشرف فقط)
+func (h *articleHandler) RestoreArticle(c *fiber.Ctx) error {
+	if !isAdmin(c) {
+		return apperr.Forbidden("trash_forbidden")
+	}
+
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return apperr.Validation("invalid_article_id")
+	}
+
+	article, err := h.articleUseCase.RestoreArticle(c.UserContext(), uint(id))
+	if err != nil {
+		return err
+	}
+
+	setVersionETag(c, article.Version)
+	return c.JSON(article)
+}
@@ -0,0 +1,98 @@
+// my-article-app/internal/handlers/article_revision.go
+package handlers
+
+import (
+	"my-article-app/internal/apperr"
+	"my-article-app/internal/dto"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetArticleRevisions يجلب صفحة من مراجعات المقال (?limit=&offset= أو ?cursor=)
+func (h *articleHandler) GetArticleRevisions(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return apperr.Validation("invalid_article_id")
+	}
+
+	pageReq, err := parsePageRequest(c)
+	if err != nil {
+		return err
+	}
+
+	revisions, err := h.articleUseCase.GetArticleRevisions(c.UserContext(), uint(id), pageReq, isAdmin(c))
+	if err != nil {
+		return err
+	}
+
+	setPageLinks(c, pageReq, revisions.Meta)
+	return c.JSON(revisions)
+}
+
+// GetArticleRevision يجلب مراجعة واحدة مع محتواها
+func (h *articleHandler) GetArticleRevision(c *fiber.Ctx) error {
+	id, rev, err := revisionParams(c)
+	if err != nil {
+		return err
+	}
+
+	revision, err := h.articleUseCase.GetArticleRevision(c.UserContext(), id, rev, isAdmin(c))
+	if err != nil {
+		return err
+	}
+	return c.JSON(revision)
+}
+
+// DiffArticleRevisions يقارن مراجعتين (?from=&to=&granularity=line|word)
+func (h *articleHandler) DiffArticleRevisions(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return apperr.Validation("invalid_article_id")
+	}
+
+	query := new(dto.RevisionDiffQuery)
+	if err := c.QueryParser(query); err != nil {
+		return errInvalidQueryParams
+	}
+
+	diff, err := h.articleUseCase.DiffArticleRevisions(c.UserContext(), uint(id), query, isAdmin(c))
+	if err != nil {
+		return err
+	}
+	return c.JSON(diff)
+}
+
+// RevertArticle يعيد المقال إلى محتوى مراجعة سابقة بمراجعة جديدة
+func (h *articleHandler) RevertArticle(c *fiber.Ctx) error {
+	id, rev, err := revisionParams(c)
+	if err != nil {
+		return err
+	}
+
+	expectedVersion, err := ifMatchVersion(c)
+	if err != nil {
+		return err
+	}
+
+	article, err := h.articleUseCase.RevertArticle(c.UserContext(), id, rev, expectedVersion)
+	if err != nil {
+		return err
+	}
+
+	setVersionETag(c, article.Version)
+	return c.JSON(article)
+}
+
+// revisionParams يحلل معرف المقال ورقم المراجعة من المسار
+func revisionParams(c *fiber.Ctx) (uint, uint, error) {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return 0, 0, apperr.Validation("invalid_article_id")
+	}
+	rev, err := strconv.ParseUint(c.Params("rev"), 10, 32)
+	if err != nil || rev == 0 {
+		return 0, 0, apperr.Validation("invalid_revision_number")
+	}
+	return uint(id), uint(rev), nil
+}
@@ -0,0 +1,62 @@
+// my-article-app/internal/handlers/article_status.go
+package handlers
+
+import (
+	"context"
+	"my-article-app/internal/apperr"
+	"my-article-app/internal/dto"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// PublishArticle ينشر المقال فورًا (POST /articles/:id/publish)
+func (h *articleHandler) PublishArticle(c *fiber.Ctx) error {
+	return h.transition(c, h.articleUseCase.PublishArticle)
+}
+
+// UnpublishArticle يعيد المقال مسودة (POST /articles/:id/unpublish)
+func (h *articleHandler) UnpublishArticle(c *fiber.Ctx) error {
+	return h.transition(c, h.articleUseCase.UnpublishArticle)
+}
+
+// ArchiveArticle يؤرشف المقال (POST /articles/:id/archive)
+func (h *articleHandler) ArchiveArticle(c *fiber.Ctx) error {
+	return h.transition(c, h.articleUseCase.ArchiveArticle)
+}
+
+// ScheduleArticle يجدول نشر المقال في publish_at من جسم الطلب (POST /articles/:id/schedule)
+func (h *articleHandler) ScheduleArticle(c *fiber.Ctx) error {
+	req := new(dto.ScheduleArticleRequest)
+	if err := c.BodyParser(req); err != nil {
+		return errInvalidBody
+	}
+	if err := validate.Struct(req); err != nil {
+		return validationError(err)
+	}
+
+	return h.transition(c, func(ctx context.Context, id uint, expectedVersion *uint) (*dto.ArticleResponse, error) {
+		return h.articleUseCase.ScheduleArticle(ctx, id, *req.PublishAt, expectedVersion)
+	})
+}
+
+// transition يحلل المعرف وترويسة If-Match ثم ينفذ انتقال الحالة ويعيد المقال مع ETag الجديد
+func (h *articleHandler) transition(c *fiber.Ctx, apply func(ctx context.Context, id uint, expectedVersion *uint) (*dto.ArticleResponse, error)) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return apperr.Validation("invalid_article_id")
+	}
+
+	expectedVersion, err := ifMatchVersion(c)
+	if err != nil {
+		return err
+	}
+
+	article, err := apply(c.UserContext(), uint(id), expectedVersion)
+	if err != nil {
+		return err
+	}
+
+	setVersionETag(c, article.Version)
+	return c.JSON(article)
+}
@@ -0,0 +1,101 @@
+// my-article-app/internal/handlers/article_trash_test.go
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// TestPurgeRequiresAdmin يتحقق أن الحذف النهائي ?purge=true مقصور على المشرف
+func TestPurgeRequiresAdmin(t *testing.T) {
+	store := newTestStore()
+	article := store.createArticle(t, "Purged article", "")
+	app := newTestApp()
+	app.Delete("/articles/:id", IdentifyAdmin("secret"), NewArticleHandler(store.articles).DeleteArticle)
+	path := "/articles/" + strconv.FormatUint(uint64(article.ID), 10) + "?purge=true"
+
+	tests := []struct {
+		name          string
+		authorization string
+		status        int
+		wantCode      string
+	}{
+		{name: "anonymous", status: fiber.StatusForbidden, wantCode: "purge_forbidden"},
+		{name: "wrong token", authorization: "Bearer guess", status: fiber.StatusForbidden, wantCode: "purge_forbidden"},
+		{name: "admin", authorization: "Bearer secret", status: fiber.StatusNoContent},
+		{name: "already purged", authorization: "Bearer secret", status: fiber.StatusNotFound, wantCode: "article_not_found"},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(fiber.MethodDelete, path, nil)
+		if tt.authorization != "" {
+			req.Header.Set(fiber.HeaderAuthorization, tt.authorization)
+		}
+		status, body := doRequest(t, app, req)
+		if status != tt.status {
+			t.Fatalf("%s: status = %d, want %d; body %s", tt.name, status, tt.status, body)
+		}
+		if tt.wantCode != "" {
+			if got := decodeError(t, body).Code; got != tt.wantCode {
+				t.Errorf("%s: code = %q, want %q", tt.name, got, tt.wantCode)
+			}
+		}
+		if tt.name == "wrong token" {
+			// الرفض لا ينقل المقال إلى السلة ولا يحذفه
+			if _, err := store.articles.GetArticleByID(context.Background(), article.ID, nil, true); err != nil {
+				t.Fatalf("article after refused purge: %v", err)
+			}
+		}
+	}
+	if _, err := store.repos.Articles.FindTrashedByID(context.Background(), article.ID); err == nil {
+		t.Error("purged article is still in the trash")
+	}
+}
+
+// TestTrashRequiresAdmin يتحقق أن السلة واستعادة المقالات منها مقصورتان على المشرف،
+// لأن السلة تضم مسودات ومقالات مجدولة لا تظهر للقراء
+func TestTrashRequiresAdmin(t *testing.T) {
+	store := newTestStore()
+	draft := store.createArticle(t, "Trashed draft article", "")
+	if err := store.articles.DeleteArticle(context.Background(), draft.ID); err != nil {
+		t.Fatalf("DeleteArticle: %v", err)
+	}
+	app := newTestApp()
+	handler := NewArticleHandler(store.articles)
+	app.Get("/articles/trash", IdentifyAdmin("secret"), handler.GetTrash)
+	app.Post("/articles/:id/restore", IdentifyAdmin("secret"), handler.RestoreArticle)
+	restorePath := "/articles/" + strconv.FormatUint(uint64(draft.ID), 10) + "/restore"
+
+	for _, req := range []*http.Request{
+		httptest.NewRequest(fiber.MethodGet, "/articles/trash", nil),
+		httptest.NewRequest(fiber.MethodPost, restorePath, nil),
+	} {
+		req.Header.Set(fiber.HeaderAuthorization, "Bearer guess")
+		status, body := doRequest(t, app, req)
+		if status != fiber.StatusForbidden || decodeError(t, body).Code != "trash_forbidden" {
+			t.Errorf("%s %s without admin = %d %s, want 403 trash_forbidden", req.Method, req.URL.Path, status, body)
+		}
+		if strings.Contains(string(body), draft.Title) {
+			t.Errorf("%s %s leaks the trashed draft: %s", req.Method, req.URL.Path, body)
+		}
+	}
+
+	req := httptest.NewRequest(fiber.MethodGet, "/articles/trash", nil)
+	req.Header.Set(fiber.HeaderAuthorization, "Bearer secret")
+	status, body := doRequest(t, app, req)
+	if status != fiber.StatusOK || !strings.Contains(string(body), draft.Title) {
+		t.Errorf("admin trash = %d %s, want 200 listing the draft", status, body)
+	}
+
+	req = httptest.NewRequest(fiber.MethodPost, restorePath, nil)
+	req.Header.Set(fiber.HeaderAuthorization, "Bearer secret")
+	resp, body := send(t, app, req)
+	if resp.StatusCode != fiber.StatusOK || resp.Header.Get(fiber.HeaderETag) == "" {
+		t.Errorf("admin restore = %d %s, want 200 with an ETag", resp.StatusCode, body)
+	}
+}
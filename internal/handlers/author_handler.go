@@ -2,8 +2,7 @@
 package handlers
 
 import (
-	"fmt"
-	"log"
+	"my-article-app/internal/apperr"
 	"my-article-app/internal/dto"
 	"my-article-app/internal/usecase"
 	"strconv"
@@ -11,11 +10,11 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
-
 type AuthorHandler interface {
 	CreateAuthor(c *fiber.Ctx) error
 	GetAllAuthors(c *fiber.Ctx) error
 	GetAuthorByID(c *fiber.Ctx) error
+	GetAuthorBySlug(c *fiber.Ctx) error
 	UpdateAuthor(c *fiber.Ctx) error
 	DeleteAuthor(c *fiber.Ctx) error
 }
@@ -33,29 +32,40 @@ func (h *authorHandler) CreateAuthor(c *fiber.Ctx) error {
 	req := new(dto.CreateAuthorRequest)
 
 	if err := c.BodyParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "جسم الطلب غير صالح."})
+		return errInvalidBody
 	}
 
 	if err := validate.Struct(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "خطأ في التحقق من صحة البيانات.", "details": err.Error()})
+		return validationError(err)
 	}
 
-	authorResponse, err := h.authorUseCase.CreateAuthor(req)
+	authorResponse, err := h.authorUseCase.CreateAuthor(c.UserContext(), req)
 	if err != nil {
-		log.Printf("خطأ في إنشاء المؤلف: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "فشل إنشاء المؤلف."})
+		return err
 	}
 
+	setVersionETag(c, authorResponse.Version)
 	return c.Status(fiber.StatusCreated).JSON(authorResponse)
 }
 
-// GetAllAuthors يجلب جميع المؤلفين
+// GetAllAuthors يجلب صفحة من المؤلفين (?limit=&offset= أو ?cursor=) مع البحث بالاسم (?name=)
 func (h *authorHandler) GetAllAuthors(c *fiber.Ctx) error {
-	authors, err := h.authorUseCase.GetAllAuthors()
+	pageReq, err := parsePageRequest(c)
+	if err != nil {
+		return err
+	}
+
+	query := new(dto.AuthorListQuery)
+	if err := c.QueryParser(query); err != nil {
+		return errInvalidQueryParams
+	}
+
+	authors, err := h.authorUseCase.GetAllAuthors(c.UserContext(), query, pageReq)
 	if err != nil {
-		log.Printf("خطأ في جلب المؤلفين: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "فشل جلب المؤلفين."})
+		return err
 	}
+
+	setPageLinks(c, pageReq, authors.Meta)
 	return c.JSON(authors)
 }
 
@@ -63,15 +73,31 @@ func (h *authorHandler) GetAllAuthors(c *fiber.Ctx) error {
 func (h *authorHandler) GetAuthorByID(c *fiber.Ctx) error {
 	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "معرف المؤلف غير صالح."})
+		return apperr.Validation("invalid_author_id")
+	}
+
+	author, err := h.authorUseCase.GetAuthorByID(c.UserContext(), uint(id))
+	if err != nil {
+		return err
+	}
+
+	setVersionETag(c, author.Version)
+	return c.JSON(author)
+}
+
+// GetAuthorBySlug يجلب مؤلفًا مع مقالاته بمعرفه النصي
+func (h *authorHandler) GetAuthorBySlug(c *fiber.Ctx) error {
+	slug, err := slugParam(c)
+	if err != nil {
+		return err
 	}
 
-	author, err := h.authorUseCase.GetAuthorByID(uint(id))
+	author, err := h.authorUseCase.GetAuthorBySlug(c.UserContext(), slug)
 	if err != nil {
-		log.Printf("خطأ في جلب المؤلف: %v", err)
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": fmt.Sprintf("المؤلف بالمعرف %d غير موجود.", id)})
+		return err
 	}
 
+	setVersionETag(c, author.Version)
 	return c.JSON(author)
 }
 
@@ -79,38 +105,47 @@ func (h *authorHandler) GetAuthorByID(c *fiber.Ctx) error {
 func (h *authorHandler) UpdateAuthor(c *fiber.Ctx) error {
 	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "معرف المؤلف غير صالح."})
+		return apperr.Validation("invalid_author_id")
 	}
 
 	req := new(dto.UpdateAuthorRequest)
 	if err := c.BodyParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "جسم الطلب غير صالح."})
+		return errInvalidBody
 	}
 
 	if err := validate.Struct(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "خطأ في التحقق من صحة البيانات.", "details": err.Error()})
+		return validationError(err)
 	}
 
-	authorResponse, err := h.authorUseCase.UpdateAuthor(uint(id), req)
+	expectedVersion, err := ifMatchVersion(c)
 	if err != nil {
-		log.Printf("خطأ في تحديث المؤلف: %v", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "فشل تحديث المؤلف."})
+		return err
 	}
 
+	authorResponse, err := h.authorUseCase.UpdateAuthor(c.UserContext(), uint(id), req, expectedVersion)
+	if err != nil {
+		return err
+	}
+
+	setVersionETag(c, authorResponse.Version)
 	return c.JSON(authorResponse)
 }
 
-// DeleteAuthor يحذف مؤلفًا
+// DeleteAuthor يحذف مؤلفًا مع سياسة مقالاته ?on_articles=block|cascade|reassign&to=ID (الافتراضي block)
 func (h *authorHandler) DeleteAuthor(c *fiber.Ctx) error {
 	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "معرف المؤلف غير صالح."})
+		return apperr.Validation("invalid_author_id")
 	}
 
-	if err := h.authorUseCase.DeleteAuthor(uint(id)); err != nil {
-		log.Printf("خطأ في حذف المؤلف: %v", err)
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": fmt.Sprintf("المؤلف بالمعرف %d غير موجود أو فشلت عملية الحذف.", id)})
+	query := new(dto.DeleteAuthorQuery)
+	if err := c.QueryParser(query); err != nil {
+		return errInvalidQueryParams
+	}
+
+	if err := h.authorUseCase.DeleteAuthor(c.UserContext(), uint(id), query); err != nil {
+		return err
 	}
 
 	return c.SendStatus(fiber.StatusNoContent)
-}
\ No newline at end of file
+}
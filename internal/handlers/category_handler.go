@@ -0,0 +1,113 @@
+// my-article-app/internal/handlers/category_handler.go
+package handlers
+
+import (
+	"my-article-app/internal/apperr"
+	"my-article-app/internal/dto"
+	"my-article-app/internal/usecase"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type CategoryHandler interface {
+	CreateCategory(c *fiber.Ctx) error
+	GetCategoryTree(c *fiber.Ctx) error
+	GetCategoryByID(c *fiber.Ctx) error
+	UpdateCategory(c *fiber.Ctx) error
+	DeleteCategory(c *fiber.Ctx) error
+}
+
+type categoryHandler struct {
+	categoryUseCase usecase.CategoryUseCase
+}
+
+func NewCategoryHandler(categoryUseCase usecase.CategoryUseCase) CategoryHandler {
+	return &categoryHandler{categoryUseCase: categoryUseCase}
+}
+
+// categoryID يقرأ معرف التصنيف من المسار
+func categoryID(c *fiber.Ctx) (uint, error) {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return 0, apperr.Validation("invalid_category_id")
+	}
+	return uint(id), nil
+}
+
+// CreateCategory يتعامل مع طلبات POST لإنشاء تصنيف جديد
+func (h *categoryHandler) CreateCategory(c *fiber.Ctx) error {
+	req := new(dto.CreateCategoryRequest)
+	if err := c.BodyParser(req); err != nil {
+		return errInvalidBody
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return validationError(err)
+	}
+
+	category, err := h.categoryUseCase.CreateCategory(c.UserContext(), req)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusCreated).JSON(category)
+}
+
+// GetCategoryTree يجلب شجرة التصنيفات كاملة
+func (h *categoryHandler) GetCategoryTree(c *fiber.Ctx) error {
+	tree, err := h.categoryUseCase.GetCategoryTree(c.UserContext())
+	if err != nil {
+		return err
+	}
+	return c.JSON(dto.CategoryTreeResponse{Data: tree})
+}
+
+// GetCategoryByID يجلب تصنيفًا واحدًا مع تصنيفاته الفرعية
+func (h *categoryHandler) GetCategoryByID(c *fiber.Ctx) error {
+	id, err := categoryID(c)
+	if err != nil {
+		return err
+	}
+
+	category, err := h.categoryUseCase.GetCategoryByID(c.UserContext(), id)
+	if err != nil {
+		return err
+	}
+	return c.JSON(category)
+}
+
+// UpdateCategory يعيد تسمية تصنيف أو ينقله إلى أب آخر
+func (h *categoryHandler) UpdateCategory(c *fiber.Ctx) error {
+	id, err := categoryID(c)
+	if err != nil {
+		return err
+	}
+
+	req := new(dto.UpdateCategoryRequest)
+	if err := c.BodyParser(req); err != nil {
+		return errInvalidBody
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return validationError(err)
+	}
+
+	category, err := h.categoryUseCase.UpdateCategory(c.UserContext(), id, req)
+	if err != nil {
+		return err
+	}
+	return c.JSON(category)
+}
+
+// DeleteCategory يحذف تصنيفًا لا فروع له ويزيله من مقالاته
+func (h *categoryHandler) DeleteCategory(c *fiber.Ctx) error {
+	id, err := categoryID(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.categoryUseCase.DeleteCategory(c.UserContext(), id); err != nil {
+		return err
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
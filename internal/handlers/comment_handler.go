@@ -0,0 +1,188 @@
+// my-article-app/internal/handlers/comment_handler.go
+package handlers
+
+import (
+	"my-article-app/internal/apperr"
+	"my-article-app/internal/dto"
+	"my-article-app/internal/models"
+	"my-article-app/internal/usecase"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// commentTokenHeader ترويسة رمز التعديل الذي أُعطي لكاتب التعليق عند إنشائه
+const commentTokenHeader = "X-Comment-Token"
+
+type CommentHandler interface {
+	CreateComment(c *fiber.Ctx) error
+	GetComments(c *fiber.Ctx) error
+	UpdateComment(c *fiber.Ctx) error
+	DeleteComment(c *fiber.Ctx) error
+	ApproveComment(c *fiber.Ctx) error
+	RejectComment(c *fiber.Ctx) error
+	GetModerationQueue(c *fiber.Ctx) error
+}
+
+type commentHandler struct {
+	commentUseCase usecase.CommentUseCase
+}
+
+func NewCommentHandler(commentUseCase usecase.CommentUseCase) CommentHandler {
+	return &commentHandler{commentUseCase: commentUseCase}
+}
+
+// commentArticleID يقرأ معرف المقال من المسار
+func commentArticleID(c *fiber.Ctx) (uint, error) {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return 0, apperr.Validation("invalid_article_id")
+	}
+	return uint(id), nil
+}
+
+// commentParams يقرأ معرف المقال ومعرف التعليق من المسار
+func commentParams(c *fiber.Ctx) (uint, uint, error) {
+	articleID, err := commentArticleID(c)
+	if err != nil {
+		return 0, 0, err
+	}
+	id, err := strconv.ParseUint(c.Params("comment"), 10, 32)
+	if err != nil {
+		return 0, 0, apperr.Validation("invalid_comment_id")
+	}
+	return articleID, uint(id), nil
+}
+
+// CreateComment يضيف تعليقًا بانتظار المراجعة، ويعيد رمز تعديله مرة واحدة
+func (h *commentHandler) CreateComment(c *fiber.Ctx) error {
+	articleID, err := commentArticleID(c)
+	if err != nil {
+		return err
+	}
+
+	req := new(dto.CreateCommentRequest)
+	if err := c.BodyParser(req); err != nil {
+		return errInvalidBody
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return validationError(err)
+	}
+
+	comment, err := h.commentUseCase.CreateComment(c.UserContext(), articleID, req)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusCreated).JSON(comment)
+}
+
+// GetComments يجلب صفحة من سلاسل التعليقات المعتمدة (?limit=&offset= أو ?cursor=)
+func (h *commentHandler) GetComments(c *fiber.Ctx) error {
+	articleID, err := commentArticleID(c)
+	if err != nil {
+		return err
+	}
+
+	pageReq, err := parsePageRequest(c)
+	if err != nil {
+		return err
+	}
+
+	comments, err := h.commentUseCase.GetComments(c.UserContext(), articleID, pageReq, isAdmin(c))
+	if err != nil {
+		return err
+	}
+
+	setPageLinks(c, pageReq, comments.Meta)
+	return c.JSON(comments)
+}
+
+// UpdateComment يعدّل نص التعليق برمز التعديل في ترويسة X-Comment-Token
+func (h *commentHandler) UpdateComment(c *fiber.Ctx) error {
+	articleID, id, err := commentParams(c)
+	if err != nil {
+		return err
+	}
+
+	req := new(dto.UpdateCommentRequest)
+	if err := c.BodyParser(req); err != nil {
+		return errInvalidBody
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return validationError(err)
+	}
+
+	comment, err := h.commentUseCase.UpdateComment(c.UserContext(), articleID, id, c.Get(commentTokenHeader), req)
+	if err != nil {
+		return err
+	}
+	return c.JSON(comment)
+}
+
+// DeleteComment يحذف التعليق برمز تعديله أو بصلاحية المشرف
+func (h *commentHandler) DeleteComment(c *fiber.Ctx) error {
+	articleID, id, err := commentParams(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.commentUseCase.DeleteComment(c.UserContext(), articleID, id, c.Get(commentTokenHeader), isAdmin(c)); err != nil {
+		return err
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// ApproveComment يعتمد التعليق فيظهر للقراء (للمشرف فقط)
+func (h *commentHandler) ApproveComment(c *fiber.Ctx) error {
+	return h.moderate(c, models.CommentStatusApproved)
+}
+
+// RejectComment يرفض التعليق فيُخفى عن القراء (للمشرف فقط)
+func (h *commentHandler) RejectComment(c *fiber.Ctx) error {
+	return h.moderate(c, models.CommentStatusRejected)
+}
+
+// moderate يطبق قرار المراجعة status على التعليق في المسار
+func (h *commentHandler) moderate(c *fiber.Ctx, status string) error {
+	if !isAdmin(c) {
+		return apperr.Forbidden("comment_moderation_forbidden")
+	}
+
+	articleID, id, err := commentParams(c)
+	if err != nil {
+		return err
+	}
+
+	comment, err := h.commentUseCase.ModerateComment(c.UserContext(), articleID, id, status)
+	if err != nil {
+		return err
+	}
+	return c.JSON(comment)
+}
+
+// GetModerationQueue يجلب طابور مراجعة التعليقات من كل المقالات (?status=pending|approved|rejected، للمشرف فقط)
+func (h *commentHandler) GetModerationQueue(c *fiber.Ctx) error {
+	if !isAdmin(c) {
+		return apperr.Forbidden("comment_moderation_forbidden")
+	}
+
+	query := new(dto.CommentQueueQuery)
+	if err := c.QueryParser(query); err != nil {
+		return errInvalidQueryParams
+	}
+
+	pageReq, err := parsePageRequest(c)
+	if err != nil {
+		return err
+	}
+
+	comments, err := h.commentUseCase.GetModerationQueue(c.UserContext(), query, pageReq)
+	if err != nil {
+		return err
+	}
+
+	setPageLinks(c, pageReq, comments.Meta)
+	return c.JSON(comments)
+}
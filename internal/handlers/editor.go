@@ -0,0 +1,31 @@
+// my-article-app/internal/handlers/editor.go
+package handlers
+
+import (
+	"my-article-app/internal/usecase"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+)
+
+// editorHeader ترويسة اسم المحرر الذي تُنسب إليه مراجعات المقالات
+const editorHeader = "X-Editor"
+
+// maxEditorLength أقصى طول لاسم المحرر بالحروف كما في عمود edited_by
+const maxEditorLength = 100
+
+// IdentifyEditor ينقل اسم المحرر من ترويسة X-Editor إلى سياق الطلب لتسجيله مع المراجعات
+// الاسم يصرّح به العميل ولا يُتحقق منه، فهو للتوثيق وليس للصلاحيات
+func IdentifyEditor() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		// قيم Fiber تشير إلى ذاكرة الطلب المعاد استخدامها، والاسم يُحفظ بعد انتهاء الطلب فيُنسخ
+		if editor := strings.TrimSpace(utils.CopyString(c.Get(editorHeader))); editor != "" {
+			if runes := []rune(editor); len(runes) > maxEditorLength {
+				editor = string(runes[:maxEditorLength])
+			}
+			c.SetUserContext(usecase.WithEditor(c.UserContext(), editor))
+		}
+		return c.Next()
+	}
+}
@@ -0,0 +1,97 @@
+// my-article-app/internal/handlers/errors.go
+package handlers
+
+import (
+	"errors"
+	"log"
+	"my-article-app/internal/apperr"
+	"my-article-app/internal/dto"
+	"my-article-app/internal/i18n"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+)
+
+// statusByKind يربط تصنيف الخطأ برمز HTTP المقابل
+var statusByKind = map[apperr.Kind]int{
+	apperr.KindValidation:           fiber.StatusBadRequest,
+	apperr.KindNotFound:             fiber.StatusNotFound,
+	apperr.KindConflict:             fiber.StatusConflict,
+	apperr.KindForbidden:            fiber.StatusForbidden,
+	apperr.KindPreconditionFailed:   fiber.StatusPreconditionFailed,
+	apperr.KindPreconditionRequired: fiber.StatusPreconditionRequired,
+	apperr.KindUnavailable:          fiber.StatusServiceUnavailable,
+	apperr.KindTimeout:              fiber.StatusGatewayTimeout,
+	apperr.KindAborted:              fiber.StatusFailedDependency,
+}
+
+// errInvalidBody يُرجع عندما يتعذر تحليل جسم الطلب
+var errInvalidBody = apperr.Validation("invalid_body")
+
+// errInvalidQueryParams يُرجع عندما يتعذر تحليل معاملات الاستعلام
+var errInvalidQueryParams = apperr.Validation("invalid_query")
+
+// ErrorHandler المعالج المركزي للأخطاء في Fiber: يحوّل تصنيف الخطأ (apperr.Kind) إلى رمز HTTP
+// وجسم dto.ErrorResponse برسالة بلغة Accept-Language، فتكتفي المعالجات بإرجاع الخطأ كما هو
+func ErrorHandler(c *fiber.Ctx, err error) error {
+	lang := i18n.Negotiate(c.Get(fiber.HeaderAcceptLanguage))
+	c.Vary(fiber.HeaderAcceptLanguage)
+
+	status, body := errorResponse(c, err, lang)
+	body.RequestID = c.GetRespHeader(fiber.HeaderXRequestID)
+	return c.Status(status).JSON(body)
+}
+
+// errorResponse يحدد رمز HTTP وجسم الاستجابة للخطأ
+func errorResponse(c *fiber.Ctx, err error, lang string) (int, dto.ErrorResponse) {
+	// أخطاء Fiber نفسها (مثل تجاوز حجم الجسم) تحمل رمزها ورسالتها الإنجليزية
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return fiberErr.Code, dto.ErrorResponse{
+			Code:        strings.ReplaceAll(strings.ToLower(utils.StatusMessage(fiberErr.Code)), " ", "_"),
+			Message:     fiberErr.Message,
+			FieldErrors: []dto.FieldError{},
+		}
+	}
+
+	var appErr *apperr.Error
+	if !errors.As(err, &appErr) {
+		// بعض المشغّلات لا تغلّف خطأ السياق، لذا نصنّف الخطأ حسب سياق الطلب نفسه أيضًا
+		kind := apperr.KindOf(err)
+		if kind == apperr.KindInternal {
+			kind = apperr.KindOf(c.UserContext().Err())
+		}
+		appErr = contextError(kind, err)
+		switch kind {
+		case apperr.KindTimeout:
+			log.Printf("انتهت مهلة الاستعلام للمسار %s %s: %v", c.Method(), c.Path(), err)
+		case apperr.KindUnavailable:
+			log.Printf("أُلغي الاستعلام للمسار %s %s: %v", c.Method(), c.Path(), err)
+		default:
+			log.Printf("خطأ داخلي في المسار %s %s: %v", c.Method(), c.Path(), err)
+		}
+	}
+
+	status, ok := statusByKind[appErr.Kind]
+	if !ok {
+		status = fiber.StatusInternalServerError
+	}
+	return status, dto.ErrorResponse{
+		Code:        appErr.Code,
+		Message:     appErr.Message(lang),
+		FieldErrors: fieldErrors(err, lang),
+	}
+}
+
+// contextError يبني الخطأ المصنّف المعروض للأخطاء غير المصنّفة حسب تصنيفها المستنتج
+func contextError(kind apperr.Kind, err error) *apperr.Error {
+	switch kind {
+	case apperr.KindTimeout:
+		return apperr.Wrap(kind, err, "request_timeout")
+	case apperr.KindUnavailable:
+		// لا يبلغه سياق طلب HTTP لأن fasthttp لا يلغيه (انظر QueryTimeout)، بل خطأ إلغاء من سياق آخر
+		return apperr.Wrap(kind, err, "request_canceled")
+	}
+	return apperr.Wrap(apperr.KindInternal, err, "internal_error")
+}
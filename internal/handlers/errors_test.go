@@ -0,0 +1,83 @@
+// my-article-app/internal/handlers/errors_test.go
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"my-article-app/internal/apperr"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+func TestErrorHandlerStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		status   int
+		wantCode string
+	}{
+		{"validation", apperr.Validation("invalid_body"), fiber.StatusBadRequest, "invalid_body"},
+		{"not found", apperr.NotFound("article_not_found", 1), fiber.StatusNotFound, "article_not_found"},
+		{"conflict", apperr.Conflict("duplicate_title"), fiber.StatusConflict, "duplicate_title"},
+		{"forbidden", apperr.Forbidden("purge_forbidden"), fiber.StatusForbidden, "purge_forbidden"},
+		{"precondition failed", apperr.PreconditionFailed("version_mismatch", 1, 2), fiber.StatusPreconditionFailed, "version_mismatch"},
+		{"precondition required", apperr.PreconditionRequired("if_match_required"), fiber.StatusPreconditionRequired, "if_match_required"},
+		{"unavailable", apperr.Unavailable("request_canceled"), fiber.StatusServiceUnavailable, "request_canceled"},
+		{"timeout", apperr.New(apperr.KindTimeout, "request_timeout"), fiber.StatusGatewayTimeout, "request_timeout"},
+		{"aborted", apperr.Aborted("bulk_aborted"), fiber.StatusFailedDependency, "bulk_aborted"},
+		{"wrapped by fmt", fmt.Errorf("load article: %w", apperr.NotFound("article_not_found", 1)), fiber.StatusNotFound, "article_not_found"},
+		// أخطاء GORM بعد ترجمتها بـ TranslateError يغلّفها المستودع بتصنيفها
+		{"translated duplicate key", apperr.Wrap(apperr.KindConflict, gorm.ErrDuplicatedKey, "author_email_taken", "a@example.com"), fiber.StatusConflict, "author_email_taken"},
+		{"translated foreign key", apperr.Wrap(apperr.KindConflict, fmt.Errorf("insert: %w", gorm.ErrForeignKeyViolated), "constraint_violation"), fiber.StatusConflict, "constraint_violation"},
+		{"record not found", apperr.Wrap(apperr.KindNotFound, gorm.ErrRecordNotFound, "author_not_found", 3), fiber.StatusNotFound, "author_not_found"},
+		// خطأ GORM غير مصنّف لم يمر بالمستودع فيبقى خطأ داخليًا
+		{"unclassified gorm error", gorm.ErrRecordNotFound, fiber.StatusInternalServerError, "internal_error"},
+		{"unknown error", errors.New("boom"), fiber.StatusInternalServerError, "internal_error"},
+		{"internal kind", apperr.New(apperr.KindInternal, "internal_error"), fiber.StatusInternalServerError, "internal_error"},
+		{"unmapped kind", apperr.New(apperr.Kind(200), "internal_error"), fiber.StatusInternalServerError, "internal_error"},
+		{"fiber error", fiber.ErrRequestEntityTooLarge, fiber.StatusRequestEntityTooLarge, "request_entity_too_large"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTestApp()
+			app.Get("/", func(*fiber.Ctx) error { return tt.err })
+
+			status, body := doRequest(t, app, httptest.NewRequest(fiber.MethodGet, "/", nil))
+			if status != tt.status {
+				t.Fatalf("status = %d, want %d; body %s", status, tt.status, body)
+			}
+			got := decodeError(t, body)
+			if got.Code != tt.wantCode {
+				t.Errorf("code = %q, want %q", got.Code, tt.wantCode)
+			}
+			if got.Message == "" || got.RequestID == "" || got.FieldErrors == nil {
+				t.Errorf("envelope = %+v, want message, request_id and field_errors", got)
+			}
+		})
+	}
+}
+
+// TestErrorHandlerHidesInternalDetails يتحقق أن نص الخطأ الأصلي لا يصل إلى العميل
+func TestErrorHandlerHidesInternalDetails(t *testing.T) {
+	secrets := []error{
+		errors.New(`dial tcp 10.0.0.5:5432: password authentication failed for user "app"`),
+		apperr.Wrap(apperr.KindConflict, errors.New(`UNIQUE constraint failed: authors.email`), "author_email_taken", "a@example.com"),
+	}
+	for _, err := range secrets {
+		app := newTestApp()
+		app.Get("/", func(*fiber.Ctx) error { return err })
+		req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+		req.Header.Set(fiber.HeaderAcceptLanguage, "en")
+
+		_, body := doRequest(t, app, req)
+		for _, leak := range []string{"password", "10.0.0.5", "UNIQUE constraint", "authors.email"} {
+			if strings.Contains(string(body), leak) {
+				t.Errorf("response %s leaks %q", body, leak)
+			}
+		}
+	}
+}
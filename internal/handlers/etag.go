@@ -0,0 +1,89 @@
+// my-article-app/internal/handlers/etag.go
+package handlers
+
+import (
+	"my-article-app/internal/apperr"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// errMalformedIfMatch يُرجع عندما لا تكون ترويسة If-Match وسمًا واحدًا بين علامتي تنصيص أو "*"
+var errMalformedIfMatch = apperr.Validation("if_match_malformed")
+
+// versionETag يبني وسم ETag قويًا من رقم نسخة السجل
+func versionETag(version uint) string {
+	return `"` + strconv.FormatUint(uint64(version), 10) + `"`
+}
+
+// setVersionETag يضع ترويسة ETag المطابقة لرقم نسخة السجل المُعاد
+func setVersionETag(c *fiber.Ctx, version uint) {
+	c.Set(fiber.HeaderETag, versionETag(version))
+}
+
+// parseIfMatch يستخرج رقم النسخة المتوقع من ترويسة If-Match
+// يعيد nil عند غياب الترويسة أو عند "*" (أي نسخة موجودة)، ويعيد matchable=false
+// للوسوم الضعيفة (W/) أو غير الرقمية لأنها لا تطابق أي نسخة بالمقارنة القوية فتستحق 412
+func parseIfMatch(c *fiber.Ctx) (version *uint, matchable bool, err error) {
+	header := strings.TrimSpace(c.Get(fiber.HeaderIfMatch))
+	if header == "" || header == "*" {
+		return nil, true, nil
+	}
+	if strings.Contains(header, ",") {
+		return nil, false, errMalformedIfMatch
+	}
+	if strings.HasPrefix(header, "W/") {
+		return nil, false, nil
+	}
+	if len(header) < 2 || header[0] != '"' || header[len(header)-1] != '"' {
+		return nil, false, errMalformedIfMatch
+	}
+	v, err := strconv.ParseUint(header[1:len(header)-1], 10, 0)
+	if err != nil {
+		return nil, false, nil
+	}
+	expected := uint(v)
+	return &expected, true, nil
+}
+
+// ifMatchVersion يقرأ If-Match ويعيد خطأ تحقق للترويسة غير الصالحة أو خطأ شرط للوسم الذي لا يطابق أي نسخة
+func ifMatchVersion(c *fiber.Ctx) (*uint, error) {
+	version, matchable, err := parseIfMatch(c)
+	if err != nil {
+		return nil, err
+	}
+	if !matchable {
+		return nil, apperr.PreconditionFailed("if_match_mismatch")
+	}
+	return version, nil
+}
+
+// RequireIfMatch يرفض طلبات التعديل التي لا تحمل ترويسة If-Match بالرمز 428،
+// حتى لا يكتب العميل فوق تعديلات لم يرها
+func RequireIfMatch() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if strings.TrimSpace(c.Get(fiber.HeaderIfMatch)) == "" {
+			return apperr.PreconditionRequired("if_match_required")
+		}
+		return c.Next()
+	}
+}
+
+// itemVersionLocalKey مفتاح c.Locals الذي يحدد ما إذا كان رقم النسخة مطلوبًا في عناصر التحديث المجمّع
+const itemVersionLocalKey = "require_item_version"
+
+// RequireItemVersions نظير RequireIfMatch للتحديث المجمّع: لا يرفض الطلب نفسه،
+// بل يُرفض كل عنصر لا يحمل version بالرمز 428 في تقرير النتائج
+func RequireItemVersions() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Locals(itemVersionLocalKey, true)
+		return c.Next()
+	}
+}
+
+// itemVersionRequired يحدد ما إذا كان رقم النسخة مطلوبًا في عناصر التحديث المجمّع
+func itemVersionRequired(c *fiber.Ctx) bool {
+	required, _ := c.Locals(itemVersionLocalKey).(bool)
+	return required
+}
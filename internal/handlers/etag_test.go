@@ -0,0 +1,116 @@
+// my-article-app/internal/handlers/etag_test.go
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestIfMatchVersion(t *testing.T) {
+	app := newTestApp()
+	app.Put("/", func(c *fiber.Ctx) error {
+		version, err := ifMatchVersion(c)
+		if err != nil {
+			return err
+		}
+		if version == nil {
+			return c.SendString("any")
+		}
+		return c.SendString(strconv.FormatUint(uint64(*version), 10))
+	})
+
+	tests := []struct {
+		name     string
+		header   string
+		status   int
+		wantBody string
+		wantCode string
+	}{
+		{name: "absent", header: "", status: fiber.StatusOK, wantBody: "any"},
+		{name: "any version", header: "*", status: fiber.StatusOK, wantBody: "any"},
+		{name: "quoted version", header: `"7"`, status: fiber.StatusOK, wantBody: "7"},
+		{name: "surrounding spaces", header: `  "7" `, status: fiber.StatusOK, wantBody: "7"},
+		// الوسم الضعيف والوسم غير الرقمي صالحان نحويًا لكنهما لا يطابقان أي نسخة بالمقارنة القوية
+		{name: "weak tag", header: `W/"7"`, status: fiber.StatusPreconditionFailed, wantCode: "if_match_mismatch"},
+		{name: "non-numeric tag", header: `"abc"`, status: fiber.StatusPreconditionFailed, wantCode: "if_match_mismatch"},
+		{name: "unquoted", header: `7`, status: fiber.StatusBadRequest, wantCode: "if_match_malformed"},
+		{name: "half quoted", header: `"7`, status: fiber.StatusBadRequest, wantCode: "if_match_malformed"},
+		{name: "list", header: `"6", "7"`, status: fiber.StatusBadRequest, wantCode: "if_match_malformed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(fiber.MethodPut, "/", nil)
+			if tt.header != "" {
+				req.Header.Set(fiber.HeaderIfMatch, tt.header)
+			}
+			status, body := doRequest(t, app, req)
+			if status != tt.status {
+				t.Fatalf("status = %d, want %d; body %s", status, tt.status, body)
+			}
+			if tt.wantCode != "" {
+				if got := decodeError(t, body).Code; got != tt.wantCode {
+					t.Errorf("code = %q, want %q", got, tt.wantCode)
+				}
+			} else if string(body) != tt.wantBody {
+				t.Errorf("body = %q, want %q", body, tt.wantBody)
+			}
+		})
+	}
+}
+
+// TestUpdateArticleIfMatch يمر بالمسار كما يسجله cmd/api مع اشتراط If-Match
+func TestUpdateArticleIfMatch(t *testing.T) {
+	store := newTestStore()
+	article := store.createArticle(t, "Versioned article", "")
+	app := newTestApp()
+	app.Put("/articles/:id", RequireIfMatch(), NewArticleHandler(store.articles).UpdateArticle)
+	path := "/articles/" + strconv.FormatUint(uint64(article.ID), 10)
+
+	put := func(ifMatch, content string) (*http.Response, []byte) {
+		t.Helper()
+		req := httptest.NewRequest(fiber.MethodPut, path, strings.NewReader(`{"content":"`+content+`"}`))
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		if ifMatch != "" {
+			req.Header.Set(fiber.HeaderIfMatch, ifMatch)
+		}
+		return send(t, app, req)
+	}
+
+	resp, body := put("", "no precondition at all")
+	if resp.StatusCode != fiber.StatusPreconditionRequired || decodeError(t, body).Code != "if_match_required" {
+		t.Fatalf("without If-Match: %d %s, want 428 if_match_required", resp.StatusCode, body)
+	}
+
+	resp, body = put(versionETag(article.Version), "first edit")
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("current If-Match: %d %s, want 200", resp.StatusCode, body)
+	}
+	if got, want := resp.Header.Get(fiber.HeaderETag), versionETag(article.Version+1); got != want {
+		t.Errorf("ETag = %s, want %s", got, want)
+	}
+
+	// العميل الثاني ما زال يحمل النسخة القديمة، فيُرفض تعديله بدل أن يكتب فوق الأول
+	resp, body = put(versionETag(article.Version), "stale edit")
+	if resp.StatusCode != fiber.StatusPreconditionFailed || decodeError(t, body).Code != "version_mismatch" {
+		t.Fatalf("stale If-Match: %d %s, want 412 version_mismatch", resp.StatusCode, body)
+	}
+
+	resp, body = put("1", "malformed header edit")
+	if resp.StatusCode != fiber.StatusBadRequest || decodeError(t, body).Code != "if_match_malformed" {
+		t.Fatalf("malformed If-Match: %d %s, want 400 if_match_malformed", resp.StatusCode, body)
+	}
+
+	current, err := store.articles.GetArticleByID(context.Background(), article.ID, nil, true)
+	if err != nil {
+		t.Fatalf("GetArticleByID: %v", err)
+	}
+	if current.Content != "first edit" || current.Version != article.Version+1 {
+		t.Errorf("article after requests: content=%q version=%d, want the first edit only", current.Content, current.Version)
+	}
+}
@@ -0,0 +1,93 @@
+// my-article-app/internal/handlers/helpers_test.go
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"my-article-app/internal/dto"
+	"my-article-app/internal/repository"
+	"my-article-app/internal/usecase"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+)
+
+// newTestApp ينشئ تطبيق Fiber بمعالج الأخطاء المركزي ومعرف الطلب كما في cmd/api
+func newTestApp() *fiber.App {
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	app.Use(requestid.New())
+	return app
+}
+
+// send ينفذ الطلب على التطبيق دون مهلة ويعيد الاستجابة وجسمها مقروءًا
+func send(t *testing.T, app *fiber.App, req *http.Request) (*http.Response, []byte) {
+	t.Helper()
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("%s %s: %v", req.Method, req.URL, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read body: %v", err)
+	}
+	return resp, body
+}
+
+// doRequest مثل send لكنه يعيد رمز الحالة والجسم فقط
+func doRequest(t *testing.T, app *fiber.App, req *http.Request) (int, []byte) {
+	t.Helper()
+	resp, body := send(t, app, req)
+	return resp.StatusCode, body
+}
+
+// decodeError يفك جسم الخطأ الموحّد
+func decodeError(t *testing.T, body []byte) dto.ErrorResponse {
+	t.Helper()
+	var out dto.ErrorResponse
+	if err := json.Unmarshal(body, &out); err != nil {
+		t.Fatalf("decode error body %q: %v", body, err)
+	}
+	return out
+}
+
+// testStore حالات الاستخدام فوق تخزين جديد في الذاكرة
+type testStore struct {
+	repos    repository.Repositories
+	articles usecase.ArticleUseCase
+	authors  usecase.AuthorUseCase
+	// authorCount يجعل اسم كل مؤلف جديد وبريده فريدين
+	authorCount int
+}
+
+func newTestStore() *testStore {
+	store := repository.NewMemoryStore()
+	repos := repository.NewMemoryRepositories(store)
+	tx := repository.NewMemoryTxManager(store)
+	return &testStore{
+		repos:    repos,
+		articles: usecase.NewArticleUseCase(repos.Articles, repos.Authors, repos.Revisions, repos.SlugHistory, tx),
+		authors:  usecase.NewAuthorUseCase(repos.Authors, tx),
+	}
+}
+
+// createArticle ينشئ مؤلفًا جديدًا ومقالًا له بالحالة المعطاة (الفارغة تعني مسودة)
+func (s *testStore) createArticle(t *testing.T, title, status string) *dto.ArticleResponse {
+	t.Helper()
+	ctx := context.Background()
+	s.authorCount++
+	name := fmt.Sprintf("author %d", s.authorCount)
+	author, err := s.authors.CreateAuthor(ctx, &dto.CreateAuthorRequest{Name: name, Email: fmt.Sprintf("author%d@example.com", s.authorCount)})
+	if err != nil {
+		t.Fatalf("CreateAuthor: %v", err)
+	}
+	article, err := s.articles.CreateArticle(ctx, &dto.CreateArticleRequest{Title: title, Content: "content of " + title, AuthorID: author.ID, Status: status})
+	if err != nil {
+		t.Fatalf("CreateArticle(%q): %v", title, err)
+	}
+	return article
+}
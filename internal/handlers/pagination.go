@@ -0,0 +1,77 @@
+// my-article-app/internal/handlers/pagination.go
+package handlers
+
+import (
+	"my-article-app/internal/apperr"
+	"my-article-app/internal/dto"
+	"my-article-app/internal/pagination"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+)
+
+// parsePageRequest يقرأ معاملات الترقيم ?limit=&offset=&cursor= من الطلب ويتحقق منها
+func parsePageRequest(c *fiber.Ctx) (pagination.Request, error) {
+	var req pagination.Request
+	var err error
+
+	if v := c.Query("limit"); v != "" {
+		if req.Limit, err = strconv.Atoi(v); err != nil {
+			return req, apperr.Validation("invalid_limit", v)
+		}
+	}
+	if v := c.Query("offset"); v != "" {
+		if req.Offset, err = strconv.Atoi(v); err != nil {
+			return req, apperr.Validation("invalid_offset", v)
+		}
+	}
+	req.Cursor = c.Query("cursor")
+
+	return req.Normalize()
+}
+
+// setPageLinks يضيف ترويسة Link (first/prev/next) لاستجابة القائمة مع الحفاظ على بقية معاملات الاستعلام
+func setPageLinks(c *fiber.Ctx, req pagination.Request, meta dto.PageMeta) {
+	var links []string
+
+	links = append(links, pageURL(c, map[string]string{"offset": "", "cursor": "", "limit": strconv.Itoa(meta.Limit)}), "first")
+
+	if !req.IsKeyset() && meta.Offset > 0 {
+		prev := max(meta.Offset-meta.Limit, 0)
+		links = append(links, pageURL(c, map[string]string{"offset": strconv.Itoa(prev), "cursor": "", "limit": strconv.Itoa(meta.Limit)}), "prev")
+	}
+
+	if meta.HasMore {
+		var next string
+		if req.IsKeyset() {
+			next = pageURL(c, map[string]string{"offset": "", "cursor": meta.NextCursor, "limit": strconv.Itoa(meta.Limit)})
+		} else {
+			next = pageURL(c, map[string]string{"offset": strconv.Itoa(meta.Offset + meta.Limit), "cursor": "", "limit": strconv.Itoa(meta.Limit)})
+		}
+		links = append(links, next, "next")
+	}
+
+	c.Links(links...)
+}
+
+// pageURL يبني رابط الطلب الحالي بعد استبدال معاملات معينة (القيمة الفارغة تعني حذف المعامل)
+func pageURL(c *fiber.Ctx, overrides map[string]string) string {
+	args := fasthttp.AcquireArgs()
+	defer fasthttp.ReleaseArgs(args)
+	c.Request().URI().QueryArgs().CopyTo(args)
+
+	for key, value := range overrides {
+		if value == "" {
+			args.Del(key)
+		} else {
+			args.Set(key, value)
+		}
+	}
+
+	url := c.BaseURL() + c.Path()
+	if qs := args.String(); qs != "" {
+		url += "?" + qs
+	}
+	return url
+}
@@ -0,0 +1,29 @@
+// my-article-app/internal/handlers/slug.go
+package handlers
+
+import (
+	"my-article-app/internal/apperr"
+	"net/url"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// slugParam يعيد المعرف النصي من المسار بعد فك ترميزه، لأن Fiber يعيد المعاملات مرمّزة كما وصلت
+// والمعرفات بحروف غير لاتينية تصل مرمّزة دائمًا (%D8%...)
+func slugParam(c *fiber.Ctx) (string, error) {
+	slug, err := url.PathUnescape(c.Params("slug"))
+	if err != nil || slug == "" {
+		return "", apperr.Validation("invalid_slug")
+	}
+	return slug, nil
+}
+
+// slugLocation يبني رابط المسار الحالي بمعرف نصي آخر مع الإبقاء على معاملات الاستعلام
+func slugLocation(c *fiber.Ctx, slug string) string {
+	location := strings.TrimSuffix(c.Route().Path, ":slug") + url.PathEscape(slug)
+	if query := c.Request().URI().QueryString(); len(query) > 0 {
+		location += "?" + string(query)
+	}
+	return location
+}
@@ -0,0 +1,44 @@
+// my-article-app/internal/handlers/slug_test.go
+package handlers
+
+import (
+	"context"
+	"my-article-app/internal/dto"
+	"my-article-app/internal/models"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// TestArticleSlugRedirect يتحقق أن المعرف السابق يجيب بـ 301 إلى المعرف الحالي مع معاملات الاستعلام،
+// وأن المعرف الحالي يجيب بالمقال مباشرة
+func TestArticleSlugRedirect(t *testing.T) {
+	store := newTestStore()
+	article := store.createArticle(t, "مقدمة في البرمجة", models.ArticleStatusPublished)
+	if _, err := store.articles.UpdateArticle(context.Background(), article.ID, &dto.UpdateArticleRequest{Title: "Introduction to programming"}, nil); err != nil {
+		t.Fatalf("UpdateArticle: %v", err)
+	}
+	app := newTestApp()
+	app.Get("/articles/by-slug/:slug", NewArticleHandler(store.articles).GetArticleBySlug)
+
+	tests := []struct {
+		name, path, location string
+		status               int
+	}{
+		{"previous slug", "/articles/by-slug/mqdma-fy-albrmja", "/articles/by-slug/introduction-to-programming", fiber.StatusMovedPermanently},
+		{"keeps the query", "/articles/by-slug/mqdma-fy-albrmja?render=html", "/articles/by-slug/introduction-to-programming?render=html", fiber.StatusMovedPermanently},
+		{"current slug", "/articles/by-slug/introduction-to-programming", "", fiber.StatusOK},
+		{"escaped slug", "/articles/by-slug/" + url.PathEscape("مقال-غير-موجود"), "", fiber.StatusNotFound},
+	}
+	for _, tt := range tests {
+		resp, body := send(t, app, httptest.NewRequest(fiber.MethodGet, tt.path, nil))
+		if resp.StatusCode != tt.status {
+			t.Fatalf("%s: status = %d, want %d; body %s", tt.name, resp.StatusCode, tt.status, body)
+		}
+		if got := resp.Header.Get(fiber.HeaderLocation); got != tt.location {
+			t.Errorf("%s: Location = %q, want %q", tt.name, got, tt.location)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+// my-article-app/internal/handlers/tag_handler.go
+package handlers
+
+import (
+	"my-article-app/internal/apperr"
+	"my-article-app/internal/dto"
+	"my-article-app/internal/usecase"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type TagHandler interface {
+	CreateTag(c *fiber.Ctx) error
+	GetAllTags(c *fiber.Ctx) error
+	GetTagByID(c *fiber.Ctx) error
+	UpdateTag(c *fiber.Ctx) error
+	DeleteTag(c *fiber.Ctx) error
+}
+
+type tagHandler struct {
+	tagUseCase usecase.TagUseCase
+}
+
+func NewTagHandler(tagUseCase usecase.TagUseCase) TagHandler {
+	return &tagHandler{tagUseCase: tagUseCase}
+}
+
+// tagID يقرأ معرف الوسم من المسار
+func tagID(c *fiber.Ctx) (uint, error) {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return 0, apperr.Validation("invalid_tag_id")
+	}
+	return uint(id), nil
+}
+
+// CreateTag يتعامل مع طلبات POST لإنشاء وسم جديد
+func (h *tagHandler) CreateTag(c *fiber.Ctx) error {
+	req := new(dto.CreateTagRequest)
+	if err := c.BodyParser(req); err != nil {
+		return errInvalidBody
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return validationError(err)
+	}
+
+	tag, err := h.tagUseCase.CreateTag(c.UserContext(), req)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusCreated).JSON(tag)
+}
+
+// GetAllTags يجلب صفحة من الوسوم مع عدد مقالاتها (?name=&sort=-article_count)
+func (h *tagHandler) GetAllTags(c *fiber.Ctx) error {
+	pageReq, err := parsePageRequest(c)
+	if err != nil {
+		return err
+	}
+
+	query := new(dto.TagListQuery)
+	if err := c.QueryParser(query); err != nil {
+		return errInvalidQueryParams
+	}
+
+	tags, err := h.tagUseCase.GetAllTags(c.UserContext(), query, pageReq)
+	if err != nil {
+		return err
+	}
+
+	setPageLinks(c, pageReq, tags.Meta)
+	return c.JSON(tags)
+}
+
+// GetTagByID يجلب وسمًا واحدًا حسب ID
+func (h *tagHandler) GetTagByID(c *fiber.Ctx) error {
+	id, err := tagID(c)
+	if err != nil {
+		return err
+	}
+
+	tag, err := h.tagUseCase.GetTagByID(c.UserContext(), id)
+	if err != nil {
+		return err
+	}
+	return c.JSON(tag)
+}
+
+// UpdateTag يعيد تسمية وسم موجود
+func (h *tagHandler) UpdateTag(c *fiber.Ctx) error {
+	id, err := tagID(c)
+	if err != nil {
+		return err
+	}
+
+	req := new(dto.UpdateTagRequest)
+	if err := c.BodyParser(req); err != nil {
+		return errInvalidBody
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return validationError(err)
+	}
+
+	tag, err := h.tagUseCase.UpdateTag(c.UserContext(), id, req)
+	if err != nil {
+		return err
+	}
+	return c.JSON(tag)
+}
+
+// DeleteTag يحذف وسمًا ويزيله من مقالاته
+func (h *tagHandler) DeleteTag(c *fiber.Ctx) error {
+	id, err := tagID(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.tagUseCase.DeleteTag(c.UserContext(), id); err != nil {
+		return err
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,25 @@
+// my-article-app/internal/handlers/timeout.go
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// QueryTimeout يضع مهلة على سياق الطلب (UserContext) تنتقل عبر الـ UseCase إلى استعلامات قاعدة البيانات
+// القيمة الصفرية تعني عدم وضع مهلة
+// fasthttp لا يلغي UserContext عند انقطاع اتصال العميل، فالمهلة هي ما يوقف الاستعلامات الطويلة،
+// والاستعلام يكمل حتى نهايته أو نهاية مهلته حتى لو غادر العميل
+func QueryTimeout(d time.Duration) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if d <= 0 {
+			return c.Next()
+		}
+		ctx, cancel := context.WithTimeout(c.UserContext(), d)
+		defer cancel()
+		c.SetUserContext(ctx)
+		return c.Next()
+	}
+}
@@ -0,0 +1,53 @@
+// my-article-app/internal/handlers/timeout_test.go
+package handlers
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestQueryTimeout(t *testing.T) {
+	app := newTestApp()
+	// استعلام بطيء ينتظر انتهاء سياق الطلب ويعيد خطأه مغلفًا كما يفعل GORM
+	slow := func(c *fiber.Ctx) error {
+		select {
+		case <-c.UserContext().Done():
+			return c.UserContext().Err()
+		case <-time.After(time.Second):
+			return c.SendStatus(fiber.StatusOK)
+		}
+	}
+	app.Get("/slow", QueryTimeout(20*time.Millisecond), slow)
+	// بعض المشغّلات تعيد خطأها الخاص دون تغليف خطأ السياق
+	app.Get("/driver", QueryTimeout(20*time.Millisecond), func(c *fiber.Ctx) error {
+		<-c.UserContext().Done()
+		return errors.New("driver: query interrupted")
+	})
+	app.Get("/unlimited", QueryTimeout(0), func(c *fiber.Ctx) error {
+		if _, ok := c.UserContext().Deadline(); ok {
+			return errors.New("deadline set with a zero timeout")
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	for _, path := range []string{"/slow", "/driver"} {
+		t.Run(path, func(t *testing.T) {
+			status, body := doRequest(t, app, httptest.NewRequest(fiber.MethodGet, path, nil))
+			if status != fiber.StatusGatewayTimeout {
+				t.Fatalf("status = %d, want 504; body %s", status, body)
+			}
+			got := decodeError(t, body)
+			if got.Code != "request_timeout" || got.Message == "" || got.RequestID == "" || got.FieldErrors == nil {
+				t.Errorf("error body = %+v, want request_timeout envelope", got)
+			}
+		})
+	}
+
+	if status, body := doRequest(t, app, httptest.NewRequest(fiber.MethodGet, "/unlimited", nil)); status != fiber.StatusNoContent {
+		t.Errorf("zero timeout: status = %d, body %s", status, body)
+	}
+}
@@ -0,0 +1,79 @@
+// my-article-app/internal/handlers/validation.go
+package handlers
+
+import (
+	"errors"
+	"my-article-app/internal/apperr"
+	"my-article-app/internal/dto"
+	"my-article-app/internal/i18n"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/locales/ar"
+	"github.com/go-playground/locales/en"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	arTranslations "github.com/go-playground/validator/v10/translations/ar"
+	enTranslations "github.com/go-playground/validator/v10/translations/en"
+)
+
+// validate مدقق مشترك بين المعالجات، وأسماء الحقول في أخطائه هي أسماء JSON التي يرسلها العميل
+var validate = newValidator()
+
+// translators مترجمات رسائل التحقق لكل لغة مدعومة
+var translators = newTranslators(validate)
+
+// newValidator ينشئ المدقق ويجعل أسماء الحقول في الأخطاء مأخوذة من وسم json
+func newValidator() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return field.Name
+		}
+		return name
+	})
+	return v
+}
+
+// newTranslators يسجّل رسائل التحقق الافتراضية بالعربية والإنجليزية في المدقق
+func newTranslators(v *validator.Validate) map[string]ut.Translator {
+	uni := ut.New(ar.New(), ar.New(), en.New())
+	arTrans, _ := uni.GetTranslator(i18n.Arabic)
+	enTrans, _ := uni.GetTranslator(i18n.English)
+
+	if err := arTranslations.RegisterDefaultTranslations(v, arTrans); err != nil {
+		panic(err)
+	}
+	if err := enTranslations.RegisterDefaultTranslations(v, enTrans); err != nil {
+		panic(err)
+	}
+	return map[string]ut.Translator{i18n.Arabic: arTrans, i18n.English: enTrans}
+}
+
+// validationError يغلّف أخطاء validator كخطأ تحقق، وتُترجم أخطاء الحقول عند بناء الاستجابة
+func validationError(err error) error {
+	return apperr.Wrap(apperr.KindValidation, err, "validation_failed")
+}
+
+// fieldErrors يحوّل أخطاء validator الموجودة في سلسلة الخطأ إلى أخطاء حقول مترجمة بلغة الطلب
+func fieldErrors(err error, lang string) []dto.FieldError {
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return []dto.FieldError{}
+	}
+
+	trans := translators[lang]
+	result := make([]dto.FieldError, 0, len(verrs))
+	for _, fe := range verrs {
+		result = append(result, dto.FieldError{
+			Field:   fe.Field(),
+			Rule:    fe.Tag(),
+			Message: fe.Translate(trans),
+		})
+	}
+	return result
+}
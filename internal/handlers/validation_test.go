@@ -0,0 +1,113 @@
+// my-article-app/internal/handlers/validation_test.go
+package handlers
+
+import (
+	"encoding/json"
+	"my-article-app/internal/dto"
+	"my-article-app/internal/i18n"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// postArticle يرسل طلب إنشاء مقال بالجسم والترويسة Accept-Language المعطاة
+func postArticle(t *testing.T, app *fiber.App, body, acceptLanguage string) (int, []byte, string) {
+	t.Helper()
+	req := httptest.NewRequest(fiber.MethodPost, "/articles", strings.NewReader(body))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	if acceptLanguage != "" {
+		req.Header.Set(fiber.HeaderAcceptLanguage, acceptLanguage)
+	}
+	resp, respBody := send(t, app, req)
+	if vary := resp.Header.Get(fiber.HeaderVary); !strings.Contains(vary, fiber.HeaderAcceptLanguage) {
+		t.Errorf("Vary = %q, want Accept-Language", vary)
+	}
+	if got := decodeError(t, respBody).RequestID; got == "" || got != resp.Header.Get(fiber.HeaderXRequestID) {
+		t.Errorf("request_id = %q, want the X-Request-ID header %q", got, resp.Header.Get(fiber.HeaderXRequestID))
+	}
+	return resp.StatusCode, respBody, resp.Header.Get(fiber.HeaderXRequestID)
+}
+
+func TestValidationErrorEnvelope(t *testing.T) {
+	app := newTestApp()
+	app.Post("/articles", NewArticleHandler(newTestStore().articles).CreateArticle)
+	invalid := `{"title":"abc","content":"","author_id":0}`
+
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		wantLang       string
+	}{
+		{"arabic", "ar", i18n.Arabic},
+		{"english", "en-US,en;q=0.9", i18n.English},
+		{"unsupported falls back to arabic", "fr", i18n.Default},
+		{"no header", "", i18n.Default},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body, _ := postArticle(t, app, invalid, tt.acceptLanguage)
+			if status != fiber.StatusBadRequest {
+				t.Fatalf("status = %d, want 400; body %s", status, body)
+			}
+			got := decodeError(t, body)
+			if got.Code != "validation_failed" || got.Message != i18n.T(tt.wantLang, "validation_failed") {
+				t.Errorf("code, message = %q, %q; want validation_failed in %s", got.Code, got.Message, tt.wantLang)
+			}
+
+			// أسماء الحقول هي أسماء JSON، وكل حقل يحمل القاعدة التي فشل فيها ورسالة بلغة الطلب
+			wantRules := map[string]string{"title": "min", "content": "required", "author_id": "required"}
+			if len(got.FieldErrors) != len(wantRules) {
+				t.Fatalf("field_errors = %+v, want %d entries", got.FieldErrors, len(wantRules))
+			}
+			for _, fe := range got.FieldErrors {
+				if wantRules[fe.Field] != fe.Rule {
+					t.Errorf("field error %s rule = %q, want %q", fe.Field, fe.Rule, wantRules[fe.Field])
+				}
+				if !strings.Contains(fe.Message, fe.Field) {
+					t.Errorf("field error message %q does not name %s", fe.Message, fe.Field)
+				}
+				if english := isASCII(fe.Message); english != (tt.wantLang == i18n.English) {
+					t.Errorf("field error message %q is not in %s", fe.Message, tt.wantLang)
+				}
+			}
+		})
+	}
+}
+
+// TestErrorEnvelopeFields يتحقق أن جسم الخطأ يحمل الحقول الأربعة دائمًا، و field_errors مصفوفة فارغة لا null
+func TestErrorEnvelopeFields(t *testing.T) {
+	app := newTestApp()
+	app.Post("/articles", NewArticleHandler(newTestStore().articles).CreateArticle)
+
+	status, body, requestID := postArticle(t, app, `{"title":`, "en")
+	if status != fiber.StatusBadRequest {
+		t.Fatalf("status = %d, want 400; body %s", status, body)
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(body, &raw); err != nil {
+		t.Fatalf("decode %s: %v", body, err)
+	}
+	for _, key := range []string{"code", "message", "field_errors", "request_id"} {
+		if _, ok := raw[key]; !ok {
+			t.Errorf("envelope %s has no %q", body, key)
+		}
+	}
+	if string(raw["field_errors"]) != "[]" {
+		t.Errorf("field_errors = %s, want []", raw["field_errors"])
+	}
+	want := dto.ErrorResponse{Code: "invalid_body", Message: "The request body is invalid.", FieldErrors: []dto.FieldError{}, RequestID: requestID}
+	if got := decodeError(t, body); got.Code != want.Code || got.Message != want.Message || got.RequestID != want.RequestID {
+		t.Errorf("envelope = %+v, want %+v", got, want)
+	}
+}
+
+func isASCII(s string) bool {
+	for _, r := range s {
+		if r > 127 {
+			return false
+		}
+	}
+	return true
+}
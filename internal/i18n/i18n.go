@@ -0,0 +1,53 @@
+// my-article-app/internal/i18n/i18n.go
+package i18n
+
+import (
+	"fmt"
+
+	"golang.org/x/text/language"
+)
+
+// اللغات المدعومة في رسائل الاستجابة
+const (
+	Arabic  = "ar"
+	English = "en"
+	// Default اللغة المستخدمة عند غياب Accept-Language أو عدم مطابقته لأي لغة مدعومة
+	Default = Arabic
+)
+
+// catalogs فهارس الرسائل لكل لغة، والمفتاح هو رمز الخطأ نفسه الذي يصل إلى العميل
+var catalogs = map[string]map[string]string{
+	Arabic:  arabicMessages,
+	English: englishMessages,
+}
+
+// matcher يطابق ترويسة Accept-Language مع اللغات المدعومة، والأولى هي الافتراضية
+var matcher = language.NewMatcher([]language.Tag{language.Arabic, language.English})
+
+// Negotiate يختار لغة الاستجابة من قيمة ترويسة Accept-Language
+func Negotiate(acceptLanguage string) string {
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return Default
+	}
+	_, index, confidence := matcher.Match(tags...)
+	if confidence == language.No {
+		return Default
+	}
+	return []string{Arabic, English}[index]
+}
+
+// T يعيد رسالة المفتاح باللغة المطلوبة بعد تعبئة معاملاتها
+// يرجع إلى اللغة الافتراضية إذا لم تكن الرسالة مترجمة، وإلى المفتاح نفسه إذا لم تكن معرّفة إطلاقًا
+func T(lang, key string, args ...any) string {
+	format, ok := catalogs[lang][key]
+	if !ok {
+		if format, ok = catalogs[Default][key]; !ok {
+			return key
+		}
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
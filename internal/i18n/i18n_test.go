@@ -0,0 +1,56 @@
+// my-article-app/internal/i18n/i18n_test.go
+package i18n
+
+import "testing"
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		header, want string
+	}{
+		{"", Arabic},
+		{"ar", Arabic},
+		{"ar-EG", Arabic},
+		{"en", English},
+		{"en-US,en;q=0.9", English},
+		{"fr", Default},
+		{"fr-FR, en;q=0.5", English},
+		{"en;q=0.4, ar;q=0.8", Arabic},
+		{"*", Default},
+		{";;;", Default},
+	}
+	for _, tt := range tests {
+		if got := Negotiate(tt.header); got != tt.want {
+			t.Errorf("Negotiate(%q) = %q, want %q", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestT(t *testing.T) {
+	if got := T(English, "validation_failed"); got != "Data validation failed." {
+		t.Errorf("T(en, validation_failed) = %q", got)
+	}
+	if got := T(English, "article_not_found", 7); got != "Article with ID 7 was not found." {
+		t.Errorf("T(en, article_not_found, 7) = %q", got)
+	}
+	// لغة غير مدعومة ترجع إلى العربية، والمفتاح غير المعرّف يعود كما هو
+	if got, want := T("fr", "validation_failed"), arabicMessages["validation_failed"]; got != want {
+		t.Errorf("T(fr, validation_failed) = %q, want %q", got, want)
+	}
+	if got := T(English, "no_such_key"); got != "no_such_key" {
+		t.Errorf("T(en, no_such_key) = %q, want the key", got)
+	}
+}
+
+// TestCatalogsMatch يتحقق أن كل رمز خطأ مترجم في الفهرسين معًا
+func TestCatalogsMatch(t *testing.T) {
+	for key := range arabicMessages {
+		if _, ok := englishMessages[key]; !ok {
+			t.Errorf("%q has no English message", key)
+		}
+	}
+	for key := range englishMessages {
+		if _, ok := arabicMessages[key]; !ok {
+			t.Errorf("%q has no Arabic message", key)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+// my-article-app/internal/i18n/messages_ar.go
+package i18n
+
+// arabicMessages الرسائل العربية (اللغة الافتراضية)، ويجب أن يحتوي على كل رمز معرّف
+var arabicMessages = map[string]string{
+	// أخطاء عامة
+	"internal_error":    "حدث خطأ داخلي في الخادم.",
+	"request_timeout":   "انتهت مهلة معالجة الطلب، حاول مرة أخرى.",
+	"request_canceled":  "تم إلغاء الطلب قبل اكتماله.",
+	"route_not_found":   "عذراً، المسار غير موجود (404).",
+	"invalid_body":      "جسم الطلب غير صالح.",
+	"invalid_query":     "معاملات الاستعلام غير صالحة.",
+	"validation_failed": "خطأ في التحقق من صحة البيانات.",
+	"invalid_slug":      "المعرف النصي في المسار غير صالح.",
+
+	// الترقيم والترتيب
+	"invalid_limit":      "قيمة limit غير صالحة: %q",
+	"invalid_offset":     "قيمة offset غير صالحة: %q",
+	"negative_offset":    "الإزاحة لا يمكن أن تكون سالبة: %d",
+	"offset_with_cursor": "لا يمكن استخدام offset و cursor معًا",
+	"invalid_cursor":     "مؤشر الترقيم غير صالح",
+	"cursor_with_sort":   "لا يمكن استخدام cursor مع ترتيب مخصص، استخدم offset بدلاً منه",
+	"cursor_with_search": "البحث يدعم الترقيم بـ offset فقط",
+	"invalid_sort_field": "حقل الترتيب غير مسموح: %q",
+
+	// التصفية والبحث
+	"invalid_author_filter": "author_id غير صالح: %q",
+	"invalid_date_filter":   "%s يجب أن يكون بصيغة RFC3339 أو YYYY-MM-DD: %q",
+	"invalid_created_range": "created_after يجب أن يسبق created_before",
+	"unsortable_field":      "لا يمكن الترتيب حسب الحقل %q",
+	"duplicate_sort_field":  "الحقل %q مكرر في الترتيب",
+	"search_query_required": "عبارة البحث q مطلوبة",
+
+	"invalid_tag_filter":      "tag غير صالح: %q",
+	"invalid_category_filter": "category غير صالح: %q",
+
+	// التزامن وترويسة If-Match
+	"if_match_malformed":      `ترويسة If-Match غير صالحة، أرسل قيمة ETag كما هي مثل "3"`,
+	"if_match_mismatch":       "قيمة If-Match لا تطابق النسخة الحالية.",
+	"if_match_required":       "ترويسة If-Match مطلوبة، استخدم قيمة ETag من آخر قراءة للسجل.",
+	"version_mismatch":        "نسخة السجل لا تطابق النسخة الحالية، أعد جلبه ثم حاول مرة أخرى (الحالية %d، المرسلة %d)",
+	"concurrent_modification": "تم تعديل السجل من طلب آخر أثناء الحفظ، أعد جلبه ثم حاول مرة أخرى",
+	"record_modified":         "تم تعديل السجل من طلب آخر منذ قراءته",
+	"constraint_violation":    "العملية تخالف ارتباط السجل بسجلات أخرى",
+
+	// المقالات
+	"invalid_article_id":            "معرف المقال غير صالح.",
+	"article_not_found":             "المقال بالمعرف %d غير موجود.",
+	"article_not_in_trash":          "المقال بالمعرف %d غير موجود في سلة المحذوفات.",
+	"article_duplicate":             "يوجد مقال آخر بنفس البيانات",
+	"duplicate_title":               "يوجد مقال آخر بنفس العنوان",
+	"article_author_not_found":      "المؤلف بالمعرف %d غير موجود",
+	"article_author_deleted":        "مؤلف المقال محذوف، لا يمكن استعادة المقال",
+	"purge_forbidden":               "الحذف النهائي متاح للمشرفين فقط.",
+	"trash_forbidden":               "سلة المحذوفات واستعادة المقالات منها متاحتان للمشرفين فقط.",
+	"bulk_aborted":                  "لم يُنفذ هذا العنصر لأن عنصرًا آخر في الدفعة فشل (وضع atomic)",
+	"bulk_duplicate_title":          "العنوان مكرر في الطلب نفسه (العنصر %d)",
+	"bulk_duplicate_id":             "المعرف %d مكرر في الطلب نفسه",
+	"item_version_required":         "version مطلوب في كل عنصر من عناصر التحديث المجمّع",
+	"invalid_status_filter":         "status لا يقبل القيمة %q (القيم المدعومة: draft و scheduled و published و archived و all)",
+	"status_filter_forbidden":       "عرض المقالات غير المنشورة متاح للمشرفين فقط.",
+	"invalid_status_transition":     "لا يمكن نقل المقال من الحالة %s إلى %s",
+	"publish_at_required":           "publish_at مطلوب مع الحالة scheduled",
+	"publish_at_requires_scheduled": "publish_at يُرسل مع الحالة scheduled فقط",
+	"publish_at_in_past":            "موعد النشر يجب أن يكون في المستقبل: %s",
+	"invalid_revision_number":       "رقم المراجعة غير صالح.",
+	"invalid_revision_param":        "%s يجب أن يكون رقم مراجعة موجبًا: %q",
+	"invalid_diff_granularity":      "granularity يقبل line أو word: %q",
+	"revision_not_found":            "المراجعة %d غير موجودة للمقال %d.",
+	"article_has_no_revisions":      "لا توجد مراجعات للمقال %d.",
+	"article_slug_not_found":        "لا يوجد مقال بالمعرف النصي %q.",
+	"article_category_not_found":    "التصنيف ذو المعرف %d غير موجود",
+
+	// المؤلفون
+	"invalid_include":           "include لا يقبل القيمة %q (القيمة المدعومة: stats)",
+	"invalid_author_id":         "معرف المؤلف غير صالح.",
+	"author_not_found":          "المؤلف بالمعرف %d غير موجود.",
+	"author_slug_not_found":     "لا يوجد مؤلف بالمعرف النصي %q.",
+	"author_email_taken":        "البريد الإلكتروني %q مستخدم من مؤلف آخر",
+	"author_slug_taken":         "المعرف النصي %q مستخدم من مؤلف آخر",
+	"author_duplicate":          "يوجد مؤلف آخر بنفس البريد الإلكتروني أو المعرف النصي",
+	"author_has_articles":       "لا يمكن حذف مؤلف له مقالات (%d مقال)، انقلها بـ on_articles=reassign&to=ID أو احذفها معه بـ on_articles=cascade",
+	"invalid_delete_policy":     "on_articles يقبل block أو cascade أو reassign: %q",
+	"reassign_target_required":  "to مطلوب مع on_articles=reassign",
+	"reassign_to_self":          "لا يمكن نقل المقالات إلى المؤلف نفسه",
+	"reassign_target_not_found": "المؤلف الهدف %d غير موجود",
+
+	// الوسوم
+	"invalid_tag_id":   "معرف الوسم غير صالح.",
+	"invalid_tag_name": "اسم الوسم يجب أن يحتوي على حرف أو رقم: %q",
+	"tag_not_found":    "الوسم ذو المعرف %d غير موجود.",
+	"tag_exists":       "يوجد وسم آخر بالمعرف النصي %q",
+
+	// التصنيفات
+	"invalid_category_id":       "معرف التصنيف غير صالح.",
+	"invalid_category_name":     "اسم التصنيف يجب أن يحتوي على حرف أو رقم: %q",
+	"category_not_found":        "التصنيف ذو المعرف %d غير موجود.",
+	"category_exists":           "يوجد تصنيف آخر بالمعرف النصي %q",
+	"category_parent_not_found": "التصنيف الأب %d غير موجود",
+	"category_cycle":            "لا يمكن نقل التصنيف تحت نفسه أو تحت أحد فروعه (%d)",
+	"category_has_children":     "لا يمكن حذف تصنيف له تصنيفات فرعية (%d)، انقلها أو احذفها أولاً",
+
+	// التعليقات
+	"invalid_comment_id":           "معرف التعليق غير صالح.",
+	"comment_not_found":            "التعليق ذو المعرف %d غير موجود.",
+	"invalid_comment_author":       "اسم كاتب التعليق لا يمكن أن يكون فارغًا",
+	"comment_body_empty":           "نص التعليق لا يمكن أن يكون فارغًا",
+	"comment_parent_not_found":     "التعليق %d غير موجود في هذا المقال أو لم يُعتمد بعد، فلا يمكن الرد عليه",
+	"comment_too_deep":             "لا يمكن الرد على رد بعمق %d، أضف ردك إلى سلسلة أعلى",
+	"comment_token_invalid":        "رمز التعديل في ترويسة X-Comment-Token غير صحيح لهذا التعليق.",
+	"comment_edit_window_closed":   "انتهت مهلة تعديل هذا التعليق.",
+	"comment_moderation_forbidden": "مراجعة التعليقات متاحة للمشرفين فقط.",
+	"invalid_comment_status":       "status يقبل pending أو approved أو rejected: %q",
+
+	// صيغ المحتوى
+	"invalid_render": "render يقبل raw أو html: %q",
+}
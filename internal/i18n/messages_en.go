@@ -0,0 +1,117 @@
+// my-article-app/internal/i18n/messages_en.go
+package i18n
+
+// englishMessages الرسائل الإنجليزية، والرموز الناقصة هنا تُعرض بالعربية
+var englishMessages = map[string]string{
+	// أخطاء عامة
+	"internal_error":    "An internal server error occurred.",
+	"request_timeout":   "The request timed out, please try again.",
+	"request_canceled":  "The request was canceled before it completed.",
+	"route_not_found":   "Sorry, the route was not found (404).",
+	"invalid_body":      "The request body is invalid.",
+	"invalid_query":     "The query parameters are invalid.",
+	"validation_failed": "Data validation failed.",
+	"invalid_slug":      "The slug in the path is invalid.",
+
+	// الترقيم والترتيب
+	"invalid_limit":      "Invalid limit value: %q",
+	"invalid_offset":     "Invalid offset value: %q",
+	"negative_offset":    "Offset cannot be negative: %d",
+	"offset_with_cursor": "offset and cursor cannot be used together",
+	"invalid_cursor":     "The pagination cursor is invalid",
+	"cursor_with_sort":   "cursor cannot be combined with a custom sort, use offset instead",
+	"cursor_with_search": "Search only supports offset pagination",
+	"invalid_sort_field": "Sort field is not allowed: %q",
+
+	// التصفية والبحث
+	"invalid_author_filter": "Invalid author_id: %q",
+	"invalid_date_filter":   "%s must be in RFC3339 or YYYY-MM-DD format: %q",
+	"invalid_created_range": "created_after must be before created_before",
+	"unsortable_field":      "Cannot sort by field %q",
+	"duplicate_sort_field":  "Field %q appears more than once in sort",
+	"search_query_required": "The search query q is required",
+
+	"invalid_tag_filter":      "Invalid tag: %q",
+	"invalid_category_filter": "Invalid category: %q",
+
+	// التزامن وترويسة If-Match
+	"if_match_malformed":      `Invalid If-Match header, send the ETag value as is, e.g. "3"`,
+	"if_match_mismatch":       "The If-Match value does not match the current version.",
+	"if_match_required":       "The If-Match header is required, use the ETag from the last read of the record.",
+	"version_mismatch":        "The record version does not match the current version, fetch it again and retry (current %d, sent %d)",
+	"concurrent_modification": "The record was modified by another request while saving, fetch it again and retry",
+	"record_modified":         "The record was modified by another request since it was read",
+	"constraint_violation":    "The operation violates the record's relation to other records",
+
+	// المقالات
+	"invalid_article_id":            "Invalid article ID.",
+	"article_not_found":             "Article with ID %d was not found.",
+	"article_not_in_trash":          "Article with ID %d is not in the trash.",
+	"article_duplicate":             "Another article with the same data already exists",
+	"duplicate_title":               "Another article with the same title already exists",
+	"article_author_not_found":      "Author with ID %d does not exist",
+	"article_author_deleted":        "The article's author has been deleted, the article cannot be restored",
+	"purge_forbidden":               "Permanent deletion is restricted to administrators.",
+	"trash_forbidden":               "The trash and restoring articles from it are restricted to administrators.",
+	"bulk_aborted":                  "This item was not applied because another item in the batch failed (atomic mode)",
+	"bulk_duplicate_title":          "The title is repeated within the same request (item %d)",
+	"bulk_duplicate_id":             "ID %d is repeated within the same request",
+	"item_version_required":         "version is required on every item of a bulk update",
+	"invalid_status_filter":         "status does not accept %q (supported: draft, scheduled, published, archived, all)",
+	"status_filter_forbidden":       "Listing unpublished articles is restricted to administrators.",
+	"invalid_status_transition":     "Cannot move the article from %s to %s",
+	"publish_at_required":           "publish_at is required with status scheduled",
+	"publish_at_requires_scheduled": "publish_at is only accepted with status scheduled",
+	"publish_at_in_past":            "The publish time must be in the future: %s",
+	"invalid_revision_number":       "Invalid revision number.",
+	"invalid_revision_param":        "%s must be a positive revision number: %q",
+	"invalid_diff_granularity":      "granularity accepts line or word: %q",
+	"revision_not_found":            "Revision %d of article %d was not found.",
+	"article_has_no_revisions":      "Article %d has no revisions.",
+	"article_slug_not_found":        "No article has the slug %q.",
+	"article_category_not_found":    "Category with ID %d does not exist",
+
+	// المؤلفون
+	"invalid_include":           "include does not accept %q (supported: stats)",
+	"invalid_author_id":         "Invalid author ID.",
+	"author_not_found":          "Author with ID %d was not found.",
+	"author_slug_not_found":     "No author has the slug %q.",
+	"author_email_taken":        "The email %q is already used by another author",
+	"author_slug_taken":         "The slug %q is already used by another author",
+	"author_duplicate":          "Another author already has the same email or slug",
+	"author_has_articles":       "Cannot delete an author who has articles (%d articles), move them with on_articles=reassign&to=ID or delete them with on_articles=cascade",
+	"invalid_delete_policy":     "on_articles accepts block, cascade or reassign: %q",
+	"reassign_target_required":  "to is required with on_articles=reassign",
+	"reassign_to_self":          "Articles cannot be reassigned to the same author",
+	"reassign_target_not_found": "Target author %d does not exist",
+
+	// الوسوم
+	"invalid_tag_id":   "Invalid tag ID.",
+	"invalid_tag_name": "The tag name must contain a letter or digit: %q",
+	"tag_not_found":    "Tag with ID %d was not found.",
+	"tag_exists":       "Another tag already has the slug %q",
+
+	// التصنيفات
+	"invalid_category_id":       "Invalid category ID.",
+	"invalid_category_name":     "The category name must contain a letter or digit: %q",
+	"category_not_found":        "Category with ID %d was not found.",
+	"category_exists":           "Another category already has the slug %q",
+	"category_parent_not_found": "Parent category %d does not exist",
+	"category_cycle":            "A category cannot be moved under itself or one of its descendants (%d)",
+	"category_has_children":     "Cannot delete a category that has subcategories (%d), move or delete them first",
+
+	// التعليقات
+	"invalid_comment_id":           "Invalid comment ID.",
+	"comment_not_found":            "Comment with ID %d was not found.",
+	"invalid_comment_author":       "The comment author name cannot be blank",
+	"comment_body_empty":           "The comment body cannot be blank",
+	"comment_parent_not_found":     "Comment %d is not an approved comment on this article and cannot be replied to",
+	"comment_too_deep":             "Replies cannot go deeper than %d levels, reply higher up the thread",
+	"comment_token_invalid":        "The X-Comment-Token header does not match this comment.",
+	"comment_edit_window_closed":   "The edit window for this comment has closed.",
+	"comment_moderation_forbidden": "Comment moderation is restricted to administrators.",
+	"invalid_comment_status":       "status accepts pending, approved or rejected: %q",
+
+	// صيغ المحتوى
+	"invalid_render": "render accepts raw or html: %q",
+}
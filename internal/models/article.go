@@ -3,6 +3,16 @@ package models
 
 import (
 	"time"
+
+	"gorm.io/gorm"
+)
+
+// حالات دورة حياة المقال؛ الانتقالات المسموحة بينها تحددها طبقة منطق العمل
+const (
+	ArticleStatusDraft     = "draft"
+	ArticleStatusScheduled = "scheduled"
+	ArticleStatusPublished = "published"
+	ArticleStatusArchived  = "archived"
 )
 
 // Article   بنية قاعدة البيانات فقط
@@ -14,4 +24,33 @@ type Article struct {
 	Author    Author    `gorm:"foreignKey:AuthorID"` // نحتفظ بهذا لـ GORM Preload
 	CreatedAt time.Time `gorm:"autoCreateTime"`
 	UpdatedAt time.Time `gorm:"autoUpdateTime"`
-}
\ No newline at end of file
+	// DeletedAt الحذف منطقي: المقال المحذوف ينتقل إلى سلة المحذوفات ويُستبعد تلقائيًا من الاستعلامات
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+	// Version رقم نسخة السجل للتحكم المتفائل في التزامن، يُعاد للعميل كـ ETag
+	Version uint `gorm:"not null;default:1"`
+	// Slug معرف نصي فريد للروابط يُولَّد من العنوان، ومعرفاته السابقة في ArticleSlugHistory
+	Slug string `gorm:"size:100;not null;uniqueIndex:uni_articles_slug"`
+	// ContentFormat صيغة المحتوى (plain أو markdown أو html)، والمحتوى بصيغة html يُخزَّن منقّى
+	ContentFormat string `gorm:"size:20;not null;default:plain"`
+	// ContentHTML المحتوى محوَّلًا إلى HTML منقّى، يملؤه المستودع عند الحفظ ويُعاد مع ?render=html
+	ContentHTML string
+
+	// نسخ موحدة (textnorm) تُستخدم للبحث وكشف تكرار العناوين، يملؤها المستودع عند الحفظ
+	TitleNormalized   string
+	ContentNormalized string
+	// WordCount عدد كلمات المحتوى لإحصاءات المؤلفين، يملؤه المستودع عند الحفظ
+	WordCount int `gorm:"not null;default:0"`
+
+	// Status حالة المقال، والمنشور وحده يظهر في القوائم العامة
+	Status string `gorm:"size:20;not null;default:draft;index:idx_articles_status_publish_at,priority:1"`
+	// PublishAt موعد النشر للمقال المجدول (scheduled)، وتفرغ عند نشره أو إلغاء جدولته
+	PublishAt *time.Time `gorm:"index:idx_articles_status_publish_at,priority:2"`
+	// PublishedAt وقت النشر الفعلي، يبقى بعد الأرشفة وتعتمد عليه إحصاءات المؤلفين
+	PublishedAt *time.Time
+	// CommentCount عدد التعليقات المعتمدة غير المحذوفة، يحدّثه مستودع التعليقات ولا يغيّره Update
+	CommentCount int `gorm:"not null;default:0"`
+
+	// Tags و Categories تصنيف المقال عبر جدولي الربط، ويحفظهما مستودعا الوسوم والتصنيفات لا Create و Update
+	Tags       []Tag      `gorm:"many2many:article_tags"`
+	Categories []Category `gorm:"many2many:article_categories"`
+}
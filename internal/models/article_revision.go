@@ -0,0 +1,23 @@
+// my-article-app/internal/models/article_revision.go
+package models
+
+import "time"
+
+// ArticleRevision نسخة محفوظة من عنوان المقال ومحتواه، تُضاف مع كل إنشاء أو تحديث ولا تُعدّل بعد ذلك
+type ArticleRevision struct {
+	ID        uint `gorm:"primaryKey"`
+	ArticleID uint `gorm:"not null;uniqueIndex:uni_article_revisions_article_revision,priority:1"`
+	// Revision رقم المراجعة المتسلسل داخل المقال، يبدأ من 1
+	Revision uint   `gorm:"not null;uniqueIndex:uni_article_revisions_article_revision,priority:2"`
+	Title    string `gorm:"not null"`
+	Content  string `gorm:"not null"`
+	// ContentFormat صيغة المحتوى في هذه المراجعة، ويستعيدها الاسترجاع مع المحتوى
+	ContentFormat string `gorm:"size:20;not null;default:plain"`
+	// EditedBy اسم المحرر كما أرسله العميل في ترويسة X-Editor (فارغ إذا لم يُرسل)
+	EditedBy string `gorm:"size:100;not null;default:''"`
+	// RevertedFrom رقم المراجعة التي استُرجع محتواها، أو nil للتعديل العادي
+	RevertedFrom *uint
+	// ArticleVersion رقم نسخة المقال بعد هذا التعديل
+	ArticleVersion uint      `gorm:"not null"`
+	CreatedAt      time.Time `gorm:"autoCreateTime"`
+}
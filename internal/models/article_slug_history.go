@@ -0,0 +1,17 @@
+// my-article-app/internal/models/article_slug_history.go
+package models
+
+import "time"
+
+// ArticleSlugHistory معرف نصي سابق للمقال بعد تغيير عنوانه، يبقى محجوزًا له ويُعاد توجيهه إلى معرفه الحالي
+type ArticleSlugHistory struct {
+	ID        uint      `gorm:"primaryKey"`
+	ArticleID uint      `gorm:"not null;index:idx_article_slug_history_article_id"`
+	Slug      string    `gorm:"size:100;not null;uniqueIndex:uni_article_slug_history_slug"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+// TableName اسم الجدول بصيغة المفرد لأنه سجل تاريخي وليس مجموعة كيانات
+func (ArticleSlugHistory) TableName() string {
+	return "article_slug_history"
+}
@@ -11,4 +11,11 @@ type Author struct {
 	Name     string
 	Email    string    `gorm:"unique;not null"`
 	Articles []Article `gorm:"foreignKey:AuthorID"` // نحتفظ بهذا لـ GORM Preload
-}
\ No newline at end of file
+	// Version رقم نسخة السجل للتحكم المتفائل في التزامن، يُعاد للعميل كـ ETag
+	Version uint `gorm:"not null;default:1"`
+	// Slug معرف نصي فريد للروابط يُولَّد من الاسم
+	Slug string `gorm:"size:100;not null;uniqueIndex:uni_authors_slug"`
+
+	// نسخة موحدة (textnorm) من الاسم تُستخدم للبحث عن المؤلفين، يملؤها المستودع عند الحفظ
+	NameNormalized string
+}
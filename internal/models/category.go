@@ -0,0 +1,25 @@
+// my-article-app/internal/models/category.go
+package models
+
+import "time"
+
+// Category تصنيف هرمي للمقالات؛ التصنيف بلا أب (ParentID nil) في جذر الشجرة
+type Category struct {
+	ID        uint      `gorm:"primaryKey"`
+	Name      string    `gorm:"size:50;not null"`
+	Slug      string    `gorm:"size:100;not null;uniqueIndex:uni_categories_slug"`
+	ParentID  *uint     `gorm:"index"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+// ArticleCategory صف في جدول الربط بين المقالات والتصنيفات
+type ArticleCategory struct {
+	ArticleID  uint `gorm:"primaryKey"`
+	CategoryID uint `gorm:"primaryKey"`
+}
+
+// TableName يحدد اسم جدول الربط كما يسميه GORM في علاقة many2many
+func (ArticleCategory) TableName() string {
+	return "article_categories"
+}
@@ -0,0 +1,40 @@
+// my-article-app/internal/models/comment.go
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// حالات مراجعة التعليق؛ المعتمد وحده يظهر للقراء ويُحسب في عدد تعليقات المقال
+const (
+	CommentStatusPending  = "pending"
+	CommentStatusApproved = "approved"
+	CommentStatusRejected = "rejected"
+)
+
+// Comment تعليق قارئ على مقال، أو رد على تعليق آخر في المقال نفسه
+type Comment struct {
+	ID        uint `gorm:"primaryKey"`
+	ArticleID uint `gorm:"not null;index"`
+	// ParentID التعليق الذي يرد عليه، أو nil للتعليق الجذري
+	ParentID *uint
+	// ThreadID التعليق الجذري لسلسلة الرد، أو nil للتعليق الجذري نفسه؛ تُجلب به ردود السلسلة كلها دفعة واحدة
+	ThreadID *uint `gorm:"index"`
+	// Depth عمق الرد في السلسلة، والتعليق الجذري عمقه 0
+	Depth      int    `gorm:"not null;default:0"`
+	AuthorName string `gorm:"size:100;not null"`
+	Body       string `gorm:"not null"`
+	Status     string `gorm:"size:20;not null;default:pending;index"`
+	// EditTokenHash بصمة SHA-256 لرمز التعديل الذي يُعطى لكاتب التعليق مرة واحدة عند إنشائه
+	EditTokenHash string `gorm:"size:64;not null"`
+	EditedAt      *time.Time
+	// ModeratedAt و ModeratedBy آخر قرار مراجعة، والمراجع كما أرسل اسمه في ترويسة X-Editor
+	ModeratedAt *time.Time
+	ModeratedBy string    `gorm:"size:100;not null;default:''"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
+	// DeletedAt الحذف منطقي، فيبقى التعليق المحذوف مكانًا لردوده في السلسلة
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
@@ -0,0 +1,24 @@
+// my-article-app/internal/models/tag.go
+package models
+
+import "time"
+
+// Tag وسم حر يُصنَّف به المقال، ويُعرَّف بمعرفه النصي فيكون "Go" و"go" وسمًا واحدًا
+type Tag struct {
+	ID        uint      `gorm:"primaryKey"`
+	Name      string    `gorm:"size:50;not null"`
+	Slug      string    `gorm:"size:100;not null;uniqueIndex:uni_tags_slug"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+// ArticleTag صف في جدول الربط بين المقالات والوسوم
+type ArticleTag struct {
+	ArticleID uint `gorm:"primaryKey"`
+	TagID     uint `gorm:"primaryKey"`
+}
+
+// TableName يحدد اسم جدول الربط كما يسميه GORM في علاقة many2many
+func (ArticleTag) TableName() string {
+	return "article_tags"
+}
@@ -0,0 +1,86 @@
+// my-article-app/internal/pagination/pagination.go
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"my-article-app/internal/apperr"
+)
+
+// حدود حجم الصفحة على مستوى الخادم
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+// ErrInvalidCursor يُرجع عندما يتعذر فك المؤشر المرسل من العميل
+var ErrInvalidCursor = apperr.Validation("invalid_cursor")
+
+// Request معاملات طلب صفحة: إما إزاحة (limit/offset) أو مؤشر مبهم (cursor)
+type Request struct {
+	Limit  int
+	Offset int
+	Cursor string
+}
+
+// Page صفحة من النتائج مع إجمالي السجلات والمؤشر التالي إن وُجد
+type Page[T any] struct {
+	Items      []T
+	Total      int64
+	Limit      int
+	Offset     int
+	HasMore    bool
+	NextCursor string
+}
+
+// cursor البنية الداخلية للمؤشر قبل ترميزه؛ العميل يتعامل معه كنص مبهم
+type cursor struct {
+	ID uint `json:"id"`
+}
+
+// Normalize يطبّق الحجم الافتراضي والحد الأقصى ويتحقق من الإزاحة
+func (r Request) Normalize() (Request, error) {
+	if r.Limit <= 0 {
+		r.Limit = DefaultLimit
+	}
+	if r.Limit > MaxLimit {
+		r.Limit = MaxLimit
+	}
+	if r.Offset < 0 {
+		return r, apperr.Validation("negative_offset", r.Offset)
+	}
+	if r.Cursor != "" && r.Offset > 0 {
+		return r, apperr.Validation("offset_with_cursor")
+	}
+	if _, err := r.AfterID(); err != nil {
+		return r, err
+	}
+	return r, nil
+}
+
+// IsKeyset يحدد ما إذا كان الطلب يستخدم الترقيم بالمؤشر
+func (r Request) IsKeyset() bool {
+	return r.Cursor != ""
+}
+
+// AfterID يفك المؤشر ويعيد المعرف الذي تبدأ الصفحة بعده (0 إذا لم يوجد مؤشر)
+func (r Request) AfterID() (uint, error) {
+	if r.Cursor == "" {
+		return 0, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(r.Cursor)
+	if err != nil {
+		return 0, ErrInvalidCursor
+	}
+	var c cursor
+	if err := json.Unmarshal(raw, &c); err != nil || c.ID == 0 {
+		return 0, ErrInvalidCursor
+	}
+	return c.ID, nil
+}
+
+// EncodeCursor يرمّز معرف آخر سجل في الصفحة كمؤشر مبهم
+func EncodeCursor(id uint) string {
+	raw, _ := json.Marshal(cursor{ID: id})
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
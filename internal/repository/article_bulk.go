@@ -0,0 +1,59 @@
+// my-article-app/internal/repository/article_bulk.go
+package repository
+
+import (
+	"context"
+	"fmt"
+	"my-article-app/internal/apperr"
+	"my-article-app/internal/models"
+	"my-article-app/internal/textnorm"
+)
+
+// CreateBatch ينشئ عدة مقالات بإدخالات مجمّعة (INSERT بعدة صفوف) من batchSize صف لكل استعلام، ويملأ معرفاتها
+// فشل أي دفعة يعيد الخطأ دون تحديد المقال المسبب، والتراجع عن الدفعات السابقة مسؤولية المعاملة المحيطة
+func (r *articleRepository) CreateBatch(ctx context.Context, articles []*models.Article, batchSize int) error {
+	if len(articles) == 0 {
+		return nil
+	}
+	for _, article := range articles {
+		normalizeArticle(article)
+		if article.Version == 0 {
+			article.Version = 1
+		}
+	}
+
+	result := r.db.WithContext(ctx).CreateInBatches(articles, batchSize)
+	if result.Error != nil {
+		if err := constraintError(result.Error, apperr.Conflict("article_duplicate")); err != nil {
+			return err
+		}
+		return fmt.Errorf("فشل إنشاء دفعة المقالات: %w", result.Error)
+	}
+	return nil
+}
+
+// ExistingTitles يعيد من بين العناوين المعطاة تلك التي تطابق (بعد التوحيد) عنوان مقال غير محذوف،
+// كمجموعة من العناوين الموحدة، باستعلام واحد بدل ExistsByTitle لكل عنوان
+func (r *articleRepository) ExistingTitles(ctx context.Context, titles []string) (map[string]bool, error) {
+	existing := make(map[string]bool)
+	if len(titles) == 0 {
+		return existing, nil
+	}
+
+	normalized := make([]string, 0, len(titles))
+	for _, title := range titles {
+		normalized = append(normalized, textnorm.Normalize(title))
+	}
+
+	var found []string
+	err := r.db.WithContext(ctx).Model(&models.Article{}).
+		Where("title_normalized IN ?", normalized).
+		Distinct().Pluck("title_normalized", &found).Error
+	if err != nil {
+		return nil, fmt.Errorf("فشل التحقق من تكرار عناوين المقالات: %w", err)
+	}
+	for _, title := range found {
+		existing[title] = true
+	}
+	return existing, nil
+}
@@ -3,20 +3,82 @@ package repository
 
 // استيراد المكتبات اللازمة للعمل
 import (
-	// مكتبة للتعامل مع الأخطاء
-	"fmt"                            // مكتبة للتعامل مع النصوص
-	"my-article-app/internal/models" // استيراد نماذج البيانات (مثل Article)
+	"context"                            // مكتبة لتمرير السياق (الإلغاء والمهلات) إلى قاعدة البيانات
+	"fmt"                                // مكتبة للتعامل مع النصوص
+	"my-article-app/internal/apperr"     // الأخطاء المصنّفة (NotFound و Conflict وغيرها)
+	"my-article-app/internal/models"     // استيراد نماذج البيانات (مثل Article)
+	"my-article-app/internal/pagination" // معاملات الترقيم ونتائج الصفحات
+	"my-article-app/internal/richtext"   // تحويل المحتوى إلى HTML منقّى
+	"my-article-app/internal/textnorm"   // توحيد النصوص العربية للبحث والمقارنة
+	"strings"                            // مكتبة لتقسيم المحتوى إلى كلمات
+	"time"                               // مكتبة للتعامل مع التواريخ
 
-	"gorm.io/gorm" // مكتبة GORM للتعامل مع قواعد البيانات
+	"gorm.io/gorm"        // مكتبة GORM للتعامل مع قواعد البيانات
+	"gorm.io/gorm/clause" // بناء عبارات SQL بترتيب صريح
 )
 
 type ArticleRepository interface {
+	Create(ctx context.Context, article *models.Article) error
+	CreateBatch(ctx context.Context, articles []*models.Article, batchSize int) error
+	FindAll(ctx context.Context, filter ArticleFilter, req pagination.Request) (*pagination.Page[models.Article], error)
+	FindByID(ctx context.Context, id uint) (*models.Article, error)
+	FindBySlug(ctx context.Context, slug string) (*models.Article, error)
+	TakenSlugs(ctx context.Context, bases []string) (map[string]uint, error)
+	Search(ctx context.Context, query string, req pagination.Request) (*pagination.Page[ArticleSearchResult], error)
+	ExistsByTitle(ctx context.Context, title string, excludeID uint) (bool, error)
+	ExistingTitles(ctx context.Context, titles []string) (map[string]bool, error)
+	Update(ctx context.Context, article *models.Article) error
+	Delete(ctx context.Context, id uint) error
+	FindTrash(ctx context.Context, req pagination.Request) (*pagination.Page[models.Article], error)
+	FindTrashedByID(ctx context.Context, id uint) (*models.Article, error)
+	Restore(ctx context.Context, article *models.Article) error
+	Purge(ctx context.Context, id uint) error
+	PurgeDeletedBefore(ctx context.Context, cutoff time.Time) (int64, error)
+	CountByAuthor(ctx context.Context, authorID uint) (int64, error)
+	DeleteByAuthor(ctx context.Context, authorID uint) (int64, error)
+	ReassignAuthor(ctx context.Context, fromID, toID uint) (int64, error)
+	PublishDue(ctx context.Context, now time.Time) (int64, error)
+}
+
+// ArticleFilter شروط تصفية وترتيب قائمة المقالات (القيم الفارغة تعني عدم التصفية)
+type ArticleFilter struct {
+	// Status يقصر القائمة على حالة واحدة؛ القوائم العامة تمرر published دائمًا
+	Status        string
+	AuthorID      uint
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	UpdatedSince  *time.Time
+	TitleContains string
+	// Tag المعرف النصي لوسم يحمله المقال
+	Tag string
+	// Category المعرف النصي لتصنيف يقع فيه المقال مباشرة أو في أحد تصنيفاته الفرعية
+	Category string
+	Sort     []SortField
+}
+
+// articlePreloads علاقات المقال التي تُحمّل مع كل قراءة له
+var articlePreloads = []string{"Author", "Tags", "Categories"}
+
+// preloadArticle يضيف articlePreloads إلى استعلام مقال واحد
+func preloadArticle(q *gorm.DB) *gorm.DB {
+	for _, p := range articlePreloads {
+		q = q.Preload(p)
+	}
+	return q
+}
+
+// articleSortColumns القائمة المسموحة لحقول الترتيب وأعمدتها المقابلة
+var articleSortColumns = map[string]string{
+	"id":         "articles.id",
+	"title":      "articles.title",
+	"created_at": "articles.created_at",
+	"updated_at": "articles.updated_at",
+}
 
-	Create(article *models.Article) error
-	FindAll() ([]models.Article, error)
-	FindByID(id uint) (*models.Article, error)
-	Update(article *models.Article) error
-	Delete(id uint) error
+// IsArticleSortField يحدد ما إذا كان الحقل مسموحًا للترتيب
+func IsArticleSortField(field string) bool {
+	_, ok := articleSortColumns[field]
+	return ok
 }
 
 type articleRepository struct {
@@ -26,53 +88,107 @@ type articleRepository struct {
 // NewArticleRepository ينشئ مثيلاً جديدًا من ArticleRepository
 
 func NewArticleRepository(db *gorm.DB) ArticleRepository {
-	
+
 	return &articleRepository{db: db}
 }
 
 // Create يقوم بإنشاء مقال جديد في قاعدة البيانات
 // تُستدعى هذه الدالة من طبقة منطق العمل (UseCase) عندما يُطلب إنشاء مقال جديد
-func (r *articleRepository) Create(article *models.Article) error {
+func (r *articleRepository) Create(ctx context.Context, article *models.Article) error {
+	// تحديث النسخ الموحدة من العنوان والمحتوى قبل الحفظ
+	normalizeArticle(article)
+	if article.Version == 0 {
+		// نضبط النسخة صراحة لأن MySQL لا يعيد القيمة الافتراضية بعد الإدخال
+		article.Version = 1
+	}
+
 	// استخدام GORM لإدخال بيانات المقال في قاعدة البيانات
 	// GORM: db.Create(&article) سيقوم بإنشاء سجل جديد في جدول articles
 	// وسيتم ملء حقل ID تلقائياً بواسطة GORM بعد الإنشاء.
 	// سيقوم GORM أيضاً بحفظ AuthorID إذا تم توفيره في بنية Article
-	result := r.db.Create(article)
+	result := r.db.WithContext(ctx).Create(article)
 	if result.Error != nil {
+		if err := constraintError(result.Error, apperr.Conflict("article_duplicate")); err != nil {
+			return err
+		}
 		// إرجاع الخطأ مع رسالة توضيحية
 		return fmt.Errorf("فشل إنشاء المقال: %w", result.Error)
 	}
 	return nil
 }
 
-// FindAll يجلب جميع المقالات من قاعدة البيانات
-// تُستدعى هذه الدالة من طبقة منطق العمل (UseCase) عندما يُطلب عرض جميع المقالات
-func (r *articleRepository) FindAll() ([]models.Article, error) {
-	// تعريف متغير لتخزين المقالات المسترجعة
-	var articles []models.Article
-	// استخدام GORM لاسترجاع جميع المقالات من قاعدة البيانات
-	// استخدام Preload("Author") لجلب بيانات المؤلف المرتبطة مع كل مقال
-	result := r.db.Preload("Author").Find(&articles)
-	if result.Error != nil {
+// FindAll يجلب صفحة من المقالات المطابقة لشروط التصفية من قاعدة البيانات
+// تُستدعى هذه الدالة من طبقة منطق العمل (UseCase) عندما يُطلب عرض قائمة المقالات
+func (r *articleRepository) FindAll(ctx context.Context, filter ArticleFilter, req pagination.Request) (*pagination.Page[models.Article], error) {
+	// تحويل حقول الترتيب إلى أعمدة من القائمة المسموحة فقط
+	orders, err := orderClauses(filter.Sort, articleSortColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	// تحميل المؤلف والوسوم والتصنيفات المرتبطة مع كل مقال في الصفحة
+	page, err := findPage(applyArticleFilter(r.db.WithContext(ctx).Model(&models.Article{}), filter), req, pageSpec[models.Article]{
+		idColumn: "articles.id",
+		idOf:     func(a *models.Article) uint { return a.ID },
+		orders:   orders,
+		preloads: articlePreloads,
+	})
+	if err != nil {
 		// إرجاع الخطأ مع رسالة توضيحية
-		return nil, fmt.Errorf("فشل جلب المقالات: %w", result.Error)
+		return nil, fmt.Errorf("فشل جلب المقالات: %w", err)
+	}
+	return page, nil
+}
+
+// applyArticleFilter يضيف شروط التصفية إلى الاستعلام كمعاملات مربوطة (بدون دمج نصوص المستخدم في SQL)
+func applyArticleFilter(q *gorm.DB, filter ArticleFilter) *gorm.DB {
+	if filter.Status != "" {
+		q = q.Where("articles.status = ?", filter.Status)
+	}
+	if filter.AuthorID != 0 {
+		q = q.Where("articles.author_id = ?", filter.AuthorID)
+	}
+	if filter.CreatedAfter != nil {
+		q = q.Where("articles.created_at >= ?", *filter.CreatedAfter)
+	}
+	if filter.CreatedBefore != nil {
+		q = q.Where("articles.created_at < ?", *filter.CreatedBefore)
+	}
+	if filter.UpdatedSince != nil {
+		q = q.Where("articles.updated_at >= ?", *filter.UpdatedSince)
+	}
+	if filter.TitleContains != "" {
+		pattern := "%" + escapeLike(textnorm.Normalize(filter.TitleContains)) + "%"
+		q = q.Where("articles.title_normalized LIKE ? ESCAPE '!'", pattern)
+	}
+	if filter.Tag != "" {
+		q = q.Where("articles.id IN (SELECT article_tags.article_id FROM article_tags "+
+			"JOIN tags ON tags.id = article_tags.tag_id WHERE tags.slug = ?)", filter.Tag)
+	}
+	if filter.Category != "" {
+		// شجرة التصنيف الفرعية باستعلام تعاودي؛ UNION يحذف المكرر فينتهي حتى لو وُجدت حلقة
+		q = q.Where("articles.id IN (WITH RECURSIVE subtree (id) AS ("+
+			"SELECT id FROM categories WHERE slug = ? "+
+			"UNION SELECT categories.id FROM categories JOIN subtree ON categories.parent_id = subtree.id) "+
+			"SELECT article_categories.article_id FROM article_categories "+
+			"WHERE article_categories.category_id IN (SELECT id FROM subtree))", filter.Category)
 	}
-	 return nil, result.Error
+	return q
 }
 
 // FindByID يجلب مقالًا واحدًا حسب ID
 // تُستدعى هذه الدالة من طبقة منطق العمل (UseCase) عندما يُطلب عرض مقال محدد
-func (r *articleRepository) FindByID(id uint) (*models.Article, error) {
+func (r *articleRepository) FindByID(ctx context.Context, id uint) (*models.Article, error) {
 	// تعريف متغير لتخزين المقال المسترجع
 	var article models.Article
 	// استخدام GORM للبحث عن المقال بواسطة الـ ID
-	// استخدام Preload("Author") لجلب بيانات المؤلف المرتبطة مع المقال
-	result := r.db.Preload("Author").First(&article, id)
+	// تحميل المؤلف والوسوم والتصنيفات المرتبطة مع المقال
+	result := preloadArticle(r.db.WithContext(ctx)).First(&article, id)
 	if result.Error != nil {
 		// إذا كان الخطأ هو عدم وجود المقال
 		if result.Error == gorm.ErrRecordNotFound {
-			// إرجاع رسالة خطأ مخصصة
-			 return nil, result.Error
+			// إرجاع خطأ NotFound مصنّف
+			return nil, notFoundIf(result.Error, "article_not_found", id)
 		}
 		// إرجاع الخطأ مع رسالة توضيحية
 		return nil, fmt.Errorf("فشل جلب المقال بالمعرف %d: %w", id, result.Error)
@@ -80,36 +196,182 @@ func (r *articleRepository) FindByID(id uint) (*models.Article, error) {
 	return &article, nil
 }
 
-// Update يقوم بتحديث مقال موجود في قاعدة البيانات
+// FindBySlug يجلب مقالاً غير محذوف مع علاقاته حسب معرفه النصي الحالي
+func (r *articleRepository) FindBySlug(ctx context.Context, slug string) (*models.Article, error) {
+	var article models.Article
+	result := preloadArticle(r.db.WithContext(ctx)).Where("slug = ?", slug).First(&article)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, notFoundIf(result.Error, "article_slug_not_found", slug)
+		}
+		return nil, fmt.Errorf("فشل جلب المقال بالمعرف النصي %q: %w", slug, result.Error)
+	}
+	return &article, nil
+}
+
+// TakenSlugs يعيد المعرفات النصية للمقالات (ومنها الموجودة في سلة المحذوفات) التي قد تكون من مرشحي bases،
+// مع معرف المقال الذي يحملها
+func (r *articleRepository) TakenSlugs(ctx context.Context, bases []string) (map[string]uint, error) {
+	taken := make(map[string]uint)
+	if len(bases) == 0 {
+		return taken, nil
+	}
+
+	var articles []models.Article
+	err := whereSlugFrom(r.db.WithContext(ctx).Unscoped().Model(&models.Article{}), "slug", bases).
+		Select("id", "slug").Find(&articles).Error
+	if err != nil {
+		return nil, fmt.Errorf("فشل جلب المعرفات النصية للمقالات: %w", err)
+	}
+	for _, article := range articles {
+		taken[article.Slug] = article.ID
+	}
+	return taken, nil
+}
+
+// ExistsByTitle يتحقق من وجود مقال آخر بنفس العنوان بعد التوحيد (بغض النظر عن التشكيل وأشكال الحروف)
+// excludeID يستثني المقال الحالي عند التحديث، ويُمرر 0 عند الإنشاء
+func (r *articleRepository) ExistsByTitle(ctx context.Context, title string, excludeID uint) (bool, error) {
+	var count int64
+	q := r.db.WithContext(ctx).Model(&models.Article{}).Where("title_normalized = ?", textnorm.Normalize(title))
+	if excludeID != 0 {
+		q = q.Where("id <> ?", excludeID)
+	}
+	if err := q.Count(&count).Error; err != nil {
+		return false, fmt.Errorf("فشل التحقق من تكرار عنوان المقال: %w", err)
+	}
+	return count > 0, nil
+}
+
+// normalizeArticle يملأ الأعمدة المشتقة من العنوان والمحتوى: النسخ الموحدة وعدد الكلمات و HTML المنقّى،
+// ويضبط الحالة والصيغة الافتراضيتين صراحة لأن MySQL لا يعيد القيمة الافتراضية بعد الإدخال
+// المحتوى بصيغة html يُنقّى نفسه قبل الحفظ فلا يُخزَّن ما قد يُنفَّذ عند عرضه، حتى مع ?render=raw
+func normalizeArticle(article *models.Article) {
+	if article.Status == "" {
+		article.Status = models.ArticleStatusDraft
+	}
+	if article.ContentFormat == "" {
+		article.ContentFormat = richtext.FormatPlain
+	}
+	if article.ContentFormat == richtext.FormatHTML {
+		article.Content = richtext.Sanitize(article.Content)
+	}
+	article.ContentHTML = richtext.Render(article.Content, article.ContentFormat)
+
+	text := articleText(article)
+	article.TitleNormalized = textnorm.Normalize(article.Title)
+	article.ContentNormalized = textnorm.Normalize(text)
+	article.WordCount = len(strings.Fields(text))
+}
+
+// articleText يعيد النص المقروء من محتوى المقال دون رموز Markdown ووسوم HTML،
+// وعليه يتم البحث وعدّ الكلمات وتُبنى مقتطفات نتائج البحث؛ هو نص غير مهرَّب، و snippet يهرّبه
+func articleText(article *models.Article) string {
+	if article.ContentFormat == "" || article.ContentFormat == richtext.FormatPlain {
+		return article.Content
+	}
+	return richtext.Text(article.ContentHTML)
+}
+
+// Update يقوم بتحديث مقال موجود في قاعدة البيانات، بما في ذلك حالته وموعد نشره
 // تُستدعى هذه الدالة من طبقة منطق العمل (UseCase) عندما يُطلب تحديث مقال
-func (r *articleRepository) Update(article *models.Article) error {
-	// استخدام GORM لتحديث السجل إذا كان له ID موجود
-	// وإلا فسيقوم بإنشاء سجل جديد (Upsert)
-	result := r.db.Save(article) // Save يعمل كـ Update أو Create
+// التحديث مشروط برقم النسخة الذي قُرئ به المقال (UPDATE ... WHERE version = ?)،
+// فإذا عدّله طلب آخر في هذه الأثناء يُرجع ErrVersionConflict بدل الكتابة فوق تعديله
+func (r *articleRepository) Update(ctx context.Context, article *models.Article) error {
+	// تحديث النسخ الموحدة من العنوان والمحتوى قبل الحفظ
+	normalizeArticle(article)
+
+	expected := article.Version
+	article.Version = expected + 1
+	result := r.db.WithContext(ctx).Model(article).
+		Where("version = ?", expected).
+		Select("title", "slug", "content", "content_format", "content_html", "title_normalized", "content_normalized",
+			"word_count", "status", "publish_at", "published_at", "updated_at", "version").
+		Updates(article)
 	if result.Error != nil {
+		article.Version = expected
+		// المعرف النصي فريد، وقد يسبق إليه طلب متزامن بين اختياره وحفظه
+		if err := constraintError(result.Error, apperr.Conflict("article_duplicate")); err != nil {
+			return err
+		}
 		// إرجاع الخطأ مع رسالة توضيحية
 		return fmt.Errorf("فشل تحديث المقال: %w", result.Error)
 	}
 	if result.RowsAffected == 0 {
-		// للتحقق مما إذا كان السجل موجوداً وتم تحديثه فعلاً
-		return gorm.ErrRecordNotFound
+		article.Version = expected
+		err := missedUpdateError(r.db.WithContext(ctx).Model(&models.Article{}).Where("id = ?", article.ID))
+		return notFoundIf(err, "article_not_found", article.ID)
 	}
 	return nil
 }
 
 // Delete يقوم بحذف مقال من قاعدة البيانات باستخدام ID
 // تُستدعى هذه الدالة من طبقة منطق العمل (UseCase) عندما يُطلب حذف مقال
-func (r *articleRepository) Delete(id uint) error {
+// الحذف منطقي (يملأ deleted_at) فينتقل المقال إلى سلة المحذوفات، والحذف النهائي عبر Purge
+func (r *articleRepository) Delete(ctx context.Context, id uint) error {
 	// استخدام GORM لحذف سجل من جدول articles
 	// يطابق ID المعطى.
-	result := r.db.Delete(&models.Article{}, id)
+	result := r.db.WithContext(ctx).Delete(&models.Article{}, id)
 	if result.Error != nil {
 		// إرجاع الخطأ مع رسالة توضيحية
 		return fmt.Errorf("فشل حذف المقال: %w", result.Error)
 	}
 	if result.RowsAffected == 0 {
 		// إذا لم يتأثر أي صف، فالمقال غير موجود
-		return gorm.ErrRecordNotFound
+		return apperr.NotFound("article_not_found", id)
 	}
 	return nil
 }
+
+// CountByAuthor يعيد عدد مقالات المؤلف بما فيها الموجودة في سلة المحذوفات،
+// فلا يُحذف مؤلف بسياسة block وله مقالات قابلة للاستعادة
+func (r *articleRepository) CountByAuthor(ctx context.Context, authorID uint) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Unscoped().Model(&models.Article{}).Where("author_id = ?", authorID).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("فشل حساب مقالات المؤلف: %w", err)
+	}
+	return count, nil
+}
+
+// DeleteByAuthor ينقل جميع مقالات المؤلف إلى سلة المحذوفات ويعيد عددها
+func (r *articleRepository) DeleteByAuthor(ctx context.Context, authorID uint) (int64, error) {
+	result := r.db.WithContext(ctx).Where("author_id = ?", authorID).Delete(&models.Article{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("فشل حذف مقالات المؤلف: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// ReassignAuthor ينقل جميع مقالات المؤلف (بما فيها الموجودة في سلة المحذوفات) إلى مؤلف آخر
+// ويزيد رقم نسخة كل مقال حتى تفشل التحديثات المتزامنة المبنية على النسخة السابقة
+func (r *articleRepository) ReassignAuthor(ctx context.Context, fromID, toID uint) (int64, error) {
+	result := r.db.WithContext(ctx).Unscoped().Model(&models.Article{}).
+		Where("author_id = ?", fromID).
+		Updates(map[string]any{"author_id": toID, "version": gorm.Expr("version + 1"), "updated_at": time.Now()})
+	if result.Error != nil {
+		return 0, fmt.Errorf("فشل نقل مقالات المؤلف: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// PublishDue ينشر المقالات المجدولة التي حان موعدها ويعيد عددها
+// التحديث الشرطي الواحد (WHERE status = 'scheduled') يجعل النشر مرة واحدة فقط حتى مع تشغيل المهمة
+// في عدة تطبيقات معًا: الصف الذي نشره تطبيق آخر لم يعد مجدولًا فلا يطابق الشرط
+// عبارة SET مبنية صراحةً لا من map (الذي يرتب GORM مفاتيحه أبجديًا): MySQL ينفذ الإسنادات بالترتيب
+// ويقرأ فيها القيم الجديدة، فيجب نسخ publish_at إلى published_at قبل تفريغه
+func (r *articleRepository) PublishDue(ctx context.Context, now time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&models.Article{}).
+		Where("status = ? AND publish_at <= ?", models.ArticleStatusScheduled, now).
+		Clauses(clause.Set{
+			{Column: clause.Column{Name: "status"}, Value: models.ArticleStatusPublished},
+			{Column: clause.Column{Name: "published_at"}, Value: clause.Column{Name: "publish_at"}},
+			{Column: clause.Column{Name: "publish_at"}, Value: nil},
+			{Column: clause.Column{Name: "version"}, Value: gorm.Expr("version + 1")},
+			{Column: clause.Column{Name: "updated_at"}, Value: now},
+		}).
+		Updates(map[string]any{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("فشل نشر المقالات المجدولة: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
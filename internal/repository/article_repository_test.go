@@ -0,0 +1,195 @@
+// my-article-app/internal/repository/article_repository_test.go
+package repository
+
+import (
+	"context"
+	"errors"
+	"my-article-app/internal/apperr"
+	"my-article-app/internal/models"
+	"my-article-app/internal/pagination"
+	"testing"
+)
+
+func TestArticleRepositoryCRUD(t *testing.T) {
+	ctx := context.Background()
+	repos := NewRepositories(openTestDB(t))
+	author := createAuthor(t, repos, "crud-author")
+
+	article := &models.Article{
+		Title:    "مقدمة في البرمجة",
+		Slug:     "mqdma-fy-albrmja",
+		Content:  "هذا محتوى المقال الأول",
+		AuthorID: author.ID,
+	}
+	if err := repos.Articles.Create(ctx, article); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if article.ID == 0 || article.Version != 1 || article.Status != models.ArticleStatusDraft {
+		t.Fatalf("after Create: id=%d version=%d status=%q, want id>0 version=1 status=draft", article.ID, article.Version, article.Status)
+	}
+
+	found, err := repos.Articles.FindByID(ctx, article.ID)
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if found.Title != article.Title || found.Author.ID != author.ID {
+		t.Errorf("FindByID = %q by author %d, want %q by %d", found.Title, found.Author.ID, article.Title, author.ID)
+	}
+	if found.WordCount != 4 || found.TitleNormalized == "" || found.ContentHTML == "" {
+		t.Errorf("derived fields not filled: word_count=%d title_normalized=%q content_html=%q",
+			found.WordCount, found.TitleNormalized, found.ContentHTML)
+	}
+
+	found.Content = "محتوى معدّل"
+	if err := repos.Articles.Update(ctx, found); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if found.Version != 2 {
+		t.Errorf("Version after Update = %d, want 2", found.Version)
+	}
+
+	// تحديث بنسخة قديمة يُرفض ولا يكتب فوق التعديل السابق
+	stale := *found
+	stale.Version = 1
+	stale.Content = "تعديل متأخر"
+	if err := repos.Articles.Update(ctx, &stale); !errors.Is(err, ErrVersionConflict) {
+		t.Fatalf("Update with stale version: err = %v, want ErrVersionConflict", err)
+	}
+	if current, _ := repos.Articles.FindByID(ctx, article.ID); current.Content != "محتوى معدّل" {
+		t.Errorf("content after rejected update = %q", current.Content)
+	}
+
+	if err := repos.Articles.Delete(ctx, article.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := repos.Articles.FindByID(ctx, article.ID); !apperr.Is(err, apperr.KindNotFound) {
+		t.Fatalf("FindByID after Delete: err = %v, want not found", err)
+	}
+	trashed, err := repos.Articles.FindTrashedByID(ctx, article.ID)
+	if err != nil {
+		t.Fatalf("FindTrashedByID: %v", err)
+	}
+	if err := repos.Articles.Restore(ctx, trashed); err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	if _, err := repos.Articles.FindByID(ctx, article.ID); err != nil {
+		t.Fatalf("FindByID after Restore: %v", err)
+	}
+
+	if err := repos.Articles.Purge(ctx, article.ID); err != nil {
+		t.Fatalf("Purge: %v", err)
+	}
+	if _, err := repos.Articles.FindTrashedByID(ctx, article.ID); !apperr.Is(err, apperr.KindNotFound) {
+		t.Fatalf("FindTrashedByID after Purge: err = %v, want not found", err)
+	}
+	if err := repos.Articles.Delete(ctx, article.ID); !apperr.Is(err, apperr.KindNotFound) {
+		t.Fatalf("Delete of purged article: err = %v, want not found", err)
+	}
+}
+
+func TestArticleRepositoryPagination(t *testing.T) {
+	ctx := context.Background()
+	repos := NewRepositories(openTestDB(t))
+	author := createAuthor(t, repos, "paging-author")
+	var ids []uint
+	for n := 1; n <= 5; n++ {
+		ids = append(ids, createArticle(t, repos, author.ID, n).ID)
+	}
+
+	t.Run("offset", func(t *testing.T) {
+		page, err := repos.Articles.FindAll(ctx, ArticleFilter{}, pagination.Request{Limit: 2, Offset: 2})
+		if err != nil {
+			t.Fatalf("FindAll: %v", err)
+		}
+		if page.Total != 5 || !page.HasMore || len(page.Items) != 2 {
+			t.Fatalf("page total=%d has_more=%v items=%d, want 5 true 2", page.Total, page.HasMore, len(page.Items))
+		}
+		if page.Items[0].ID != ids[2] || page.Items[1].ID != ids[3] {
+			t.Errorf("page ids = %d,%d, want %d,%d", page.Items[0].ID, page.Items[1].ID, ids[2], ids[3])
+		}
+
+		last, err := repos.Articles.FindAll(ctx, ArticleFilter{}, pagination.Request{Limit: 2, Offset: 4})
+		if err != nil {
+			t.Fatalf("FindAll last page: %v", err)
+		}
+		if last.HasMore || len(last.Items) != 1 {
+			t.Errorf("last page has_more=%v items=%d, want false 1", last.HasMore, len(last.Items))
+		}
+	})
+
+	t.Run("cursor", func(t *testing.T) {
+		var seen []uint
+		req := pagination.Request{Limit: 2}
+		for range 10 {
+			page, err := repos.Articles.FindAll(ctx, ArticleFilter{}, req)
+			if err != nil {
+				t.Fatalf("FindAll: %v", err)
+			}
+			for _, a := range page.Items {
+				seen = append(seen, a.ID)
+			}
+			if page.NextCursor == "" {
+				break
+			}
+			req.Cursor = page.NextCursor
+		}
+		if len(seen) != len(ids) {
+			t.Fatalf("cursor walk visited %v, want %v", seen, ids)
+		}
+		for i := range ids {
+			if seen[i] != ids[i] {
+				t.Fatalf("cursor walk visited %v, want %v", seen, ids)
+			}
+		}
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		for _, req := range []pagination.Request{
+			{Offset: -1},
+			{Cursor: "not-a-cursor"},
+			{Cursor: pagination.EncodeCursor(1), Offset: 1},
+		} {
+			if _, err := repos.Articles.FindAll(ctx, ArticleFilter{}, req); !apperr.Is(err, apperr.KindValidation) {
+				t.Errorf("FindAll(%+v): err = %v, want validation error", req, err)
+			}
+		}
+	})
+}
+
+func TestArticleRepositoryUniqueness(t *testing.T) {
+	ctx := context.Background()
+	repos := NewRepositories(openTestDB(t))
+	author := createAuthor(t, repos, "unique-author")
+	article := createArticle(t, repos, author.ID, 1)
+
+	tests := []struct {
+		title     string
+		excludeID uint
+		want      bool
+	}{
+		{"Article number 1", 0, true},
+		{"ARTICLE   number 1", 0, true},
+		{"Article number 1", article.ID, false},
+		{"Article number 2", 0, false},
+	}
+	for _, tt := range tests {
+		got, err := repos.Articles.ExistsByTitle(ctx, tt.title, tt.excludeID)
+		if err != nil {
+			t.Fatalf("ExistsByTitle(%q): %v", tt.title, err)
+		}
+		if got != tt.want {
+			t.Errorf("ExistsByTitle(%q, %d) = %v, want %v", tt.title, tt.excludeID, got, tt.want)
+		}
+	}
+
+	// المعرف النصي فريد على مستوى قاعدة البيانات، وتكراره تعارض لا خطأ داخلي
+	duplicate := &models.Article{Title: "Another title", Slug: article.Slug, Content: "different content", AuthorID: author.ID}
+	if err := repos.Articles.Create(ctx, duplicate); !errors.Is(err, apperr.Conflict("article_duplicate")) {
+		t.Fatalf("Create with taken slug: err = %v, want article_duplicate", err)
+	}
+
+	other := &models.Author{Name: "other", Email: author.Email, Slug: "other"}
+	if err := repos.Authors.Create(ctx, other); !apperr.Is(err, apperr.KindConflict) {
+		t.Fatalf("Create author with taken email: err = %v, want conflict", err)
+	}
+}
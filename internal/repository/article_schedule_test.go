@@ -0,0 +1,102 @@
+// my-article-app/internal/repository/article_schedule_test.go
+package repository
+
+import (
+	"context"
+	"my-article-app/internal/models"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func TestPublishDue(t *testing.T) {
+	forEachStore(t, func(t *testing.T, repos Repositories) {
+		ctx := context.Background()
+		author := createAuthor(t, repos, "scheduler")
+		now := time.Now().UTC().Truncate(time.Second)
+		due, later := now.Add(-time.Minute), now.Add(time.Hour)
+
+		schedule := func(slug string, publishAt time.Time) *models.Article {
+			t.Helper()
+			article := &models.Article{Title: slug, Slug: slug, Content: "scheduled content", AuthorID: author.ID,
+				Status: models.ArticleStatusScheduled, PublishAt: &publishAt}
+			if err := repos.Articles.Create(ctx, article); err != nil {
+				t.Fatalf("create %s: %v", slug, err)
+			}
+			return article
+		}
+		ready := schedule("ready", due)
+		waiting := schedule("waiting", later)
+		trashed := schedule("trashed", due)
+		if err := repos.Articles.Delete(ctx, trashed.ID); err != nil {
+			t.Fatalf("delete: %v", err)
+		}
+
+		// عدة مشغّلات متزامنة تنشر المقال المستحق مرة واحدة فقط
+		var wg sync.WaitGroup
+		counts := make([]int64, 4)
+		errs := make([]error, len(counts))
+		for i := range counts {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				counts[i], errs[i] = repos.Articles.PublishDue(ctx, now)
+			}()
+		}
+		wg.Wait()
+		var total int64
+		for i := range counts {
+			if errs[i] != nil {
+				t.Fatalf("PublishDue: %v", errs[i])
+			}
+			total += counts[i]
+		}
+		if total != 1 {
+			t.Fatalf("PublishDue published %d articles in total, want 1", total)
+		}
+
+		got, err := repos.Articles.FindByID(ctx, ready.ID)
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		if got.Status != models.ArticleStatusPublished || got.PublishAt != nil || got.Version != ready.Version+1 {
+			t.Errorf("published article = status %q publish_at %v version %d; want published, nil, %d",
+				got.Status, got.PublishAt, got.Version, ready.Version+1)
+		}
+		if got.PublishedAt == nil || !got.PublishedAt.Equal(due) {
+			t.Errorf("published_at = %v, want the scheduled time %v", got.PublishedAt, due)
+		}
+
+		if got, err := repos.Articles.FindByID(ctx, waiting.ID); err != nil || got.Status != models.ArticleStatusScheduled || got.PublishedAt != nil {
+			t.Errorf("future article = %+v, %v; want it still scheduled", got, err)
+		}
+		if got, err := repos.Articles.FindTrashedByID(ctx, trashed.ID); err != nil || got.Status != models.ArticleStatusScheduled {
+			t.Errorf("trashed article = %+v, %v; want it left scheduled", got, err)
+		}
+	})
+}
+
+// TestPublishDueSetOrder يتحقق من ترتيب عبارة SET: في MySQL تُقرأ القيم الجديدة داخل الإسنادات نفسها،
+// فلو فُرّغ publish_at أولًا لأصبح published_at فارغًا، وهو ما لا يظهر في SQLite ولا PostgreSQL
+func TestPublishDueSetOrder(t *testing.T) {
+	db := openTestDB(t)
+	var statement string
+	err := db.Callback().Update().After("gorm:update").Register("test:capture_sql", func(tx *gorm.DB) {
+		statement = tx.Statement.SQL.String()
+	})
+	if err != nil {
+		t.Fatalf("register callback: %v", err)
+	}
+
+	if _, err := NewArticleRepository(db).PublishDue(context.Background(), time.Now()); err != nil {
+		t.Fatalf("PublishDue: %v", err)
+	}
+	copyAt := strings.Index(statement, "`published_at`=`publish_at`")
+	clearAt := strings.Index(statement, ",`publish_at`=")
+	if copyAt < 0 || clearAt < 0 || copyAt > clearAt {
+		t.Errorf("PublishDue SQL = %s, want published_at copied before publish_at is cleared", statement)
+	}
+}
@@ -0,0 +1,241 @@
+// my-article-app/internal/repository/article_search.go
+package repository
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"my-article-app/internal/apperr"
+	"my-article-app/internal/models"
+	"my-article-app/internal/pagination"
+	"my-article-app/internal/textnorm"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// علامات تمييز الكلمات المطابقة في العناوين والمقتطفات
+const (
+	highlightStart = "<mark>"
+	highlightStop  = "</mark>"
+)
+
+// عدد الكلمات المحيطة بأول تطابق في مقتطف البحث البديل
+const snippetWords = 15
+
+// ErrCursorWithSearch يُرجع عند طلب ترقيم بالمؤشر في البحث، لأن النتائج مرتبة حسب الصلة
+var ErrCursorWithSearch = apperr.Validation("cursor_with_search")
+
+// ArticleSearchResult مقال مطابق لعبارة البحث مع درجة الصلة والمقتطفات المميزة
+type ArticleSearchResult struct {
+	models.Article
+	// Rank درجة الصلة؛ الاسم البديل search_rank لأن rank كلمة محجوزة في MySQL 8
+	Rank           float64 `gorm:"column:search_rank"`
+	TitleHighlight string
+	Snippet        string
+}
+
+// Search يبحث في عناوين المقالات المنشورة ومحتواها ويعيد صفحة مرتبة حسب الصلة
+// على PostgreSQL يُستخدم البحث النصي الكامل (tsvector + GIN)، وعلى غيرها بحث LIKE بديل
+func (r *articleRepository) Search(ctx context.Context, query string, req pagination.Request) (*pagination.Page[ArticleSearchResult], error) {
+	req, err := req.Normalize()
+	if err != nil {
+		return nil, err
+	}
+	if req.IsKeyset() {
+		return nil, ErrCursorWithSearch
+	}
+
+	var page *pagination.Page[ArticleSearchResult]
+	if r.db.Dialector.Name() == "postgres" {
+		page, err = r.searchFullText(ctx, query, req)
+	} else {
+		page, err = r.searchLike(ctx, query, req)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("فشل البحث في المقالات: %w", err)
+	}
+
+	if err := r.attachAuthors(ctx, page.Items); err != nil {
+		return nil, err
+	}
+	if err := r.attachTaxonomy(ctx, page.Items); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// searchFullText يستخدم websearch_to_tsquery و ts_rank و ts_headline على عمود search_vector
+// النص يُهرَّب قبل ts_headline لأنها تعيد المستند كما هو، فلا يبقى فيه وسم غير علامات التمييز
+func (r *articleRepository) searchFullText(ctx context.Context, query string, req pagination.Request) (*pagination.Page[ArticleSearchResult], error) {
+	base := r.db.WithContext(ctx).Table("articles").
+		Joins("CROSS JOIN websearch_to_tsquery('simple', ?) AS q", textnorm.Normalize(query)).
+		Where("articles.search_vector @@ q").
+		Where("articles.deleted_at IS NULL").
+		Where("articles.status = ?", models.ArticleStatusPublished)
+
+	var total int64
+	if err := base.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	headline := fmt.Sprintf("StartSel=%s, StopSel=%s", highlightStart, highlightStop)
+	var items []ArticleSearchResult
+	err := base.Session(&gorm.Session{}).
+		Select("articles.*, "+
+			"ts_rank(articles.search_vector, q) AS search_rank, "+
+			"ts_headline('simple', "+escapeHTMLColumn("articles.title")+", q, ?) AS title_highlight, "+
+			"ts_headline('simple', "+escapeHTMLColumn("articles.content")+", q, ?) AS snippet",
+			headline+", HighlightAll=true",
+			headline+", MaxFragments=2, MaxWords=30, MinWords=10").
+		Order("search_rank DESC").Order("articles.id ASC").
+		Limit(req.Limit + 1).Offset(req.Offset).
+		Scan(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	return searchPage(items, total, req), nil
+}
+
+// escapeHTMLColumn يعيد تعبير SQL يهرّب عمودًا نصيًا كما يفعل html.EscapeString
+func escapeHTMLColumn(column string) string {
+	expr := "coalesce(" + column + ", '')"
+	for _, r := range [][2]string{{"&", "&amp;"}, {"<", "&lt;"}, {">", "&gt;"}, {`"`, "&#34;"}, {"''", "&#39;"}} {
+		expr = fmt.Sprintf("replace(%s, '%s', '%s')", expr, r[0], r[1])
+	}
+	return expr
+}
+
+// searchLike بحث بديل لقواعد البيانات التي لا تدعم tsvector، يعطي تطابق العنوان وزنًا أعلى
+func (r *articleRepository) searchLike(ctx context.Context, query string, req pagination.Request) (*pagination.Page[ArticleSearchResult], error) {
+	pattern := "%" + escapeLike(textnorm.Normalize(query)) + "%"
+	base := r.db.WithContext(ctx).Table("articles").
+		Where("articles.title_normalized LIKE ? ESCAPE '!' OR articles.content_normalized LIKE ? ESCAPE '!'", pattern, pattern).
+		Where("articles.deleted_at IS NULL").
+		Where("articles.status = ?", models.ArticleStatusPublished)
+
+	var total int64
+	if err := base.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	var items []ArticleSearchResult
+	err := base.Session(&gorm.Session{}).
+		Select("articles.*, "+
+			"(CASE WHEN articles.title_normalized LIKE ? ESCAPE '!' THEN 2 ELSE 0 END + "+
+			"CASE WHEN articles.content_normalized LIKE ? ESCAPE '!' THEN 1 ELSE 0 END) AS search_rank",
+			pattern, pattern).
+		Order("search_rank DESC").Order("articles.id ASC").
+		Limit(req.Limit + 1).Offset(req.Offset).
+		Scan(&items).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range items {
+		items[i].TitleHighlight = highlight(items[i].Title, query)
+		items[i].Snippet = snippet(articleText(&items[i].Article), query)
+	}
+	return searchPage(items, total, req), nil
+}
+
+// searchPage يقص السجل الإضافي ويبني صفحة نتائج البحث
+func searchPage(items []ArticleSearchResult, total int64, req pagination.Request) *pagination.Page[ArticleSearchResult] {
+	page := &pagination.Page[ArticleSearchResult]{Total: total, Limit: req.Limit, Offset: req.Offset}
+	if len(items) > req.Limit {
+		items = items[:req.Limit]
+		page.HasMore = true
+	}
+	page.Items = items
+	return page
+}
+
+// attachAuthors يحمّل مؤلفي نتائج البحث باستعلام واحد، لأن Preload لا يعمل مع Scan
+func (r *articleRepository) attachAuthors(ctx context.Context, items []ArticleSearchResult) error {
+	if len(items) == 0 {
+		return nil
+	}
+	ids := make([]uint, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.AuthorID)
+	}
+
+	var authors []models.Author
+	if err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&authors).Error; err != nil {
+		return fmt.Errorf("فشل جلب مؤلفي نتائج البحث: %w", err)
+	}
+	byID := make(map[uint]models.Author, len(authors))
+	for _, author := range authors {
+		byID[author.ID] = author
+	}
+	for i := range items {
+		items[i].Author = byID[items[i].AuthorID]
+	}
+	return nil
+}
+
+// attachTaxonomy يحمّل وسوم نتائج البحث وتصنيفاتها باستعلام على معرفات الصفحة مع Preload
+func (r *articleRepository) attachTaxonomy(ctx context.Context, items []ArticleSearchResult) error {
+	if len(items) == 0 {
+		return nil
+	}
+	ids := make([]uint, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.ID)
+	}
+
+	var articles []models.Article
+	err := r.db.WithContext(ctx).Select("id").Preload("Tags").Preload("Categories").Find(&articles, ids).Error
+	if err != nil {
+		return fmt.Errorf("فشل جلب وسوم نتائج البحث وتصنيفاتها: %w", err)
+	}
+	byID := make(map[uint]models.Article, len(articles))
+	for _, article := range articles {
+		byID[article.ID] = article
+	}
+	for i := range items {
+		items[i].Tags = byID[items[i].ID].Tags
+		items[i].Categories = byID[items[i].ID].Categories
+	}
+	return nil
+}
+
+// highlight يحيط كل تطابق بعلامات التمييز؛ المطابقة تتم بعد التوحيد مع الحفاظ على النص الأصلي بتشكيله
+// كل مقطع من النص يُهرَّب، فالناتج HTML لا يحوي وسمًا غير علامات التمييز
+func highlight(text, query string) string {
+	var b strings.Builder
+	last := 0
+	for _, span := range textnorm.FindAll(text, query) {
+		b.WriteString(html.EscapeString(text[last:span.Start]))
+		b.WriteString(highlightStart)
+		b.WriteString(html.EscapeString(text[span.Start:span.End]))
+		b.WriteString(highlightStop)
+		last = span.End
+	}
+	b.WriteString(html.EscapeString(text[last:]))
+	return b.String()
+}
+
+// snippet يعيد مقتطفًا من المحتوى حول أول تطابق مع تمييز الكلمات المطابقة
+func snippet(content, query string) string {
+	words := strings.Fields(content)
+
+	first := 0
+	for i, w := range words {
+		if len(textnorm.FindAll(w, query)) > 0 {
+			first = i
+			break
+		}
+	}
+	start := max(first-snippetWords/2, 0)
+	end := min(start+snippetWords, len(words))
+
+	out := strings.Join(words[start:end], " ")
+	if start > 0 {
+		out = "… " + out
+	}
+	if end < len(words) {
+		out += " …"
+	}
+	return highlight(out, query)
+}
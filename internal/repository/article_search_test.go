@@ -0,0 +1,129 @@
+// my-article-app/internal/repository/article_search_test.go
+package repository
+
+import (
+	"context"
+	"my-article-app/internal/models"
+	"my-article-app/internal/pagination"
+	"my-article-app/internal/richtext"
+	"strings"
+	"testing"
+)
+
+func TestHighlight(t *testing.T) {
+	tests := []struct {
+		name, text, query, want string
+	}{
+		{"no match", "plain text", "missing", "plain text"},
+		{"match", "Go is fun", "fun", "Go is <mark>fun</mark>"},
+		{"normalized match keeps tashkeel", "مُقَدِّمَة قصيرة", "مقدمه", "<mark>مُقَدِّمَة</mark> قصيرة"},
+		{"escapes text without match", `<img src=x onerror="alert(1)">`, "missing", "&lt;img src=x onerror=&#34;alert(1)&#34;&gt;"},
+		{"escapes around match", "<script>alert('guide')</script> guide", "guide",
+			"&lt;script&gt;alert(&#39;<mark>guide</mark>&#39;)&lt;/script&gt; <mark>guide</mark>"},
+		{"escapes inside match", "a&b", "a&b", "<mark>a&amp;b</mark>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := highlight(tt.text, tt.query); got != tt.want {
+				t.Errorf("highlight(%q, %q) = %q, want %q", tt.text, tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSnippetEscapesContent(t *testing.T) {
+	content := "intro <img src=x onerror=alert(1)> then the keyword appears"
+	got := snippet(content, "keyword")
+	if strings.Contains(got, "<img") {
+		t.Fatalf("snippet kept a raw tag: %q", got)
+	}
+	if !strings.Contains(got, "&lt;img") || !strings.Contains(got, "<mark>keyword</mark>") {
+		t.Errorf("snippet = %q, want escaped tag and highlighted keyword", got)
+	}
+}
+
+// TestSearchEscapesStoredMarkup يتحقق أن العنوان والمحتوى المخزنين بوسوم HTML لا يعودان من البحث وسومًا فعلية
+func TestSearchEscapesStoredMarkup(t *testing.T) {
+	forEachStore(t, func(t *testing.T, repos Repositories) {
+		ctx := context.Background()
+		author := createAuthor(t, repos, "search-author")
+		article := &models.Article{
+			Title:    `<img src=x onerror=alert(1)> payload guide`,
+			Slug:     "payload-guide",
+			Content:  `<script>alert("x")</script> the payload body`,
+			AuthorID: author.ID,
+			Status:   models.ArticleStatusPublished,
+		}
+		if err := repos.Articles.Create(ctx, article); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+
+		page, err := repos.Articles.Search(ctx, "payload", pagination.Request{})
+		if err != nil {
+			t.Fatalf("Search: %v", err)
+		}
+		if len(page.Items) != 1 {
+			t.Fatalf("Search returned %d items, want 1", len(page.Items))
+		}
+		result := page.Items[0]
+		if want := "&lt;img src=x onerror=alert(1)&gt; <mark>payload</mark> guide"; result.TitleHighlight != want {
+			t.Errorf("TitleHighlight = %q, want %q", result.TitleHighlight, want)
+		}
+		if strings.Contains(result.Snippet, "<script") || !strings.Contains(result.Snippet, "<mark>payload</mark>") {
+			t.Errorf("Snippet = %q, want escaped markup and highlighted match", result.Snippet)
+		}
+	})
+}
+
+func TestEscapeHTMLColumn(t *testing.T) {
+	got := escapeHTMLColumn("articles.title")
+	// & يُستبدل أولًا حتى لا تُهرَّب الكيانات الناتجة مرة ثانية
+	want := "replace(replace(replace(replace(replace(coalesce(articles.title, ''), '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '\"', '&#34;'), '''', '&#39;')"
+	if got != want {
+		t.Errorf("escapeHTMLColumn = %s, want %s", got, want)
+	}
+}
+
+// TestSearchSnippetEscapesDecodedEntities يتحقق أن الكيانات التي يفكها استخراج النص من HTML لا تعود وسومًا في المقتطف
+func TestSearchSnippetEscapesDecodedEntities(t *testing.T) {
+	tests := []struct {
+		format, content string
+	}{
+		{richtext.FormatPlain, "keyword <img src=x onerror=alert(1)>"},
+		{richtext.FormatMarkdown, "keyword `<img src=x onerror=alert(1)>` and &lt;script&gt;alert(1)&lt;/script&gt;"},
+		{richtext.FormatHTML, "<p>keyword &lt;img src=x onerror=alert(1)&gt;</p><script>alert(1)</script>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			ctx := context.Background()
+			repos := NewRepositories(openTestDB(t))
+			author := createAuthor(t, repos, "entity-author")
+			article := &models.Article{
+				Title:         "Entity article",
+				Slug:          "entity-article",
+				Content:       tt.content,
+				ContentFormat: tt.format,
+				AuthorID:      author.ID,
+				Status:        models.ArticleStatusPublished,
+			}
+			if err := repos.Articles.Create(ctx, article); err != nil {
+				t.Fatalf("Create: %v", err)
+			}
+
+			page, err := repos.Articles.Search(ctx, "keyword", pagination.Request{})
+			if err != nil {
+				t.Fatalf("Search: %v", err)
+			}
+			if len(page.Items) != 1 {
+				t.Fatalf("Search returned %d items, want 1", len(page.Items))
+			}
+			got := page.Items[0].Snippet
+			if strings.Contains(got, "<img") || strings.Contains(got, "<script") {
+				t.Errorf("Snippet = %q, want no raw tags", got)
+			}
+			if !strings.Contains(got, "<mark>keyword</mark>") || !strings.Contains(got, "&lt;img") {
+				t.Errorf("Snippet = %q, want highlighted keyword and escaped tag text", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+// my-article-app/internal/repository/article_trash.go
+package repository
+
+import (
+	"context"
+	"fmt"
+	"my-article-app/internal/apperr"
+	"my-article-app/internal/models"
+	"my-article-app/internal/pagination"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// trashed يعيد استعلامًا على المقالات المحذوفة منطقيًا فقط
+func (r *articleRepository) trashed(ctx context.Context) *gorm.DB {
+	return r.db.WithContext(ctx).Unscoped().Model(&models.Article{}).Where("articles.deleted_at IS NOT NULL")
+}
+
+// FindTrash يجلب صفحة من المقالات الموجودة في سلة المحذوفات
+func (r *articleRepository) FindTrash(ctx context.Context, req pagination.Request) (*pagination.Page[models.Article], error) {
+	page, err := findPage(r.trashed(ctx), req, pageSpec[models.Article]{
+		idColumn: "articles.id",
+		idOf:     func(a *models.Article) uint { return a.ID },
+		preloads: articlePreloads,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("فشل جلب سلة المحذوفات: %w", err)
+	}
+	return page, nil
+}
+
+// FindTrashedByID يجلب مقالاً محذوفًا منطقيًا، ويعيد gorm.ErrRecordNotFound إذا لم يكن في السلة
+func (r *articleRepository) FindTrashedByID(ctx context.Context, id uint) (*models.Article, error) {
+	var article models.Article
+	if err := preloadArticle(r.trashed(ctx)).First(&article, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, notFoundIf(err, "article_not_in_trash", id)
+		}
+		return nil, fmt.Errorf("فشل جلب المقال المحذوف بالمعرف %d: %w", id, err)
+	}
+	return &article, nil
+}
+
+// Restore يعيد مقالاً من سلة المحذوفات، مشروطًا برقم النسخة الذي قُرئ به كما في Update
+func (r *articleRepository) Restore(ctx context.Context, article *models.Article) error {
+	now := time.Now()
+	result := r.trashed(ctx).
+		Where("articles.id = ? AND articles.version = ?", article.ID, article.Version).
+		Updates(map[string]any{"deleted_at": nil, "version": article.Version + 1, "updated_at": now})
+	if result.Error != nil {
+		return fmt.Errorf("فشل استعادة المقال: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		err := missedUpdateError(r.trashed(ctx).Where("articles.id = ?", article.ID))
+		return notFoundIf(err, "article_not_in_trash", article.ID)
+	}
+	article.Version++
+	article.UpdatedAt = now
+	article.DeletedAt = gorm.DeletedAt{}
+	return nil
+}
+
+// Purge يحذف المقال نهائيًا من قاعدة البيانات سواء كان في السلة أم لا
+func (r *articleRepository) Purge(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Unscoped().Delete(&models.Article{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("فشل الحذف النهائي للمقال: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return apperr.NotFound("article_not_found", id)
+	}
+	return nil
+}
+
+// PurgeDeletedBefore يحذف نهائيًا المقالات التي حُذفت منطقيًا قبل cutoff ويعيد عددها
+func (r *articleRepository) PurgeDeletedBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).
+		Delete(&models.Article{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("فشل تنظيف سلة المحذوفات: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
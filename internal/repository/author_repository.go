@@ -3,19 +3,37 @@ package repository
 
 // استيراد المكتبات اللازمة للعمل مع قاعدة البيانات
 import (
-	"fmt"                        // مكتبة لتنسيق النصوص ورسائل الخطأ
-	"my-article-app/internal/models" // استيراد نماذج البيانات (مثل Author)
+	"context"                            // مكتبة لتمرير السياق (الإلغاء والمهلات) إلى قاعدة البيانات
+	"fmt"                                // مكتبة لتنسيق النصوص ورسائل الخطأ
+	"my-article-app/internal/apperr"     // الأخطاء المصنّفة (NotFound و Conflict وغيرها)
+	"my-article-app/internal/models"     // استيراد نماذج البيانات (مثل Author)
+	"my-article-app/internal/pagination" // معاملات الترقيم ونتائج الصفحات
+	"my-article-app/internal/textnorm"   // توحيد النصوص العربية للبحث والمقارنة
 
-	"gorm.io/gorm"               // مكتبة GORM للتعامل مع قواعد البيانات
+	"gorm.io/gorm"        // مكتبة GORM للتعامل مع قواعد البيانات
+	"gorm.io/gorm/clause" // بنود SQL الإضافية مثل أقفال الصفوف
 )
 
 type AuthorRepository interface {
+	Create(ctx context.Context, author *models.Author) error
+	FindAll(ctx context.Context, filter AuthorFilter, req pagination.Request) (*pagination.Page[models.Author], error)
+	FindAllWithStats(ctx context.Context, filter AuthorFilter, req pagination.Request) (*pagination.Page[AuthorWithStats], error)
+	FindByID(ctx context.Context, id uint) (*models.Author, error)
+	FindBySlug(ctx context.Context, slug string) (*models.Author, error)
+	TakenSlugs(ctx context.Context, bases []string) (map[string]uint, error)
+	FindByIDForShare(ctx context.Context, id uint) (*models.Author, error)
+	FindByIDForUpdate(ctx context.Context, id uint) (*models.Author, error)
+	FindByIDsForShare(ctx context.Context, ids []uint) (map[uint]models.Author, error)
+	Update(ctx context.Context, author *models.Author) error
+	Delete(ctx context.Context, id uint) error
+}
 
-	Create(author *models.Author) error
-	FindAll() ([]models.Author, error)
-	FindByID(id uint) (*models.Author, error)
-	Update(author *models.Author) error
-	Delete(id uint) error
+// AuthorFilter شروط تصفية قائمة المؤلفين (القيم الفارغة تعني عدم التصفية)
+type AuthorFilter struct {
+	// Name جزء من اسم المؤلف، يُقارن بعد التوحيد فيطابق "أحمد" و"احمد" و"أَحْمَد"
+	Name string
+	// Sort حقول الترتيب، ومنها حقول الإحصاءات (article_count و total_words وتاريخا النشر)
+	Sort []SortField
 }
 
 type authorRepository struct {
@@ -31,59 +49,117 @@ func NewAuthorRepository(db *gorm.DB) AuthorRepository {
 
 // Create ينشئ مؤلفًا جديدًا في قاعدة البيانات
 // هذه الدالة مسؤولة عن حفظ بيانات مؤلف جديد في قاعدة البيانات
-func (r *authorRepository) Create(author *models.Author) error {
+func (r *authorRepository) Create(ctx context.Context, author *models.Author) error {
+	// تحديث النسخة الموحدة من الاسم قبل الحفظ
+	normalizeAuthor(author)
+	if author.Version == 0 {
+		// نضبط النسخة صراحة لأن MySQL لا يعيد القيمة الافتراضية بعد الإدخال
+		author.Version = 1
+	}
+
+	// البريد والمعرف النصي فريدان، وتكرار أحدهما تعارض يُعرض للعميل وليس خطأً داخليًا
+	if err := r.uniqueConflict(ctx, author); err != nil {
+		return err
+	}
+
 	// استخدام GORM لإنشاء سجل جديد في قاعدة البيانات
 	// سيتم تعبئة حقل ID تلقائيًا بعد الإنشاء الناجح
-	result := r.db.Create(author)
+	result := r.db.WithContext(ctx).Create(author)
 
 	// التحقق من حدوث أي خطأ أثناء الإنشاء
 	if result.Error != nil {
+		// إدخال متزامن سبق الفحص أعلاه، والخطأ المترجَم لا يحدد القيد المنتهَك
+		if err := constraintError(result.Error, apperr.Conflict("author_duplicate")); err != nil {
+			return err
+		}
 		// إرجاع رسالة خطأ منسقة مع الخطأ الأصلي
 		return fmt.Errorf("فشل إنشاء المؤلف: %w", result.Error)
 	}
-	
+
 	// إرجاع nil في حالة نجاح العملية
 	return nil
 }
 
-// FindAll يجلب جميع المؤلفين من قاعدة البيانات
-// هذه الدالة مسؤولة عن استرجاع جميع سجلات المؤلفين من قاعدة البيانات
-func (r *authorRepository) FindAll() ([]models.Author, error) {
-	// إنشاء متغير من نوع مصفوفة لتخزين المؤلفين المسترجعين
-	var authors []models.Author
+// uniqueConflict يتحقق قبل الكتابة من أن بريد المؤلف ومعرفه النصي غير مستخدمين لمؤلف آخر (ومنهم المحذوفون)
+// TranslateError يعيد gorm.ErrDuplicatedKey بلا اسم القيد، فلا يُعرف منه أي العمودين تكرر
+func (r *authorRepository) uniqueConflict(ctx context.Context, author *models.Author) error {
+	var others []models.Author
+	err := r.db.WithContext(ctx).Unscoped().Model(&models.Author{}).
+		Select("email", "slug").
+		Where("id <> ? AND (email = ? OR slug = ?)", author.ID, author.Email, author.Slug).
+		Find(&others).Error
+	if err != nil {
+		return fmt.Errorf("فشل التحقق من تكرار بيانات المؤلف: %w", err)
+	}
+	for _, other := range others {
+		if other.Email == author.Email {
+			return apperr.Conflict("author_email_taken", author.Email)
+		}
+	}
+	if len(others) > 0 {
+		return apperr.Conflict("author_slug_taken", author.Slug)
+	}
+	return nil
+}
+
+// FindAll يجلب صفحة من المؤلفين من قاعدة البيانات
+// هذه الدالة مسؤولة عن استرجاع سجلات المؤلفين صفحةً صفحة بدلاً من تحميلها كلها في الذاكرة
+func (r *authorRepository) FindAll(ctx context.Context, filter AuthorFilter, req pagination.Request) (*pagination.Page[models.Author], error) {
+	// تحويل حقول الترتيب إلى أعمدة من القائمة المسموحة فقط
+	orders, err := orderClauses(filter.Sort, authorSortColumns)
+	if err != nil {
+		return nil, err
+	}
 
-	// يمكن استخدام Preload("Articles") هنا إذا أردت جلب المقالات المرتبطة بكل مؤلف
-	// مثال: r.db.Preload("Articles").Find(&authors)
+	query := r.authorQuery(ctx, filter)
+	if filter.needsStats() {
+		// الترتيب بالإحصاءات يتطلب ضمها حتى لو لم تُطلب في النتيجة
+		query = withArticleStats(query)
+	}
 
-	// استخدام GORM لجلب جميع سجلات المؤلفين
-	result := r.db.Find(&authors)
+	// يمكن تمرير "Articles" كعلاقة إضافية إذا أردت جلب المقالات المرتبطة بكل مؤلف
+	page, err := findPage(query, req, pageSpec[models.Author]{
+		idColumn: "authors.id",
+		idOf:     func(a *models.Author) uint { return a.ID },
+		orders:   orders,
+	})
 
 	// التحقق من حدوث أي خطأ أثناء الاستعلام
-	if result.Error != nil {
+	if err != nil {
 		// إرجاع nil ورسالة خطأ في حالة الفشل
-		return nil, fmt.Errorf("فشل جلب المؤلفين: %w", result.Error)
+		return nil, fmt.Errorf("فشل جلب المؤلفين: %w", err)
 	}
 
-	// إرجاع مصفوفة المؤلفين وnil للخطأ في حالة النجاح
-	return authors, nil
+	// إرجاع الصفحة وnil للخطأ في حالة النجاح
+	return page, nil
+}
+
+// authorQuery يبني استعلام المؤلفين غير المحذوفين مع شروط التصفية
+func (r *authorRepository) authorQuery(ctx context.Context, filter AuthorFilter) *gorm.DB {
+	query := r.db.WithContext(ctx).Model(&models.Author{})
+	if filter.Name != "" {
+		// البحث بالاسم يتم على العمود الموحد حتى لا يتأثر بالتشكيل أو أشكال الهمزات
+		query = query.Where("authors.name_normalized LIKE ? ESCAPE '!'", "%"+escapeLike(textnorm.Normalize(filter.Name))+"%")
+	}
+	return query
 }
 
 // FindByID يجلب مؤلفًا واحدًا حسب ID
 // هذه الدالة تُستخدم لاسترجاع مؤلف معين من قاعدة البيانات باستخدام معرفه الفريد
-func (r *authorRepository) FindByID(id uint) (*models.Author, error) {
+func (r *authorRepository) FindByID(ctx context.Context, id uint) (*models.Author, error) {
 	// إنشاء متغير من نوع Author لتخزين المؤلف المسترجع
 	var author models.Author
 
-	// استخدام Preload("Articles") لجلب المقالات المرتبطة بالمؤلف
-	// هذا يعني أننا سنجلب المؤلف مع جميع مقالاته في استعلام واحد
-	result := r.db.Preload("Articles").First(&author, id)
+	// استخدام Preload("Articles") لجلب المقالات المنشورة المرتبطة بالمؤلف
+	// المسودات والمجدولة والمؤرشفة لا تظهر في صفحة المؤلف العامة
+	result := preloadAuthorArticles(r.db.WithContext(ctx)).First(&author, id)
 
 	// التحقق من حدوث أي خطأ أثناء الاستعلام
 	if result.Error != nil {
 		// التحقق مما إذا كان الخطأ هو عدم العثور على السجل
 		if result.Error == gorm.ErrRecordNotFound {
-			// إرجاع nil, nil للإشارة إلى أنه لم يتم العثور على المؤلف
-			return nil, nil // لم يتم العثور على المؤلف
+			// إرجاع خطأ NotFound مصنّف للإشارة إلى أنه لم يتم العثور على المؤلف
+			return nil, notFoundIf(result.Error, "author_not_found", id)
 		}
 		// إرجاع nil ورسالة خطأ في حالة حدوث خطأ آخر
 		return nil, fmt.Errorf("فشل جلب المؤلف بالمعرف %d: %w", id, result.Error)
@@ -93,24 +169,128 @@ func (r *authorRepository) FindByID(id uint) (*models.Author, error) {
 	return &author, nil
 }
 
+// preloadAuthorArticles يحمّل مقالات المؤلف المنشورة مع وسومها وتصنيفاتها
+func preloadAuthorArticles(q *gorm.DB) *gorm.DB {
+	return q.Preload("Articles", "status = ?", models.ArticleStatusPublished).
+		Preload("Articles.Tags").Preload("Articles.Categories")
+}
+
+// FindBySlug يجلب مؤلفًا غير محذوف مع مقالاته المنشورة حسب معرفه النصي
+func (r *authorRepository) FindBySlug(ctx context.Context, slug string) (*models.Author, error) {
+	var author models.Author
+	result := preloadAuthorArticles(r.db.WithContext(ctx)).Where("slug = ?", slug).First(&author)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, notFoundIf(result.Error, "author_slug_not_found", slug)
+		}
+		return nil, fmt.Errorf("فشل جلب المؤلف بالمعرف النصي %q: %w", slug, result.Error)
+	}
+	return &author, nil
+}
+
+// TakenSlugs يعيد المعرفات النصية للمؤلفين (ومنهم المحذوفون) التي قد تكون من مرشحي bases، مع معرف صاحبها
+func (r *authorRepository) TakenSlugs(ctx context.Context, bases []string) (map[string]uint, error) {
+	taken := make(map[string]uint)
+	if len(bases) == 0 {
+		return taken, nil
+	}
+
+	var authors []models.Author
+	err := whereSlugFrom(r.db.WithContext(ctx).Unscoped().Model(&models.Author{}), "slug", bases).
+		Select("id", "slug").Find(&authors).Error
+	if err != nil {
+		return nil, fmt.Errorf("فشل جلب المعرفات النصية للمؤلفين: %w", err)
+	}
+	for _, author := range authors {
+		taken[author.Slug] = author.ID
+	}
+	return taken, nil
+}
+
+// FindByIDForShare يجلب المؤلف دون مقالاته ويقفل صفه بقفل مشترك (FOR SHARE) حتى نهاية المعاملة،
+// فلا يمكن حذفه أو تعديله من معاملة أخرى بينما تُنشأ مقالة تشير إليه؛ يعيد خطأ NotFound إذا لم يوجد
+// خارج المعاملة ينتهي القفل فورًا، وعلى SQLite يُتجاهل لأن الكتابة متسلسلة أصلاً
+func (r *authorRepository) FindByIDForShare(ctx context.Context, id uint) (*models.Author, error) {
+	return r.findLocked(ctx, id, clause.LockingStrengthShare)
+}
+
+// FindByIDForUpdate مثل FindByIDForShare لكن بقفل حصري (FOR UPDATE)، ويُستخدم قبل حذف المؤلف
+// حتى تنتظر أي معاملة تُنشئ مقالاً له إلى أن يكتمل الحذف فترى المؤلف محذوفًا
+func (r *authorRepository) FindByIDForUpdate(ctx context.Context, id uint) (*models.Author, error) {
+	return r.findLocked(ctx, id, clause.LockingStrengthUpdate)
+}
+
+// FindByIDsForShare يجلب عدة مؤلفين باستعلام واحد ويقفل صفوفهم بقفل مشترك كما في FindByIDForShare
+// يعيد المؤلفين الموجودين فقط مفهرسين بالمعرف، والمعرف الغائب من النتيجة يعني أن المؤلف غير موجود
+func (r *authorRepository) FindByIDsForShare(ctx context.Context, ids []uint) (map[uint]models.Author, error) {
+	found := make(map[uint]models.Author, len(ids))
+	if len(ids) == 0 {
+		return found, nil
+	}
+
+	var authors []models.Author
+	err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: clause.LockingStrengthShare}).
+		Where("id IN ?", ids).Find(&authors).Error
+	if err != nil {
+		return nil, fmt.Errorf("فشل جلب المؤلفين: %w", err)
+	}
+	for _, author := range authors {
+		found[author.ID] = author
+	}
+	return found, nil
+}
+
+// findLocked يجلب المؤلف بقفل الصف المحدد، ويعيد خطأ NotFound إذا لم يوجد
+func (r *authorRepository) findLocked(ctx context.Context, id uint, strength string) (*models.Author, error) {
+	var author models.Author
+	result := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: strength}).First(&author, id)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, notFoundIf(result.Error, "author_not_found", id)
+		}
+		return nil, fmt.Errorf("فشل جلب المؤلف بالمعرف %d: %w", id, result.Error)
+	}
+	return &author, nil
+}
+
+// normalizeAuthor يملأ العمود الموحد من اسم المؤلف
+func normalizeAuthor(author *models.Author) {
+	author.NameNormalized = textnorm.Normalize(author.Name)
+}
+
 // Update يقوم بتحديث مؤلف موجود في قاعدة البيانات
 // هذه الدالة مسؤولة عن تحديث بيانات مؤلف موجود بالفعل في قاعدة البيانات
-func (r *authorRepository) Update(author *models.Author) error {
-	// استخدام دالة Save من GORM لحفظ التغييرات على المؤلف
-	// إذا كان المؤلف موجودًا (له ID) فسيتم تحديثه، وإلا سيتم إنشاؤه
-	result := r.db.Save(author)
+// التحديث مشروط برقم النسخة الذي قُرئ به المؤلف، ويقتصر على أعمدة المؤلف دون مقالاته المحمّلة
+func (r *authorRepository) Update(ctx context.Context, author *models.Author) error {
+	// تحديث النسخة الموحدة من الاسم قبل الحفظ
+	normalizeAuthor(author)
+
+	if err := r.uniqueConflict(ctx, author); err != nil {
+		return err
+	}
+
+	expected := author.Version
+	author.Version = expected + 1
+	result := r.db.WithContext(ctx).Model(author).
+		Where("version = ?", expected).
+		Select("name", "email", "slug", "name_normalized", "updated_at", "version").
+		Updates(author)
 
 	// التحقق من حدوث أي خطأ أثناء التحديث
 	if result.Error != nil {
+		author.Version = expected
+		if err := constraintError(result.Error, apperr.Conflict("author_duplicate")); err != nil {
+			return err
+		}
 		// إرجاع رسالة خطأ منسقة مع الخطأ الأصلي
 		return fmt.Errorf("فشل تحديث المؤلف: %w", result.Error)
 	}
 
-	// التحقق من أن التحديث أثر على سجل واحد على الأقل
-	// إذا كانت الصفوف المتأثرة = 0، فهذا يعني أن المؤلف غير موجود
+	// إذا كانت الصفوف المتأثرة = 0، فإما أن المؤلف حُذف أو أن نسخته تغيّرت
 	if result.RowsAffected == 0 {
-		// إرجاع خطأ قياسي من GORM يشير إلى أن السجل غير موجود
-		return gorm.ErrRecordNotFound
+		author.Version = expected
+		err := missedUpdateError(r.db.WithContext(ctx).Model(&models.Author{}).Where("id = ?", author.ID))
+		return notFoundIf(err, "author_not_found", author.ID)
 	}
 
 	// إرجاع nil في حالة نجاح العملية
@@ -119,11 +299,11 @@ func (r *authorRepository) Update(author *models.Author) error {
 
 // Delete يقوم بحذف مؤلف من قاعدة البيانات باستخدام ID
 // هذه الدالة مسؤولة عن حذف مؤلف من قاعدة البيانات باستخدام معرّفه الفريد
-func (r *authorRepository) Delete(id uint) error {
+func (r *authorRepository) Delete(ctx context.Context, id uint) error {
 	// استخدام GORM لحذف المؤلف بواسطة المعرّف
 	// نمرر كائن Author فارغ ومعرّف المؤلف المراد حذفه
 	// ملاحظة: اعتمادًا على إعدادات GORM، قد يكون هذا حذفًا فعليًا أو حذفًا منطقيًا (soft delete)
-	result := r.db.Delete(&models.Author{}, id)
+	result := r.db.WithContext(ctx).Delete(&models.Author{}, id)
 
 	// التحقق من حدوث أي خطأ أثناء الحذف
 	if result.Error != nil {
@@ -134,10 +314,10 @@ func (r *authorRepository) Delete(id uint) error {
 	// التحقق من أن الحذف أثر على سجل واحد على الأقل
 	// إذا كانت الصفوف المتأثرة = 0، فهذا يعني أن المؤلف غير موجود
 	if result.RowsAffected == 0 {
-		// إرجاع خطأ قياسي من GORM يشير إلى أن السجل غير موجود
-		return gorm.ErrRecordNotFound
+		// إرجاع خطأ NotFound مصنّف
+		return apperr.NotFound("author_not_found", id)
 	}
 
 	// إرجاع nil في حالة نجاح العملية
 	return nil
-}
\ No newline at end of file
+}
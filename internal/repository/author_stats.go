@@ -0,0 +1,190 @@
+// my-article-app/internal/repository/author_stats.go
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"my-article-app/internal/models"
+	"my-article-app/internal/pagination"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AuthorStats إحصاءات مقالات المؤلف غير المحذوفة؛ تاريخا النشر nil للمؤلف الذي لا مقالات له
+type AuthorStats struct {
+	ArticleCount     int64
+	FirstPublishedAt *time.Time
+	LastPublishedAt  *time.Time
+	TotalWords       int64
+}
+
+// AuthorWithStats مؤلف مع إحصاءات مقالاته
+type AuthorWithStats struct {
+	models.Author
+	Stats AuthorStats
+}
+
+// authorSortColumns القائمة المسموحة لحقول ترتيب المؤلفين وأعمدتها المقابلة
+// أعمدة الإحصاءات تأتي من الاستعلام المجمّع author_stats، والتواريخ تُسبق بشرط يضع المؤلفين بلا مقالات
+// في آخر النتائج بكلا الاتجاهين، لأن موضع NULL في الترتيب يختلف بين قواعد البيانات
+var authorSortColumns = map[string]string{
+	"id":                 "authors.id",
+	"name":               "authors.name",
+	"created_at":         "authors.created_at",
+	"article_count":      "COALESCE(author_stats.article_count, 0)",
+	"total_words":        "COALESCE(author_stats.total_words, 0)",
+	"first_published_at": "CASE WHEN author_stats.first_published_at IS NULL THEN 1 ELSE 0 END, author_stats.first_published_at",
+	"last_published_at":  "CASE WHEN author_stats.last_published_at IS NULL THEN 1 ELSE 0 END, author_stats.last_published_at",
+}
+
+// authorStatsFields حقول الترتيب التي تتطلب ضم إحصاءات المقالات
+var authorStatsFields = map[string]bool{
+	"article_count":      true,
+	"total_words":        true,
+	"first_published_at": true,
+	"last_published_at":  true,
+}
+
+// IsAuthorSortField يحدد ما إذا كان الحقل مسموحًا لترتيب المؤلفين
+func IsAuthorSortField(field string) bool {
+	_, ok := authorSortColumns[field]
+	return ok
+}
+
+// needsStats يحدد ما إذا كان الترتيب المطلوب يعتمد على إحصاءات المقالات
+func (f AuthorFilter) needsStats() bool {
+	for _, s := range f.Sort {
+		if authorStatsFields[s.Field] {
+			return true
+		}
+	}
+	return false
+}
+
+// articleStatsByAuthor استعلام مجمّع واحد يحسب إحصاءات المقالات المنشورة غير المحذوفة لكل مؤلف
+// تاريخ النشر هو published_at، فلا تدخل المسودات والمجدولة والمؤرشفة في الإحصاءات
+func articleStatsByAuthor(db *gorm.DB) *gorm.DB {
+	return db.Model(&models.Article{}).
+		Select("author_id, COUNT(*) AS article_count, MIN(published_at) AS first_published_at, "+
+			"MAX(published_at) AS last_published_at, SUM(word_count) AS total_words").
+		Where("status = ?", models.ArticleStatusPublished).
+		Group("author_id")
+}
+
+// withArticleStats يضم إحصاءات المقالات إلى استعلام المؤلفين (LEFT JOIN حتى يظهر المؤلف بلا مقالات)
+func withArticleStats(query *gorm.DB) *gorm.DB {
+	return query.
+		Joins("LEFT JOIN (?) AS author_stats ON author_stats.author_id = authors.id", articleStatsByAuthor(query.Session(&gorm.Session{NewDB: true}))).
+		Select("authors.*, COALESCE(author_stats.article_count, 0) AS article_count, " +
+			"author_stats.first_published_at, author_stats.last_published_at, " +
+			"COALESCE(author_stats.total_words, 0) AS total_words")
+}
+
+// authorStatsRow صف نتيجة الاستعلام مع الإحصاءات كما تعيده قاعدة البيانات
+type authorStatsRow struct {
+	models.Author
+	ArticleCount     int64
+	FirstPublishedAt aggregateTime
+	LastPublishedAt  aggregateTime
+	TotalWords       int64
+}
+
+// FindAllWithStats يجلب صفحة من المؤلفين مع إحصاءات مقالاتهم، محسوبة باستعلام مجمّع واحد مضموم إلى الصفحة
+func (r *authorRepository) FindAllWithStats(ctx context.Context, filter AuthorFilter, req pagination.Request) (*pagination.Page[AuthorWithStats], error) {
+	orders, err := orderClauses(filter.Sort, authorSortColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := findPage(withArticleStats(r.authorQuery(ctx, filter)), req, pageSpec[authorStatsRow]{
+		idColumn: "authors.id",
+		idOf:     func(a *authorStatsRow) uint { return a.ID },
+		orders:   orders,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("فشل جلب المؤلفين مع الإحصاءات: %w", err)
+	}
+
+	page := &pagination.Page[AuthorWithStats]{
+		Items:      make([]AuthorWithStats, 0, len(rows.Items)),
+		Total:      rows.Total,
+		Limit:      rows.Limit,
+		Offset:     rows.Offset,
+		HasMore:    rows.HasMore,
+		NextCursor: rows.NextCursor,
+	}
+	for _, row := range rows.Items {
+		page.Items = append(page.Items, AuthorWithStats{
+			Author: row.Author,
+			Stats: AuthorStats{
+				ArticleCount:     row.ArticleCount,
+				FirstPublishedAt: row.FirstPublishedAt.ptr(),
+				LastPublishedAt:  row.LastPublishedAt.ptr(),
+				TotalWords:       row.TotalWords,
+			},
+		})
+	}
+	return page, nil
+}
+
+// aggregateTime يقرأ تاريخًا ناتجًا عن دالة تجميع (MIN/MAX)؛ SQLite يعيده نصًا لأن العمود المحسوب بلا نوع معلن
+type aggregateTime struct {
+	time.Time
+	Valid bool
+}
+
+// صيغ التاريخ التي يكتبها مشغّل SQLite
+var aggregateTimeLayouts = []string{
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02T15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// Scan ينفذ sql.Scanner
+func (t *aggregateTime) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		*t = aggregateTime{}
+		return nil
+	case time.Time:
+		*t = aggregateTime{Time: v, Valid: true}
+		return nil
+	case []byte:
+		return t.parse(string(v))
+	case string:
+		return t.parse(v)
+	}
+	return fmt.Errorf("نوع غير مدعوم لتاريخ مجمّع: %T", value)
+}
+
+func (t *aggregateTime) parse(s string) error {
+	for _, layout := range aggregateTimeLayouts {
+		if parsed, err := time.Parse(layout, s); err == nil {
+			*t = aggregateTime{Time: parsed, Valid: true}
+			return nil
+		}
+	}
+	return fmt.Errorf("صيغة تاريخ مجمّع غير معروفة: %q", s)
+}
+
+// Value ينفذ driver.Valuer حتى يعامل GORM الحقل كقيمة واحدة لا كبنية متداخلة
+func (t aggregateTime) Value() (driver.Value, error) {
+	if !t.Valid {
+		return nil, nil
+	}
+	return t.Time, nil
+}
+
+// ptr يعيد التاريخ كمؤشر، أو nil إذا كان NULL
+func (t aggregateTime) ptr() *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	v := t.Time
+	return &v
+}
@@ -0,0 +1,107 @@
+// my-article-app/internal/repository/author_stats_test.go
+package repository
+
+import (
+	"context"
+	"fmt"
+	"my-article-app/internal/models"
+	"my-article-app/internal/pagination"
+	"slices"
+	"testing"
+	"time"
+)
+
+// TestAuthorStats يشغّل الاستعلام المجمّع في SQLite ونظيره في التخزين في الذاكرة على البيانات نفسها
+func TestAuthorStats(t *testing.T) {
+	forEachStore(t, func(t *testing.T, repos Repositories) {
+		ctx := context.Background()
+		base := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+		t1, t2, t3 := base, base.Add(24*time.Hour), base.Add(48*time.Hour)
+
+		alice := createAuthor(t, repos, "alice")
+		bob := createAuthor(t, repos, "bob")
+		carol := createAuthor(t, repos, "carol")
+
+		add := func(authorID uint, slug, content, status string, publishedAt *time.Time) *models.Article {
+			t.Helper()
+			article := &models.Article{Title: slug, Slug: slug, Content: content, AuthorID: authorID, Status: status, PublishedAt: publishedAt}
+			if err := repos.Articles.Create(ctx, article); err != nil {
+				t.Fatalf("create %s: %v", slug, err)
+			}
+			return article
+		}
+		add(alice.ID, "alice-first", "one two three", models.ArticleStatusPublished, &t1)
+		add(alice.ID, "alice-last", "one two three four five", models.ArticleStatusPublished, &t3)
+		// المسودة والمقال المحذوف لا يدخلان في الإحصاءات
+		add(alice.ID, "alice-draft", "not counted at all here", models.ArticleStatusDraft, nil)
+		deleted := add(alice.ID, "alice-deleted", "not counted either", models.ArticleStatusPublished, &t3)
+		if err := repos.Articles.Delete(ctx, deleted.ID); err != nil {
+			t.Fatalf("delete: %v", err)
+		}
+		add(bob.ID, "bob-only", "two words", models.ArticleStatusPublished, &t2)
+
+		page, err := repos.Authors.FindAllWithStats(ctx, AuthorFilter{}, pagination.Request{})
+		if err != nil {
+			t.Fatalf("FindAllWithStats: %v", err)
+		}
+		want := map[uint]AuthorStats{
+			alice.ID: {ArticleCount: 2, FirstPublishedAt: &t1, LastPublishedAt: &t3, TotalWords: 8},
+			bob.ID:   {ArticleCount: 1, FirstPublishedAt: &t2, LastPublishedAt: &t2, TotalWords: 2},
+			carol.ID: {},
+		}
+		if len(page.Items) != len(want) || page.Total != int64(len(want)) {
+			t.Fatalf("FindAllWithStats = %d items (total %d), want %d", len(page.Items), page.Total, len(want))
+		}
+		for _, item := range page.Items {
+			if got := item.Stats; !sameStats(got, want[item.ID]) {
+				t.Errorf("%s stats = %s, want %s", item.Name, formatStats(got), formatStats(want[item.ID]))
+			}
+		}
+
+		sorts := []struct {
+			field string
+			desc  bool
+			want  []uint
+		}{
+			{"article_count", true, []uint{alice.ID, bob.ID, carol.ID}},
+			{"article_count", false, []uint{carol.ID, bob.ID, alice.ID}},
+			{"total_words", false, []uint{carol.ID, bob.ID, alice.ID}},
+			// المؤلف بلا مقالات منشورة في آخر الترتيب بكلا الاتجاهين
+			{"last_published_at", true, []uint{alice.ID, bob.ID, carol.ID}},
+			{"last_published_at", false, []uint{bob.ID, alice.ID, carol.ID}},
+			{"first_published_at", false, []uint{alice.ID, bob.ID, carol.ID}},
+		}
+		for _, s := range sorts {
+			filter := AuthorFilter{Sort: []SortField{{Field: s.field, Desc: s.desc}}}
+			page, err := repos.Authors.FindAllWithStats(ctx, filter, pagination.Request{})
+			if err != nil {
+				t.Fatalf("FindAllWithStats sort=%s: %v", s.field, err)
+			}
+			var got []uint
+			for _, item := range page.Items {
+				got = append(got, item.ID)
+			}
+			if !slices.Equal(got, s.want) {
+				t.Errorf("sort %s desc=%v = %v, want %v", s.field, s.desc, got, s.want)
+			}
+		}
+	})
+}
+
+func sameStats(a, b AuthorStats) bool {
+	sameTime := func(x, y *time.Time) bool {
+		return (x == nil) == (y == nil) && (x == nil || x.Equal(*y))
+	}
+	return a.ArticleCount == b.ArticleCount && a.TotalWords == b.TotalWords &&
+		sameTime(a.FirstPublishedAt, b.FirstPublishedAt) && sameTime(a.LastPublishedAt, b.LastPublishedAt)
+}
+
+func formatStats(s AuthorStats) string {
+	format := func(t *time.Time) string {
+		if t == nil {
+			return "nil"
+		}
+		return t.UTC().Format(time.RFC3339)
+	}
+	return fmt.Sprintf("{count %d, words %d, first %s, last %s}", s.ArticleCount, s.TotalWords, format(s.FirstPublishedAt), format(s.LastPublishedAt))
+}
@@ -0,0 +1,150 @@
+// my-article-app/internal/repository/category_repository.go
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"my-article-app/internal/apperr"
+	"my-article-app/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// CategoryRepository شجرة التصنيفات وربطها بالمقالات
+// التصنيفات قليلة بطبيعتها، فتُقرأ الشجرة كاملة دون ترقيم
+type CategoryRepository interface {
+	Create(ctx context.Context, category *models.Category) error
+	FindAll(ctx context.Context) ([]models.Category, error)
+	FindByID(ctx context.Context, id uint) (*models.Category, error)
+	FindByIDs(ctx context.Context, ids []uint) (map[uint]models.Category, error)
+	CountChildren(ctx context.Context, id uint) (int64, error)
+	Update(ctx context.Context, category *models.Category) error
+	Delete(ctx context.Context, id uint) error
+	ReplaceArticleCategories(ctx context.Context, articleID uint, categoryIDs []uint) error
+	LinkArticles(ctx context.Context, links []models.ArticleCategory, batchSize int) error
+}
+
+type categoryRepository struct {
+	db *gorm.DB
+}
+
+// NewCategoryRepository ينشئ مثيلاً جديدًا من CategoryRepository
+func NewCategoryRepository(db *gorm.DB) CategoryRepository {
+	return &categoryRepository{db: db}
+}
+
+// Create ينشئ تصنيفًا جديدًا، ويعيد تعارضًا إذا وُجد تصنيف بالمعرف النصي نفسه
+func (r *categoryRepository) Create(ctx context.Context, category *models.Category) error {
+	if err := r.db.WithContext(ctx).Create(category).Error; err != nil {
+		if err := constraintError(err, apperr.Conflict("category_exists", category.Slug)); err != nil {
+			return err
+		}
+		return fmt.Errorf("فشل إنشاء التصنيف: %w", err)
+	}
+	return nil
+}
+
+// FindAll يجلب كل التصنيفات مرتبة حسب المعرف
+func (r *categoryRepository) FindAll(ctx context.Context) ([]models.Category, error) {
+	var categories []models.Category
+	if err := r.db.WithContext(ctx).Order("id ASC").Find(&categories).Error; err != nil {
+		return nil, fmt.Errorf("فشل جلب التصنيفات: %w", err)
+	}
+	return categories, nil
+}
+
+// FindByID يجلب تصنيفًا واحدًا حسب المعرف
+func (r *categoryRepository) FindByID(ctx context.Context, id uint) (*models.Category, error) {
+	var category models.Category
+	if err := r.db.WithContext(ctx).First(&category, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, notFoundIf(err, "category_not_found", id)
+		}
+		return nil, fmt.Errorf("فشل جلب التصنيف بالمعرف %d: %w", id, err)
+	}
+	return &category, nil
+}
+
+// FindByIDs يجلب عدة تصنيفات باستعلام واحد مفهرسة بالمعرف، والمعرف الغائب من النتيجة غير موجود
+func (r *categoryRepository) FindByIDs(ctx context.Context, ids []uint) (map[uint]models.Category, error) {
+	found := make(map[uint]models.Category, len(ids))
+	if len(ids) == 0 {
+		return found, nil
+	}
+
+	var categories []models.Category
+	if err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&categories).Error; err != nil {
+		return nil, fmt.Errorf("فشل جلب التصنيفات: %w", err)
+	}
+	for _, category := range categories {
+		found[category.ID] = category
+	}
+	return found, nil
+}
+
+// CountChildren يعيد عدد التصنيفات الفرعية المباشرة للتصنيف
+func (r *categoryRepository) CountChildren(ctx context.Context, id uint) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&models.Category{}).Where("parent_id = ?", id).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("فشل حساب التصنيفات الفرعية: %w", err)
+	}
+	return count, nil
+}
+
+// Update يحفظ اسم التصنيف ومعرفه النصي وأباه
+func (r *categoryRepository) Update(ctx context.Context, category *models.Category) error {
+	result := r.db.WithContext(ctx).Model(category).Select("name", "slug", "parent_id", "updated_at").Updates(category)
+	if result.Error != nil {
+		if err := constraintError(result.Error, apperr.Conflict("category_exists", category.Slug)); err != nil {
+			return err
+		}
+		return fmt.Errorf("فشل تحديث التصنيف: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return apperr.NotFound("category_not_found", category.ID)
+	}
+	return nil
+}
+
+// Delete يحذف التصنيف نهائيًا، وتُحذف صفوف ربطه بالمقالات بقيد ON DELETE CASCADE
+// قيد الأب يرفض حذف تصنيف له تصنيفات فرعية
+func (r *categoryRepository) Delete(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Delete(&models.Category{}, id)
+	if result.Error != nil {
+		if err := constraintError(result.Error, apperr.Conflict("constraint_violation")); err != nil {
+			return err
+		}
+		return fmt.Errorf("فشل حذف التصنيف: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return apperr.NotFound("category_not_found", id)
+	}
+	return nil
+}
+
+// ReplaceArticleCategories يستبدل تصنيفات المقال بـ categoryIDs، والقائمة الفارغة تزيل تصنيفاته كلها
+func (r *categoryRepository) ReplaceArticleCategories(ctx context.Context, articleID uint, categoryIDs []uint) error {
+	if err := r.db.WithContext(ctx).Where("article_id = ?", articleID).Delete(&models.ArticleCategory{}).Error; err != nil {
+		return fmt.Errorf("فشل إزالة تصنيفات المقال: %w", err)
+	}
+	links := make([]models.ArticleCategory, 0, len(categoryIDs))
+	for _, id := range categoryIDs {
+		links = append(links, models.ArticleCategory{ArticleID: articleID, CategoryID: id})
+	}
+	return r.LinkArticles(ctx, links, len(links))
+}
+
+// LinkArticles يضيف صفوف ربط بين مقالات وتصنيفات بإدخالات مجمّعة من batchSize صف
+func (r *categoryRepository) LinkArticles(ctx context.Context, links []models.ArticleCategory, batchSize int) error {
+	if len(links) == 0 {
+		return nil
+	}
+	if err := r.db.WithContext(ctx).CreateInBatches(links, batchSize).Error; err != nil {
+		if err := constraintError(err, apperr.Conflict("constraint_violation")); err != nil {
+			return err
+		}
+		return fmt.Errorf("فشل ربط التصنيفات بالمقالات: %w", err)
+	}
+	return nil
+}
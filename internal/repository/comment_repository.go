@@ -0,0 +1,149 @@
+// my-article-app/internal/repository/comment_repository.go
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"my-article-app/internal/apperr"
+	"my-article-app/internal/models"
+	"my-article-app/internal/pagination"
+
+	"gorm.io/gorm"
+)
+
+// CommentRepository تعليقات المقالات وردودها وطابور مراجعتها
+type CommentRepository interface {
+	Create(ctx context.Context, comment *models.Comment) error
+	FindByID(ctx context.Context, id uint) (*models.Comment, error)
+	FindThreads(ctx context.Context, articleID uint, req pagination.Request) (*pagination.Page[models.Comment], error)
+	FindReplies(ctx context.Context, threadIDs []uint) ([]models.Comment, error)
+	FindQueue(ctx context.Context, status string, req pagination.Request) (*pagination.Page[models.Comment], error)
+	Update(ctx context.Context, comment *models.Comment) error
+	Delete(ctx context.Context, id uint) error
+	RefreshArticleCount(ctx context.Context, articleID uint) error
+}
+
+type commentRepository struct {
+	db *gorm.DB
+}
+
+// NewCommentRepository ينشئ مثيلاً جديدًا من CommentRepository
+func NewCommentRepository(db *gorm.DB) CommentRepository {
+	return &commentRepository{db: db}
+}
+
+// Create ينشئ تعليقًا جديدًا؛ غياب المقال أو التعليق الأب يرفضه قيد المفتاح الأجنبي
+func (r *commentRepository) Create(ctx context.Context, comment *models.Comment) error {
+	if err := r.db.WithContext(ctx).Create(comment).Error; err != nil {
+		if err := constraintError(err, apperr.Conflict("constraint_violation")); err != nil {
+			return err
+		}
+		return fmt.Errorf("فشل إنشاء التعليق: %w", err)
+	}
+	return nil
+}
+
+// FindByID يجلب تعليقًا واحدًا غير محذوف حسب المعرف
+func (r *commentRepository) FindByID(ctx context.Context, id uint) (*models.Comment, error) {
+	var comment models.Comment
+	if err := r.db.WithContext(ctx).First(&comment, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, notFoundIf(err, "comment_not_found", id)
+		}
+		return nil, fmt.Errorf("فشل جلب التعليق بالمعرف %d: %w", id, err)
+	}
+	return &comment, nil
+}
+
+// FindThreads يجلب صفحة من التعليقات الجذرية المعتمدة للمقال مرتبة من الأقدم
+// التعليق الجذري المحذوف يبقى في الصفحة ما دام له رد معتمد غير محذوف، ليظهر مكانًا لردوده
+func (r *commentRepository) FindThreads(ctx context.Context, articleID uint, req pagination.Request) (*pagination.Page[models.Comment], error) {
+	replies := r.db.Session(&gorm.Session{NewDB: true}).Table("comments AS replies").
+		Select("1").
+		Where("replies.thread_id = comments.id AND replies.status = ? AND replies.deleted_at IS NULL", models.CommentStatusApproved)
+
+	query := r.db.WithContext(ctx).Unscoped().Model(&models.Comment{}).
+		Where("comments.article_id = ? AND comments.parent_id IS NULL AND comments.status = ?", articleID, models.CommentStatusApproved).
+		Where("comments.deleted_at IS NULL OR EXISTS (?)", replies)
+
+	page, err := findPage(query, req, pageSpec[models.Comment]{
+		idColumn: "comments.id",
+		idOf:     func(c *models.Comment) uint { return c.ID },
+	})
+	if err != nil {
+		return nil, fmt.Errorf("فشل جلب تعليقات المقال: %w", err)
+	}
+	return page, nil
+}
+
+// FindReplies يجلب الردود المعتمدة في السلاسل threadIDs مرتبة من الأقدم، ومنها المحذوفة
+// لأن الرد المحذوف قد يكون أبًا لردود ظاهرة
+func (r *commentRepository) FindReplies(ctx context.Context, threadIDs []uint) ([]models.Comment, error) {
+	if len(threadIDs) == 0 {
+		return nil, nil
+	}
+	var replies []models.Comment
+	err := r.db.WithContext(ctx).Unscoped().
+		Where("thread_id IN ? AND status = ?", threadIDs, models.CommentStatusApproved).
+		Order("id ASC").
+		Find(&replies).Error
+	if err != nil {
+		return nil, fmt.Errorf("فشل جلب الردود: %w", err)
+	}
+	return replies, nil
+}
+
+// FindQueue يجلب صفحة من التعليقات غير المحذوفة بالحالة status من كل المقالات، الأقدم أولاً
+func (r *commentRepository) FindQueue(ctx context.Context, status string, req pagination.Request) (*pagination.Page[models.Comment], error) {
+	query := r.db.WithContext(ctx).Model(&models.Comment{}).Where("status = ?", status)
+	page, err := findPage(query, req, pageSpec[models.Comment]{
+		idColumn: "comments.id",
+		idOf:     func(c *models.Comment) uint { return c.ID },
+	})
+	if err != nil {
+		return nil, fmt.Errorf("فشل جلب طابور التعليقات: %w", err)
+	}
+	return page, nil
+}
+
+// Update يحفظ نص التعليق وحالته وبيانات تعديله ومراجعته
+func (r *commentRepository) Update(ctx context.Context, comment *models.Comment) error {
+	result := r.db.WithContext(ctx).Model(comment).
+		Select("body", "status", "edited_at", "moderated_at", "moderated_by", "updated_at").
+		Updates(comment)
+	if result.Error != nil {
+		return fmt.Errorf("فشل تحديث التعليق: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return apperr.NotFound("comment_not_found", comment.ID)
+	}
+	return nil
+}
+
+// Delete يحذف التعليق حذفًا منطقيًا، فتبقى ردوده في سلستها
+func (r *commentRepository) Delete(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Delete(&models.Comment{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("فشل حذف التعليق: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return apperr.NotFound("comment_not_found", id)
+	}
+	return nil
+}
+
+// RefreshArticleCount يعيد حساب عدد التعليقات المعتمدة غير المحذوفة للمقال باستعلام فرعي واحد
+// العدد ليس من محتوى المقال، فلا يغيّر رقم نسخته ولا وقت تحديثه
+func (r *commentRepository) RefreshArticleCount(ctx context.Context, articleID uint) error {
+	count := r.db.Session(&gorm.Session{NewDB: true}).Model(&models.Comment{}).
+		Select("COUNT(*)").
+		Where("comments.article_id = articles.id AND comments.status = ?", models.CommentStatusApproved)
+	err := r.db.WithContext(ctx).Unscoped().Model(&models.Article{}).
+		Where("id = ?", articleID).
+		UpdateColumn("comment_count", count).Error
+	if err != nil {
+		return fmt.Errorf("فشل تحديث عدد تعليقات المقال: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+// my-article-app/internal/repository/errors.go
+package repository
+
+import (
+	"errors"
+	"my-article-app/internal/apperr"
+
+	"gorm.io/gorm"
+)
+
+// notFoundIf يحوّل gorm.ErrRecordNotFound إلى خطأ NotFound برمز المستودع، ويعيد بقية الأخطاء كما هي
+func notFoundIf(err error, code string, args ...any) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return apperr.Wrap(apperr.KindNotFound, err, code, args...)
+	}
+	return err
+}
+
+// constraintError يحوّل انتهاكات قيود قاعدة البيانات (بعد ترجمتها عبر TranslateError) إلى أخطاء مصنّفة
+// duplicate الخطأ المعروض عند انتهاك القيد الفريد، ويعيد nil إذا لم يكن الخطأ انتهاكًا لقيد
+func constraintError(err error, duplicate *apperr.Error) error {
+	switch {
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return apperr.Wrap(apperr.KindConflict, err, duplicate.Code, duplicate.Args...)
+	case errors.Is(err, gorm.ErrForeignKeyViolated):
+		return apperr.Wrap(apperr.KindConflict, err, "constraint_violation")
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+// my-article-app/internal/repository/errors_test.go
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"my-article-app/internal/apperr"
+	"my-article-app/internal/models"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+func TestConstraintError(t *testing.T) {
+	duplicate := apperr.Conflict("author_email_taken", "a@example.com")
+	tests := []struct {
+		name     string
+		err      error
+		wantCode string // فارغ يعني أن الخطأ ليس انتهاكًا لقيد
+	}{
+		{"duplicate key", gorm.ErrDuplicatedKey, "author_email_taken"},
+		{"wrapped duplicate key", fmt.Errorf("create: %w", gorm.ErrDuplicatedKey), "author_email_taken"},
+		{"foreign key", gorm.ErrForeignKeyViolated, "constraint_violation"},
+		{"other error", errors.New("disk full"), ""},
+		{"nil", nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := constraintError(tt.err, duplicate)
+			if tt.wantCode == "" {
+				if err != nil {
+					t.Fatalf("constraintError = %v, want nil", err)
+				}
+				return
+			}
+			var appErr *apperr.Error
+			if !errors.As(err, &appErr) || appErr.Kind != apperr.KindConflict || appErr.Code != tt.wantCode {
+				t.Fatalf("constraintError = %#v, want conflict %q", err, tt.wantCode)
+			}
+			if !errors.Is(err, tt.err) {
+				t.Error("constraintError dropped the original error")
+			}
+		})
+	}
+}
+
+func TestNotFoundIf(t *testing.T) {
+	err := notFoundIf(fmt.Errorf("first: %w", gorm.ErrRecordNotFound), "article_not_found", uint(4))
+	if !apperr.Is(err, apperr.KindNotFound) || !errors.Is(err, apperr.NotFound("article_not_found")) {
+		t.Errorf("notFoundIf(record not found) = %v, want article_not_found", err)
+	}
+	other := errors.New("connection reset")
+	if err := notFoundIf(other, "article_not_found"); err != other {
+		t.Errorf("notFoundIf(other) = %v, want it unchanged", err)
+	}
+}
+
+// TestDuplicateEmailIsConflict يتحقق أن انتهاك القيد الفريد في SQLite يصل مترجمًا عبر TranslateError
+func TestDuplicateEmailIsConflict(t *testing.T) {
+	forEachStore(t, func(t *testing.T, repos Repositories) {
+		createAuthor(t, repos, "first")
+		err := repos.Authors.Create(context.Background(), &models.Author{Name: "second", Email: "first@example.com", Slug: "second"})
+		if !errors.Is(err, apperr.Conflict("author_email_taken")) {
+			t.Errorf("duplicate email: err = %v, want author_email_taken", err)
+		}
+	})
+}
+
+// TestDuplicateAuthorSlugIsConflict يتحقق أن تكرار المعرف النصي يُبلّغ بخطئه الخاص لا بخطأ البريد،
+// وأن المؤلف المحذوف يبقى حاجزًا لمعرفه وبريده كما في القيدين الفريدين
+func TestDuplicateAuthorSlugIsConflict(t *testing.T) {
+	forEachStore(t, func(t *testing.T, repos Repositories) {
+		ctx := context.Background()
+		first := createAuthor(t, repos, "first")
+		second := createAuthor(t, repos, "second")
+
+		err := repos.Authors.Create(ctx, &models.Author{Name: "third", Email: "third@example.com", Slug: "first"})
+		if !errors.Is(err, apperr.Conflict("author_slug_taken")) {
+			t.Errorf("create with a taken slug: err = %v, want author_slug_taken", err)
+		}
+		// البريد المكرر يُقدَّم على المعرف المكرر
+		err = repos.Authors.Create(ctx, &models.Author{Name: "third", Email: "first@example.com", Slug: "first"})
+		if !errors.Is(err, apperr.Conflict("author_email_taken")) {
+			t.Errorf("create with a taken email and slug: err = %v, want author_email_taken", err)
+		}
+
+		second.Slug = "first"
+		if err := repos.Authors.Update(ctx, second); !errors.Is(err, apperr.Conflict("author_slug_taken")) {
+			t.Errorf("update to a taken slug: err = %v, want author_slug_taken", err)
+		}
+		second.Slug = "second"
+		if err := repos.Authors.Update(ctx, second); err != nil {
+			t.Errorf("update keeping its own slug: %v", err)
+		}
+
+		if err := repos.Authors.Delete(ctx, first.ID); err != nil {
+			t.Fatalf("delete: %v", err)
+		}
+		err = repos.Authors.Create(ctx, &models.Author{Name: "fourth", Email: "fourth@example.com", Slug: "first"})
+		if !errors.Is(err, apperr.Conflict("author_slug_taken")) {
+			t.Errorf("create with a deleted author's slug: err = %v, want author_slug_taken", err)
+		}
+	})
+}
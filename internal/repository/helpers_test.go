@@ -0,0 +1,69 @@
+// my-article-app/internal/repository/helpers_test.go
+package repository
+
+import (
+	"context"
+	"fmt"
+	"my-article-app/internal/config"
+	"my-article-app/internal/database"
+	"my-article-app/internal/models"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// openTestDB يفتح قاعدة SQLite في الذاكرة ويطبق عليها كل الترحيلات، فلا تحتاج الاختبارات أي خدمة خارجية
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	cfg := config.Default()
+	cfg.Database.Driver = config.DriverSQLite
+	cfg.Database.Path = config.SQLiteMemory
+	cfg.Log.Level = config.LogLevelError
+
+	db, err := database.Open(cfg)
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	t.Cleanup(func() { _ = database.Close(db) })
+
+	migrator, err := database.NewMigrator(db)
+	if err != nil {
+		t.Fatalf("NewMigrator: %v", err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		t.Fatalf("migrate up: %v", err)
+	}
+	return db
+}
+
+// forEachStore يشغّل test في اختبار فرعي لكل مخزن: SQLite في الذاكرة والتخزين في الذاكرة
+func forEachStore(t *testing.T, test func(t *testing.T, repos Repositories)) {
+	t.Run("sqlite", func(t *testing.T) { test(t, NewRepositories(openTestDB(t))) })
+	t.Run("memory", func(t *testing.T) { test(t, NewMemoryRepositories(NewMemoryStore())) })
+}
+
+// createAuthor يضيف مؤلفًا بمعرف نصي واسم وبريد فريدين مشتقين من name
+func createAuthor(t *testing.T, repos Repositories, name string) *models.Author {
+	t.Helper()
+	author := &models.Author{Name: name, Email: name + "@example.com", Slug: name}
+	if err := repos.Authors.Create(context.Background(), author); err != nil {
+		t.Fatalf("create author %q: %v", name, err)
+	}
+	return author
+}
+
+// createArticle يضيف مقالًا منشورًا للمؤلف بعنوان ومعرف نصي فريدين
+func createArticle(t *testing.T, repos Repositories, authorID uint, n int) *models.Article {
+	t.Helper()
+	article := &models.Article{
+		Title:    fmt.Sprintf("Article number %d", n),
+		Slug:     fmt.Sprintf("article-number-%d", n),
+		Content:  fmt.Sprintf("content of article %d", n),
+		AuthorID: authorID,
+		Status:   models.ArticleStatusPublished,
+	}
+	if err := repos.Articles.Create(context.Background(), article); err != nil {
+		t.Fatalf("create article %d: %v", n, err)
+	}
+	return article
+}
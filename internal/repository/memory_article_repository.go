@@ -0,0 +1,525 @@
+// my-article-app/internal/repository/memory_article_repository.go
+package repository
+
+import (
+	"cmp"
+	"context"
+	"my-article-app/internal/apperr"
+	"my-article-app/internal/models"
+	"my-article-app/internal/pagination"
+	"my-article-app/internal/textnorm"
+	"slices"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// memoryArticleRepository تنفيذ ArticleRepository في الذاكرة بنفس سلوك تنفيذ GORM:
+// الحذف منطقي، والمؤلف يُحمّل مع كل مقال ما لم يكن محذوفًا، والمؤلف المشار إليه يجب أن يوجد كقيد المفتاح الأجنبي
+type memoryArticleRepository struct {
+	access memoryAccess
+}
+
+// NewMemoryArticleRepository ينشئ ArticleRepository يعمل على تخزين في الذاكرة
+func NewMemoryArticleRepository(store *MemoryStore) ArticleRepository {
+	return &memoryArticleRepository{access: store}
+}
+
+// withRelations يعيد نسخة من المقال مع مؤلفه كما يفعل Preload("Author"): المؤلف المحذوف يُتجاهل
+// إلا مع المقالات المحذوفة، لأن استعلامات سلة المحذوفات تعمل بـ Unscoped الذي يشمل التحميل المسبق؛
+// ومع وسومه وتصنيفاته كما يفعل Preload("Tags") و Preload("Categories")
+func (d *memoryData) withRelations(article models.Article) models.Article {
+	if article.DeletedAt.Valid {
+		article.Author = d.authors[article.AuthorID]
+	} else {
+		article.Author, _ = d.liveAuthor(article.AuthorID)
+	}
+	return d.withTaxonomy(article)
+}
+
+// withTaxonomy يعيد نسخة من المقال مع وسومه وتصنيفاته
+func (d *memoryData) withTaxonomy(article models.Article) models.Article {
+	article.Tags, article.Categories = nil, nil
+	for _, id := range d.articleTags[article.ID] {
+		article.Tags = append(article.Tags, d.tags[id])
+	}
+	for _, id := range d.articleCategories[article.ID] {
+		article.Categories = append(article.Categories, d.categories[id])
+	}
+	return article
+}
+
+// articles يجمع نسخًا من المقالات المطابقة لشرط مع علاقاتها مرتبة حسب المعرف
+func (r *memoryArticleRepository) articles(ctx context.Context, match func(a *models.Article) bool) ([]models.Article, error) {
+	var items []models.Article
+	err := r.access.view(ctx, func(d *memoryData) error {
+		for _, article := range d.articles {
+			if article = d.withRelations(article); match(&article) {
+				items = append(items, article)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(items, func(a, b models.Article) int { return cmp.Compare(a.ID, b.ID) })
+	return items, nil
+}
+
+// Create ينشئ مقالاً جديدًا ويملأ معرفه وتواريخه
+func (r *memoryArticleRepository) Create(ctx context.Context, article *models.Article) error {
+	normalizeArticle(article)
+	if article.Version == 0 {
+		article.Version = 1
+	}
+
+	return r.access.update(ctx, func(d *memoryData) error {
+		if _, ok := d.authors[article.AuthorID]; !ok {
+			return apperr.Conflict("constraint_violation")
+		}
+		d.nextArticleID++
+		now := time.Now()
+		article.ID = d.nextArticleID
+		article.CreatedAt, article.UpdatedAt = now, now
+
+		stored := *article
+		stored.Author = models.Author{}
+		d.articles[stored.ID] = stored
+		return nil
+	})
+}
+
+// FindAll يجلب صفحة من المقالات غير المحذوفة المطابقة لشروط التصفية
+func (r *memoryArticleRepository) FindAll(ctx context.Context, filter ArticleFilter, req pagination.Request) (*pagination.Page[models.Article], error) {
+	// التحقق من حقول الترتيب بنفس القائمة المسموحة في تنفيذ GORM
+	if _, err := orderClauses(filter.Sort, articleSortColumns); err != nil {
+		return nil, err
+	}
+
+	var subtree map[uint]bool
+	if filter.Category != "" {
+		err := r.access.view(ctx, func(d *memoryData) error {
+			subtree = d.categorySubtree(filter.Category)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	title := textnorm.Normalize(filter.TitleContains)
+	items, err := r.articles(ctx, func(a *models.Article) bool {
+		switch {
+		case a.DeletedAt.Valid,
+			filter.Status != "" && a.Status != filter.Status,
+			filter.AuthorID != 0 && a.AuthorID != filter.AuthorID,
+			filter.CreatedAfter != nil && a.CreatedAt.Before(*filter.CreatedAfter),
+			filter.CreatedBefore != nil && !a.CreatedAt.Before(*filter.CreatedBefore),
+			filter.UpdatedSince != nil && a.UpdatedAt.Before(*filter.UpdatedSince),
+			title != "" && !strings.Contains(a.TitleNormalized, title),
+			filter.Tag != "" && !slices.ContainsFunc(a.Tags, func(t models.Tag) bool { return t.Slug == filter.Tag }),
+			filter.Category != "" && !slices.ContainsFunc(a.Categories, func(c models.Category) bool { return subtree[c.ID] }):
+			return false
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(filter.Sort) > 0 {
+		slices.SortStableFunc(items, func(a, b models.Article) int {
+			for _, s := range filter.Sort {
+				c := compareArticles(&a, &b, s.Field)
+				if s.Desc {
+					c = -c
+				}
+				if c != 0 {
+					return c
+				}
+			}
+			return cmp.Compare(a.ID, b.ID)
+		})
+	}
+	return memoryPage(items, req, func(a *models.Article) uint { return a.ID }, len(filter.Sort) > 0)
+}
+
+// compareArticles يقارن مقالين حسب حقل ترتيب من articleSortColumns
+func compareArticles(a, b *models.Article, field string) int {
+	switch field {
+	case "title":
+		return strings.Compare(a.Title, b.Title)
+	case "created_at":
+		return a.CreatedAt.Compare(b.CreatedAt)
+	case "updated_at":
+		return a.UpdatedAt.Compare(b.UpdatedAt)
+	}
+	return cmp.Compare(a.ID, b.ID)
+}
+
+// FindByID يجلب مقالاً غير محذوف مع مؤلفه
+func (r *memoryArticleRepository) FindByID(ctx context.Context, id uint) (*models.Article, error) {
+	return r.findOne(ctx, id, false)
+}
+
+// findOne يجلب مقالاً واحدًا من المقالات الحية أو من سلة المحذوفات
+func (r *memoryArticleRepository) findOne(ctx context.Context, id uint, trashed bool) (*models.Article, error) {
+	var article models.Article
+	err := r.access.view(ctx, func(d *memoryData) error {
+		stored, ok := d.articles[id]
+		if !ok || stored.DeletedAt.Valid != trashed {
+			if trashed {
+				return apperr.NotFound("article_not_in_trash", id)
+			}
+			return apperr.NotFound("article_not_found", id)
+		}
+		article = d.withRelations(stored)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &article, nil
+}
+
+// FindBySlug يجلب مقالاً غير محذوف مع مؤلفه حسب معرفه النصي الحالي
+func (r *memoryArticleRepository) FindBySlug(ctx context.Context, slug string) (*models.Article, error) {
+	items, err := r.articles(ctx, func(a *models.Article) bool { return !a.DeletedAt.Valid && a.Slug == slug })
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, apperr.NotFound("article_slug_not_found", slug)
+	}
+	return &items[0], nil
+}
+
+// TakenSlugs يعيد المعرفات النصية للمقالات (ومنها المحذوفة) التي قد تكون من مرشحي bases
+func (r *memoryArticleRepository) TakenSlugs(ctx context.Context, bases []string) (map[string]uint, error) {
+	taken := make(map[string]uint)
+	err := r.access.view(ctx, func(d *memoryData) error {
+		for _, article := range d.articles {
+			if slugFromAny(article.Slug, bases) {
+				taken[article.Slug] = article.ID
+			}
+		}
+		return nil
+	})
+	return taken, err
+}
+
+// Search يطابق العبارة في العنوان والمحتوى بعد التوحيد بنفس ترتيب بحث LIKE البديل:
+// تطابق العنوان بوزن 2 والمحتوى بوزن 1، ثم المعرف
+func (r *memoryArticleRepository) Search(ctx context.Context, query string, req pagination.Request) (*pagination.Page[ArticleSearchResult], error) {
+	req, err := req.Normalize()
+	if err != nil {
+		return nil, err
+	}
+	if req.IsKeyset() {
+		return nil, ErrCursorWithSearch
+	}
+
+	normalized := textnorm.Normalize(query)
+	articles, err := r.articles(ctx, func(a *models.Article) bool {
+		return !a.DeletedAt.Valid && a.Status == models.ArticleStatusPublished &&
+			(strings.Contains(a.TitleNormalized, normalized) || strings.Contains(a.ContentNormalized, normalized))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]ArticleSearchResult, 0, len(articles))
+	for _, article := range articles {
+		var rank float64
+		if strings.Contains(article.TitleNormalized, normalized) {
+			rank += 2
+		}
+		if strings.Contains(article.ContentNormalized, normalized) {
+			rank++
+		}
+		items = append(items, ArticleSearchResult{Article: article, Rank: rank})
+	}
+	slices.SortStableFunc(items, func(a, b ArticleSearchResult) int { return cmp.Compare(b.Rank, a.Rank) })
+
+	total := int64(len(items))
+	items = items[min(req.Offset, len(items)):]
+	items = items[:min(req.Limit+1, len(items))]
+	for i := range items {
+		items[i].TitleHighlight = highlight(items[i].Title, query)
+		items[i].Snippet = snippet(articleText(&items[i].Article), query)
+	}
+	return searchPage(items, total, req), nil
+}
+
+// ExistsByTitle يتحقق من وجود مقال آخر غير محذوف بنفس العنوان بعد التوحيد
+func (r *memoryArticleRepository) ExistsByTitle(ctx context.Context, title string, excludeID uint) (bool, error) {
+	normalized := textnorm.Normalize(title)
+	var exists bool
+	err := r.access.view(ctx, func(d *memoryData) error {
+		for _, article := range d.articles {
+			if !article.DeletedAt.Valid && article.ID != excludeID && article.TitleNormalized == normalized {
+				exists = true
+				break
+			}
+		}
+		return nil
+	})
+	return exists, err
+}
+
+// Update يحدّث العنوان والمحتوى والحالة مشروطًا برقم النسخة الذي قُرئ به المقال
+func (r *memoryArticleRepository) Update(ctx context.Context, article *models.Article) error {
+	normalizeArticle(article)
+
+	return r.access.update(ctx, func(d *memoryData) error {
+		stored, ok := d.articles[article.ID]
+		switch {
+		case !ok || stored.DeletedAt.Valid:
+			return apperr.NotFound("article_not_found", article.ID)
+		case stored.Version != article.Version:
+			return ErrVersionConflict
+		}
+
+		stored.Title = article.Title
+		stored.Slug = article.Slug
+		stored.Content = article.Content
+		stored.ContentFormat = article.ContentFormat
+		stored.ContentHTML = article.ContentHTML
+		stored.TitleNormalized = article.TitleNormalized
+		stored.ContentNormalized = article.ContentNormalized
+		stored.WordCount = article.WordCount
+		stored.Status = article.Status
+		stored.PublishAt = article.PublishAt
+		stored.PublishedAt = article.PublishedAt
+		stored.UpdatedAt = time.Now()
+		stored.Version++
+		d.articles[stored.ID] = stored
+
+		article.UpdatedAt = stored.UpdatedAt
+		article.Version = stored.Version
+		return nil
+	})
+}
+
+// Delete ينقل المقال إلى سلة المحذوفات
+func (r *memoryArticleRepository) Delete(ctx context.Context, id uint) error {
+	return r.access.update(ctx, func(d *memoryData) error {
+		stored, ok := d.articles[id]
+		if !ok || stored.DeletedAt.Valid {
+			return apperr.NotFound("article_not_found", id)
+		}
+		stored.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+		d.articles[id] = stored
+		return nil
+	})
+}
+
+// FindTrash يجلب صفحة من المقالات الموجودة في سلة المحذوفات
+func (r *memoryArticleRepository) FindTrash(ctx context.Context, req pagination.Request) (*pagination.Page[models.Article], error) {
+	items, err := r.articles(ctx, func(a *models.Article) bool { return a.DeletedAt.Valid })
+	if err != nil {
+		return nil, err
+	}
+	return memoryPage(items, req, func(a *models.Article) uint { return a.ID }, false)
+}
+
+// FindTrashedByID يجلب مقالاً من سلة المحذوفات مع مؤلفه
+func (r *memoryArticleRepository) FindTrashedByID(ctx context.Context, id uint) (*models.Article, error) {
+	return r.findOne(ctx, id, true)
+}
+
+// Restore يعيد المقال من سلة المحذوفات مشروطًا برقم النسخة الذي قُرئ به
+func (r *memoryArticleRepository) Restore(ctx context.Context, article *models.Article) error {
+	return r.access.update(ctx, func(d *memoryData) error {
+		stored, ok := d.articles[article.ID]
+		switch {
+		case !ok || !stored.DeletedAt.Valid:
+			return apperr.NotFound("article_not_in_trash", article.ID)
+		case stored.Version != article.Version:
+			return ErrVersionConflict
+		}
+
+		stored.DeletedAt = gorm.DeletedAt{}
+		stored.UpdatedAt = time.Now()
+		stored.Version++
+		d.articles[stored.ID] = stored
+
+		article.DeletedAt = stored.DeletedAt
+		article.UpdatedAt = stored.UpdatedAt
+		article.Version = stored.Version
+		return nil
+	})
+}
+
+// deleteArticle يحذف المقال فعليًا مع مراجعاته ومعرفاته السابقة وصفوف ربطه كما يفعل ON DELETE CASCADE
+func (d *memoryData) deleteArticle(id uint) {
+	delete(d.articles, id)
+	for revID, rev := range d.revisions {
+		if rev.ArticleID == id {
+			delete(d.revisions, revID)
+		}
+	}
+	for slug, entry := range d.slugHistory {
+		if entry.ArticleID == id {
+			delete(d.slugHistory, slug)
+		}
+	}
+	delete(d.articleTags, id)
+	delete(d.articleCategories, id)
+	for commentID, comment := range d.comments {
+		if comment.ArticleID == id {
+			delete(d.comments, commentID)
+		}
+	}
+}
+
+// Purge يحذف المقال نهائيًا سواء كان في السلة أم لا
+func (r *memoryArticleRepository) Purge(ctx context.Context, id uint) error {
+	return r.access.update(ctx, func(d *memoryData) error {
+		if _, ok := d.articles[id]; !ok {
+			return apperr.NotFound("article_not_found", id)
+		}
+		d.deleteArticle(id)
+		return nil
+	})
+}
+
+// PurgeDeletedBefore يحذف نهائيًا المقالات التي حُذفت منطقيًا قبل cutoff ويعيد عددها
+func (r *memoryArticleRepository) PurgeDeletedBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	var purged int64
+	err := r.access.update(ctx, func(d *memoryData) error {
+		for id, article := range d.articles {
+			if article.DeletedAt.Valid && article.DeletedAt.Time.Before(cutoff) {
+				d.deleteArticle(id)
+				purged++
+			}
+		}
+		return nil
+	})
+	return purged, err
+}
+
+// CountByAuthor يعيد عدد مقالات المؤلف بما فيها الموجودة في سلة المحذوفات
+func (r *memoryArticleRepository) CountByAuthor(ctx context.Context, authorID uint) (int64, error) {
+	var count int64
+	err := r.access.view(ctx, func(d *memoryData) error {
+		for _, article := range d.articles {
+			if article.AuthorID == authorID {
+				count++
+			}
+		}
+		return nil
+	})
+	return count, err
+}
+
+// DeleteByAuthor ينقل جميع مقالات المؤلف إلى سلة المحذوفات ويعيد عددها
+func (r *memoryArticleRepository) DeleteByAuthor(ctx context.Context, authorID uint) (int64, error) {
+	var deleted int64
+	err := r.access.update(ctx, func(d *memoryData) error {
+		now := time.Now()
+		for id, article := range d.articles {
+			if article.AuthorID == authorID && !article.DeletedAt.Valid {
+				article.DeletedAt = gorm.DeletedAt{Time: now, Valid: true}
+				d.articles[id] = article
+				deleted++
+			}
+		}
+		return nil
+	})
+	return deleted, err
+}
+
+// ReassignAuthor ينقل جميع مقالات المؤلف (بما فيها المحذوفة) إلى مؤلف آخر ويزيد أرقام نسخها
+func (r *memoryArticleRepository) ReassignAuthor(ctx context.Context, fromID, toID uint) (int64, error) {
+	var moved int64
+	err := r.access.update(ctx, func(d *memoryData) error {
+		if _, ok := d.authors[toID]; !ok {
+			return apperr.Conflict("constraint_violation")
+		}
+		now := time.Now()
+		for id, article := range d.articles {
+			if article.AuthorID == fromID {
+				article.AuthorID = toID
+				article.Version++
+				article.UpdatedAt = now
+				d.articles[id] = article
+				moved++
+			}
+		}
+		return nil
+	})
+	return moved, err
+}
+
+// PublishDue ينشر المقالات المجدولة غير المحذوفة التي حان موعدها ويعيد عددها
+func (r *memoryArticleRepository) PublishDue(ctx context.Context, now time.Time) (int64, error) {
+	var published int64
+	err := r.access.update(ctx, func(d *memoryData) error {
+		for id, article := range d.articles {
+			if article.DeletedAt.Valid || article.Status != models.ArticleStatusScheduled ||
+				article.PublishAt == nil || article.PublishAt.After(now) {
+				continue
+			}
+			article.Status = models.ArticleStatusPublished
+			article.PublishedAt, article.PublishAt = article.PublishAt, nil
+			article.Version++
+			article.UpdatedAt = now
+			d.articles[id] = article
+			published++
+		}
+		return nil
+	})
+	return published, err
+}
+
+// CreateBatch ينشئ عدة مقالات دفعة واحدة؛ يتحقق من وجود كل المؤلفين أولاً فلا يُنشأ شيء إذا فشل أحدها
+// batchSize لا أثر له هنا لأن الإدخال كله تحت قفل واحد
+func (r *memoryArticleRepository) CreateBatch(ctx context.Context, articles []*models.Article, batchSize int) error {
+	for _, article := range articles {
+		normalizeArticle(article)
+		if article.Version == 0 {
+			article.Version = 1
+		}
+	}
+
+	return r.access.update(ctx, func(d *memoryData) error {
+		for _, article := range articles {
+			if _, ok := d.authors[article.AuthorID]; !ok {
+				return apperr.Conflict("constraint_violation")
+			}
+		}
+		now := time.Now()
+		for _, article := range articles {
+			d.nextArticleID++
+			article.ID = d.nextArticleID
+			article.CreatedAt, article.UpdatedAt = now, now
+
+			stored := *article
+			stored.Author = models.Author{}
+			d.articles[stored.ID] = stored
+		}
+		return nil
+	})
+}
+
+// ExistingTitles يعيد العناوين الموحدة من بين titles التي تطابق عنوان مقال غير محذوف
+func (r *memoryArticleRepository) ExistingTitles(ctx context.Context, titles []string) (map[string]bool, error) {
+	wanted := make(map[string]bool, len(titles))
+	for _, title := range titles {
+		wanted[textnorm.Normalize(title)] = true
+	}
+
+	existing := make(map[string]bool)
+	err := r.access.view(ctx, func(d *memoryData) error {
+		for _, article := range d.articles {
+			if !article.DeletedAt.Valid && wanted[article.TitleNormalized] {
+				existing[article.TitleNormalized] = true
+			}
+		}
+		return nil
+	})
+	return existing, err
+}
@@ -0,0 +1,340 @@
+// my-article-app/internal/repository/memory_author_repository.go
+package repository
+
+import (
+	"cmp"
+	"context"
+	"my-article-app/internal/apperr"
+	"my-article-app/internal/models"
+	"my-article-app/internal/pagination"
+	"my-article-app/internal/textnorm"
+	"slices"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// memoryAuthorRepository تنفيذ AuthorRepository في الذاكرة بنفس سلوك تنفيذ GORM:
+// الحذف منطقي، والبريد فريد حتى بين المؤلفين المحذوفين كما في الفهرس الفريد
+type memoryAuthorRepository struct {
+	access memoryAccess
+}
+
+// NewMemoryAuthorRepository ينشئ AuthorRepository يعمل على تخزين في الذاكرة
+func NewMemoryAuthorRepository(store *MemoryStore) AuthorRepository {
+	return &memoryAuthorRepository{access: store}
+}
+
+// liveAuthor يعيد المؤلف إذا كان موجودًا وغير محذوف
+func (d *memoryData) liveAuthor(id uint) (models.Author, bool) {
+	author, ok := d.authors[id]
+	if !ok || author.DeletedAt.Valid {
+		return models.Author{}, false
+	}
+	return author, true
+}
+
+// uniqueConflict يتحقق من أن بريد المؤلف ومعرفه النصي غير مستخدمين لمؤلف آخر، محذوفًا كان أم لا
+func (d *memoryData) uniqueConflict(author *models.Author, excludeID uint) error {
+	slugTaken := false
+	for _, other := range d.authors {
+		switch {
+		case other.ID == excludeID:
+		case other.Email == author.Email:
+			return apperr.Conflict("author_email_taken", author.Email)
+		case other.Slug == author.Slug:
+			slugTaken = true
+		}
+	}
+	if slugTaken {
+		return apperr.Conflict("author_slug_taken", author.Slug)
+	}
+	return nil
+}
+
+// Create ينشئ مؤلفًا جديدًا ويملأ معرفه وتواريخه
+func (r *memoryAuthorRepository) Create(ctx context.Context, author *models.Author) error {
+	normalizeAuthor(author)
+	if author.Version == 0 {
+		author.Version = 1
+	}
+
+	return r.access.update(ctx, func(d *memoryData) error {
+		if err := d.uniqueConflict(author, 0); err != nil {
+			return err
+		}
+		d.nextAuthorID++
+		now := time.Now()
+		author.ID = d.nextAuthorID
+		author.CreatedAt, author.UpdatedAt = now, now
+
+		stored := *author
+		stored.Articles = nil
+		d.authors[stored.ID] = stored
+		return nil
+	})
+}
+
+// FindAll يجلب صفحة من المؤلفين غير المحذوفين مرتبة حسب المعرف أو حسب filter.Sort
+func (r *memoryAuthorRepository) FindAll(ctx context.Context, filter AuthorFilter, req pagination.Request) (*pagination.Page[models.Author], error) {
+	page, err := r.FindAllWithStats(ctx, filter, req)
+	if err != nil {
+		return nil, err
+	}
+
+	authors := &pagination.Page[models.Author]{
+		Items:      make([]models.Author, 0, len(page.Items)),
+		Total:      page.Total,
+		Limit:      page.Limit,
+		Offset:     page.Offset,
+		HasMore:    page.HasMore,
+		NextCursor: page.NextCursor,
+	}
+	for _, item := range page.Items {
+		authors.Items = append(authors.Items, item.Author)
+	}
+	return authors, nil
+}
+
+// FindAllWithStats يجلب صفحة من المؤلفين مع إحصاءات مقالاتهم غير المحذوفة
+func (r *memoryAuthorRepository) FindAllWithStats(ctx context.Context, filter AuthorFilter, req pagination.Request) (*pagination.Page[AuthorWithStats], error) {
+	// التحقق من حقول الترتيب بنفس القائمة المسموحة في تنفيذ GORM
+	if _, err := orderClauses(filter.Sort, authorSortColumns); err != nil {
+		return nil, err
+	}
+	name := textnorm.Normalize(filter.Name)
+
+	var items []AuthorWithStats
+	err := r.access.view(ctx, func(d *memoryData) error {
+		stats := d.articleStats()
+		for _, author := range d.authors {
+			if author.DeletedAt.Valid || (name != "" && !strings.Contains(author.NameNormalized, name)) {
+				continue
+			}
+			items = append(items, AuthorWithStats{Author: author, Stats: stats[author.ID]})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(items, func(a, b AuthorWithStats) int {
+		for _, s := range filter.Sort {
+			if c := compareAuthors(&a, &b, s); c != 0 {
+				return c
+			}
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return memoryPage(items, req, func(a *AuthorWithStats) uint { return a.ID }, len(filter.Sort) > 0)
+}
+
+// articleStats يحسب إحصاءات المقالات المنشورة غير المحذوفة لكل مؤلف كما يفعل الاستعلام المجمّع
+func (d *memoryData) articleStats() map[uint]AuthorStats {
+	stats := make(map[uint]AuthorStats)
+	for _, article := range d.articles {
+		if article.DeletedAt.Valid || article.Status != models.ArticleStatusPublished {
+			continue
+		}
+		s := stats[article.AuthorID]
+		s.ArticleCount++
+		s.TotalWords += int64(article.WordCount)
+		if published := article.PublishedAt; published != nil {
+			if s.FirstPublishedAt == nil || published.Before(*s.FirstPublishedAt) {
+				s.FirstPublishedAt = published
+			}
+			if s.LastPublishedAt == nil || published.After(*s.LastPublishedAt) {
+				s.LastPublishedAt = published
+			}
+		}
+		stats[article.AuthorID] = s
+	}
+	return stats
+}
+
+// compareAuthors يقارن مؤلفين حسب حقل ترتيب من authorSortColumns؛
+// التاريخ الغائب (مؤلف بلا مقالات) يأتي أخيرًا في الاتجاهين كما في تنفيذ GORM
+func compareAuthors(a, b *AuthorWithStats, s SortField) int {
+	var c int
+	switch s.Field {
+	case "name":
+		c = strings.Compare(a.Name, b.Name)
+	case "created_at":
+		c = a.CreatedAt.Compare(b.CreatedAt)
+	case "article_count":
+		c = cmp.Compare(a.Stats.ArticleCount, b.Stats.ArticleCount)
+	case "total_words":
+		c = cmp.Compare(a.Stats.TotalWords, b.Stats.TotalWords)
+	case "first_published_at":
+		return compareOptionalTime(a.Stats.FirstPublishedAt, b.Stats.FirstPublishedAt, s.Desc)
+	case "last_published_at":
+		return compareOptionalTime(a.Stats.LastPublishedAt, b.Stats.LastPublishedAt, s.Desc)
+	default:
+		c = cmp.Compare(a.ID, b.ID)
+	}
+	if s.Desc {
+		return -c
+	}
+	return c
+}
+
+// compareOptionalTime يقارن تاريخين اختياريين مع وضع nil أخيرًا بغض النظر عن الاتجاه
+func compareOptionalTime(a, b *time.Time, desc bool) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	}
+	if desc {
+		return b.Compare(*a)
+	}
+	return a.Compare(*b)
+}
+
+// FindByID يجلب المؤلف مع مقالاته المنشورة غير المحذوفة
+func (r *memoryAuthorRepository) FindByID(ctx context.Context, id uint) (*models.Author, error) {
+	return r.findWithArticles(ctx, func(d *memoryData) (models.Author, error) {
+		author, ok := d.liveAuthor(id)
+		if !ok {
+			return author, apperr.NotFound("author_not_found", id)
+		}
+		return author, nil
+	})
+}
+
+// FindBySlug يجلب المؤلف غير المحذوف مع مقالاته المنشورة حسب معرفه النصي
+func (r *memoryAuthorRepository) FindBySlug(ctx context.Context, slug string) (*models.Author, error) {
+	return r.findWithArticles(ctx, func(d *memoryData) (models.Author, error) {
+		for _, author := range d.authors {
+			if !author.DeletedAt.Valid && author.Slug == slug {
+				return author, nil
+			}
+		}
+		return models.Author{}, apperr.NotFound("author_slug_not_found", slug)
+	})
+}
+
+// findWithArticles يجلب المؤلف الذي تختاره find ويرفق مقالاته المنشورة غير المحذوفة مرتبة بالمعرف مع وسومها وتصنيفاتها
+func (r *memoryAuthorRepository) findWithArticles(ctx context.Context, find func(d *memoryData) (models.Author, error)) (*models.Author, error) {
+	var author models.Author
+	err := r.access.view(ctx, func(d *memoryData) error {
+		var err error
+		if author, err = find(d); err != nil {
+			return err
+		}
+		for _, article := range d.articles {
+			if article.AuthorID == author.ID && !article.DeletedAt.Valid && article.Status == models.ArticleStatusPublished {
+				author.Articles = append(author.Articles, d.withTaxonomy(article))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(author.Articles, func(a, b models.Article) int { return cmp.Compare(a.ID, b.ID) })
+	return &author, nil
+}
+
+// TakenSlugs يعيد المعرفات النصية للمؤلفين (ومنهم المحذوفون) التي قد تكون من مرشحي bases
+func (r *memoryAuthorRepository) TakenSlugs(ctx context.Context, bases []string) (map[string]uint, error) {
+	taken := make(map[string]uint)
+	err := r.access.view(ctx, func(d *memoryData) error {
+		for _, author := range d.authors {
+			if slugFromAny(author.Slug, bases) {
+				taken[author.Slug] = author.ID
+			}
+		}
+		return nil
+	})
+	return taken, err
+}
+
+// FindByIDForShare يجلب المؤلف دون مقالاته؛ لا حاجة لقفل الصف لأن المعاملة تمسك قفل التخزين كاملاً
+func (r *memoryAuthorRepository) FindByIDForShare(ctx context.Context, id uint) (*models.Author, error) {
+	return r.find(ctx, id)
+}
+
+// FindByIDForUpdate مثل FindByIDForShare
+func (r *memoryAuthorRepository) FindByIDForUpdate(ctx context.Context, id uint) (*models.Author, error) {
+	return r.find(ctx, id)
+}
+
+// FindByIDsForShare يجلب المؤلفين غير المحذوفين الموجودين من بين ids مفهرسين بالمعرف
+func (r *memoryAuthorRepository) FindByIDsForShare(ctx context.Context, ids []uint) (map[uint]models.Author, error) {
+	found := make(map[uint]models.Author, len(ids))
+	err := r.access.view(ctx, func(d *memoryData) error {
+		for _, id := range ids {
+			if author, ok := d.liveAuthor(id); ok {
+				found[id] = author
+			}
+		}
+		return nil
+	})
+	return found, err
+}
+
+// find يجلب المؤلف غير المحذوف دون مقالاته، ويعيد خطأ NotFound إذا لم يوجد
+func (r *memoryAuthorRepository) find(ctx context.Context, id uint) (*models.Author, error) {
+	var author models.Author
+	err := r.access.view(ctx, func(d *memoryData) error {
+		var ok bool
+		if author, ok = d.liveAuthor(id); !ok {
+			return apperr.NotFound("author_not_found", id)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &author, nil
+}
+
+// Update يحدّث بيانات المؤلف مشروطًا برقم النسخة الذي قُرئ به، كما في تنفيذ GORM
+func (r *memoryAuthorRepository) Update(ctx context.Context, author *models.Author) error {
+	normalizeAuthor(author)
+
+	return r.access.update(ctx, func(d *memoryData) error {
+		stored, ok := d.liveAuthor(author.ID)
+		switch {
+		case !ok:
+			return apperr.NotFound("author_not_found", author.ID)
+		case stored.Version != author.Version:
+			return ErrVersionConflict
+		}
+		if err := d.uniqueConflict(author, author.ID); err != nil {
+			return err
+		}
+
+		stored.Name = author.Name
+		stored.Email = author.Email
+		stored.Slug = author.Slug
+		stored.NameNormalized = author.NameNormalized
+		stored.UpdatedAt = time.Now()
+		stored.Version++
+		d.authors[stored.ID] = stored
+
+		author.UpdatedAt = stored.UpdatedAt
+		author.Version = stored.Version
+		return nil
+	})
+}
+
+// Delete يحذف المؤلف منطقيًا
+func (r *memoryAuthorRepository) Delete(ctx context.Context, id uint) error {
+	return r.access.update(ctx, func(d *memoryData) error {
+		stored, ok := d.liveAuthor(id)
+		if !ok {
+			return apperr.NotFound("author_not_found", id)
+		}
+		stored.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+		d.authors[id] = stored
+		return nil
+	})
+}
@@ -0,0 +1,213 @@
+// my-article-app/internal/repository/memory_category_repository.go
+package repository
+
+import (
+	"cmp"
+	"context"
+	"my-article-app/internal/apperr"
+	"my-article-app/internal/models"
+	"slices"
+	"time"
+)
+
+// memoryCategoryRepository تنفيذ CategoryRepository في الذاكرة بنفس سلوك تنفيذ GORM:
+// المعرف النصي فريد، والأب يجب أن يوجد ولا يُحذف تصنيف له تصنيفات فرعية، وحذف التصنيف يزيل ربطه بالمقالات
+type memoryCategoryRepository struct {
+	access memoryAccess
+}
+
+// categorySubtree يعيد معرفات التصنيف الذي يحمل المعرف النصي وكل تصنيفاته الفرعية، أو nil إذا لم يوجد
+func (d *memoryData) categorySubtree(slug string) map[uint]bool {
+	subtree := make(map[uint]bool)
+	for _, category := range d.categories {
+		if category.Slug == slug {
+			subtree[category.ID] = true
+		}
+	}
+	// كل دورة تضيف أبناء ما أُضيف قبلها، حتى لا يبقى ابن جديد
+	for grew := len(subtree) > 0; grew; {
+		grew = false
+		for _, category := range d.categories {
+			if category.ParentID != nil && subtree[*category.ParentID] && !subtree[category.ID] {
+				subtree[category.ID] = true
+				grew = true
+			}
+		}
+	}
+	return subtree
+}
+
+// checkCategory يتحقق من تفرد المعرف النصي ومن وجود الأب كما يفعل القيدان الفريد والأجنبي
+func (d *memoryData) checkCategory(category *models.Category) error {
+	for _, other := range d.categories {
+		if other.ID != category.ID && other.Slug == category.Slug {
+			return apperr.Conflict("category_exists", category.Slug)
+		}
+	}
+	if category.ParentID != nil {
+		if _, ok := d.categories[*category.ParentID]; !ok {
+			return apperr.Conflict("constraint_violation")
+		}
+	}
+	return nil
+}
+
+// Create ينشئ تصنيفًا جديدًا ويملأ معرفه وتواريخه
+func (r *memoryCategoryRepository) Create(ctx context.Context, category *models.Category) error {
+	return r.access.update(ctx, func(d *memoryData) error {
+		if err := d.checkCategory(category); err != nil {
+			return err
+		}
+		d.nextCategoryID++
+		now := time.Now()
+		category.ID = d.nextCategoryID
+		category.CreatedAt, category.UpdatedAt = now, now
+		d.categories[category.ID] = *category
+		return nil
+	})
+}
+
+// FindAll يجلب كل التصنيفات مرتبة حسب المعرف
+func (r *memoryCategoryRepository) FindAll(ctx context.Context) ([]models.Category, error) {
+	var categories []models.Category
+	err := r.access.view(ctx, func(d *memoryData) error {
+		for _, category := range d.categories {
+			categories = append(categories, category)
+		}
+		return nil
+	})
+	slices.SortFunc(categories, func(a, b models.Category) int { return cmp.Compare(a.ID, b.ID) })
+	return categories, err
+}
+
+// FindByID يجلب تصنيفًا واحدًا حسب المعرف
+func (r *memoryCategoryRepository) FindByID(ctx context.Context, id uint) (*models.Category, error) {
+	var category models.Category
+	err := r.access.view(ctx, func(d *memoryData) error {
+		var ok bool
+		if category, ok = d.categories[id]; !ok {
+			return apperr.NotFound("category_not_found", id)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+// FindByIDs يجلب التصنيفات الموجودة من بين ids مفهرسة بالمعرف
+func (r *memoryCategoryRepository) FindByIDs(ctx context.Context, ids []uint) (map[uint]models.Category, error) {
+	found := make(map[uint]models.Category, len(ids))
+	err := r.access.view(ctx, func(d *memoryData) error {
+		for _, id := range ids {
+			if category, ok := d.categories[id]; ok {
+				found[id] = category
+			}
+		}
+		return nil
+	})
+	return found, err
+}
+
+// CountChildren يعيد عدد التصنيفات الفرعية المباشرة للتصنيف
+func (r *memoryCategoryRepository) CountChildren(ctx context.Context, id uint) (int64, error) {
+	var count int64
+	err := r.access.view(ctx, func(d *memoryData) error {
+		for _, category := range d.categories {
+			if category.ParentID != nil && *category.ParentID == id {
+				count++
+			}
+		}
+		return nil
+	})
+	return count, err
+}
+
+// Update يحفظ اسم التصنيف ومعرفه النصي وأباه
+func (r *memoryCategoryRepository) Update(ctx context.Context, category *models.Category) error {
+	return r.access.update(ctx, func(d *memoryData) error {
+		stored, ok := d.categories[category.ID]
+		if !ok {
+			return apperr.NotFound("category_not_found", category.ID)
+		}
+		if err := d.checkCategory(category); err != nil {
+			return err
+		}
+
+		stored.Name = category.Name
+		stored.Slug = category.Slug
+		stored.ParentID = category.ParentID
+		stored.UpdatedAt = time.Now()
+		d.categories[stored.ID] = stored
+		category.UpdatedAt = stored.UpdatedAt
+		return nil
+	})
+}
+
+// Delete يحذف التصنيف ويزيله من تصنيفات المقالات، ويرفض حذف تصنيف له تصنيفات فرعية كقيد الأب
+func (r *memoryCategoryRepository) Delete(ctx context.Context, id uint) error {
+	return r.access.update(ctx, func(d *memoryData) error {
+		if _, ok := d.categories[id]; !ok {
+			return apperr.NotFound("category_not_found", id)
+		}
+		for _, category := range d.categories {
+			if category.ParentID != nil && *category.ParentID == id {
+				return apperr.Conflict("constraint_violation")
+			}
+		}
+		delete(d.categories, id)
+		for articleID, categoryIDs := range d.articleCategories {
+			if slices.Contains(categoryIDs, id) {
+				d.articleCategories[articleID] = slices.DeleteFunc(slices.Clone(categoryIDs), func(c uint) bool { return c == id })
+			}
+		}
+		return nil
+	})
+}
+
+// ReplaceArticleCategories يستبدل تصنيفات المقال بـ categoryIDs
+func (r *memoryCategoryRepository) ReplaceArticleCategories(ctx context.Context, articleID uint, categoryIDs []uint) error {
+	return r.access.update(ctx, func(d *memoryData) error {
+		if err := d.checkArticleCategories(articleID, categoryIDs); err != nil {
+			return err
+		}
+		if len(categoryIDs) == 0 {
+			delete(d.articleCategories, articleID)
+		} else {
+			d.articleCategories[articleID] = slices.Clone(categoryIDs)
+		}
+		return nil
+	})
+}
+
+// LinkArticles يضيف صفوف ربط بين مقالات وتصنيفات؛ يتحقق منها كلها أولاً فلا يُضاف شيء إذا فشل أحدها
+func (r *memoryCategoryRepository) LinkArticles(ctx context.Context, links []models.ArticleCategory, batchSize int) error {
+	return r.access.update(ctx, func(d *memoryData) error {
+		for _, link := range links {
+			if err := d.checkArticleCategories(link.ArticleID, []uint{link.CategoryID}); err != nil {
+				return err
+			}
+			if slices.Contains(d.articleCategories[link.ArticleID], link.CategoryID) {
+				return apperr.Conflict("constraint_violation")
+			}
+		}
+		for _, link := range links {
+			d.articleCategories[link.ArticleID] = append(slices.Clone(d.articleCategories[link.ArticleID]), link.CategoryID)
+		}
+		return nil
+	})
+}
+
+// checkArticleCategories يتحقق من وجود المقال والتصنيفات كما تفعل قيود المفاتيح الأجنبية لجدول الربط
+func (d *memoryData) checkArticleCategories(articleID uint, categoryIDs []uint) error {
+	if _, ok := d.articles[articleID]; !ok {
+		return apperr.Conflict("constraint_violation")
+	}
+	for _, id := range categoryIDs {
+		if _, ok := d.categories[id]; !ok {
+			return apperr.Conflict("constraint_violation")
+		}
+	}
+	return nil
+}
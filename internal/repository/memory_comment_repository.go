@@ -0,0 +1,173 @@
+// my-article-app/internal/repository/memory_comment_repository.go
+package repository
+
+import (
+	"cmp"
+	"context"
+	"my-article-app/internal/apperr"
+	"my-article-app/internal/models"
+	"my-article-app/internal/pagination"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// memoryCommentRepository تنفيذ CommentRepository في الذاكرة بنفس سلوك تنفيذ GORM:
+// الحذف منطقي، والتعليق يتطلب وجود مقاله وأبيه كقيدي المفتاحين الأجنبيين
+type memoryCommentRepository struct {
+	access memoryAccess
+}
+
+// Create ينشئ تعليقًا جديدًا ويملأ معرفه وتواريخه
+func (r *memoryCommentRepository) Create(ctx context.Context, comment *models.Comment) error {
+	return r.access.update(ctx, func(d *memoryData) error {
+		if _, ok := d.articles[comment.ArticleID]; !ok {
+			return apperr.Conflict("constraint_violation")
+		}
+		if comment.ParentID != nil {
+			if _, ok := d.comments[*comment.ParentID]; !ok {
+				return apperr.Conflict("constraint_violation")
+			}
+		}
+		if comment.Status == "" {
+			comment.Status = models.CommentStatusPending
+		}
+		d.nextCommentID++
+		now := time.Now()
+		comment.ID = d.nextCommentID
+		comment.CreatedAt, comment.UpdatedAt = now, now
+		d.comments[comment.ID] = *comment
+		return nil
+	})
+}
+
+// FindByID يجلب تعليقًا واحدًا غير محذوف حسب المعرف
+func (r *memoryCommentRepository) FindByID(ctx context.Context, id uint) (*models.Comment, error) {
+	var comment models.Comment
+	err := r.access.view(ctx, func(d *memoryData) error {
+		var ok bool
+		if comment, ok = d.comments[id]; !ok || comment.DeletedAt.Valid {
+			return apperr.NotFound("comment_not_found", id)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// FindThreads يجلب صفحة من التعليقات الجذرية المعتمدة للمقال، والمحذوف منها يبقى إذا كان له رد ظاهر
+func (r *memoryCommentRepository) FindThreads(ctx context.Context, articleID uint, req pagination.Request) (*pagination.Page[models.Comment], error) {
+	var items []models.Comment
+	err := r.access.view(ctx, func(d *memoryData) error {
+		answered := make(map[uint]bool)
+		for _, comment := range d.comments {
+			if comment.ThreadID != nil && comment.Status == models.CommentStatusApproved && !comment.DeletedAt.Valid {
+				answered[*comment.ThreadID] = true
+			}
+		}
+		for _, comment := range d.comments {
+			if comment.ArticleID == articleID && comment.ParentID == nil && comment.Status == models.CommentStatusApproved &&
+				(!comment.DeletedAt.Valid || answered[comment.ID]) {
+				items = append(items, comment)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sortComments(items)
+	return memoryPage(items, req, func(c *models.Comment) uint { return c.ID }, false)
+}
+
+// FindReplies يجلب الردود المعتمدة في السلاسل threadIDs مرتبة من الأقدم، ومنها المحذوفة
+func (r *memoryCommentRepository) FindReplies(ctx context.Context, threadIDs []uint) ([]models.Comment, error) {
+	var replies []models.Comment
+	err := r.access.view(ctx, func(d *memoryData) error {
+		for _, comment := range d.comments {
+			if comment.ThreadID != nil && slices.Contains(threadIDs, *comment.ThreadID) && comment.Status == models.CommentStatusApproved {
+				replies = append(replies, comment)
+			}
+		}
+		return nil
+	})
+	sortComments(replies)
+	return replies, err
+}
+
+// FindQueue يجلب صفحة من التعليقات غير المحذوفة بالحالة status من كل المقالات، الأقدم أولاً
+func (r *memoryCommentRepository) FindQueue(ctx context.Context, status string, req pagination.Request) (*pagination.Page[models.Comment], error) {
+	var items []models.Comment
+	err := r.access.view(ctx, func(d *memoryData) error {
+		for _, comment := range d.comments {
+			if comment.Status == status && !comment.DeletedAt.Valid {
+				items = append(items, comment)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sortComments(items)
+	return memoryPage(items, req, func(c *models.Comment) uint { return c.ID }, false)
+}
+
+// sortComments يرتب التعليقات حسب المعرف، أي من الأقدم
+func sortComments(comments []models.Comment) {
+	slices.SortFunc(comments, func(a, b models.Comment) int { return cmp.Compare(a.ID, b.ID) })
+}
+
+// Update يحفظ نص التعليق وحالته وبيانات تعديله ومراجعته
+func (r *memoryCommentRepository) Update(ctx context.Context, comment *models.Comment) error {
+	return r.access.update(ctx, func(d *memoryData) error {
+		stored, ok := d.comments[comment.ID]
+		if !ok || stored.DeletedAt.Valid {
+			return apperr.NotFound("comment_not_found", comment.ID)
+		}
+
+		stored.Body = comment.Body
+		stored.Status = comment.Status
+		stored.EditedAt = comment.EditedAt
+		stored.ModeratedAt = comment.ModeratedAt
+		stored.ModeratedBy = comment.ModeratedBy
+		stored.UpdatedAt = time.Now()
+		d.comments[stored.ID] = stored
+		comment.UpdatedAt = stored.UpdatedAt
+		return nil
+	})
+}
+
+// Delete يحذف التعليق حذفًا منطقيًا
+func (r *memoryCommentRepository) Delete(ctx context.Context, id uint) error {
+	return r.access.update(ctx, func(d *memoryData) error {
+		stored, ok := d.comments[id]
+		if !ok || stored.DeletedAt.Valid {
+			return apperr.NotFound("comment_not_found", id)
+		}
+		stored.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+		d.comments[id] = stored
+		return nil
+	})
+}
+
+// RefreshArticleCount يعيد حساب عدد التعليقات المعتمدة غير المحذوفة للمقال دون تغيير نسخته
+func (r *memoryCommentRepository) RefreshArticleCount(ctx context.Context, articleID uint) error {
+	return r.access.update(ctx, func(d *memoryData) error {
+		article, ok := d.articles[articleID]
+		if !ok {
+			return nil
+		}
+		article.CommentCount = 0
+		for _, comment := range d.comments {
+			if comment.ArticleID == articleID && comment.Status == models.CommentStatusApproved && !comment.DeletedAt.Valid {
+				article.CommentCount++
+			}
+		}
+		d.articles[articleID] = article
+		return nil
+	})
+}
@@ -0,0 +1,123 @@
+// my-article-app/internal/repository/memory_revision_repository.go
+package repository
+
+import (
+	"cmp"
+	"context"
+	"my-article-app/internal/apperr"
+	"my-article-app/internal/models"
+	"my-article-app/internal/pagination"
+	"slices"
+	"time"
+)
+
+// memoryRevisionRepository تنفيذ RevisionRepository في الذاكرة بنفس سلوك تنفيذ GORM:
+// المقال المشار إليه يجب أن يوجد، ورقم المراجعة فريد داخل المقال
+type memoryRevisionRepository struct {
+	access memoryAccess
+}
+
+// NewMemoryRevisionRepository ينشئ RevisionRepository يعمل على تخزين في الذاكرة
+func NewMemoryRevisionRepository(store *MemoryStore) RevisionRepository {
+	return &memoryRevisionRepository{access: store}
+}
+
+// articleRevisions يعيد مراجعات المقال مرتبة برقم المراجعة
+func (d *memoryData) articleRevisions(articleID uint) []models.ArticleRevision {
+	var revisions []models.ArticleRevision
+	for _, rev := range d.revisions {
+		if rev.ArticleID == articleID {
+			revisions = append(revisions, rev)
+		}
+	}
+	slices.SortFunc(revisions, func(a, b models.ArticleRevision) int { return cmp.Compare(a.Revision, b.Revision) })
+	return revisions
+}
+
+// insertRevision يحفظ المراجعة بعد التحقق من قيد المقال والقيد الفريد
+func (d *memoryData) insertRevision(revision *models.ArticleRevision, now time.Time) error {
+	if _, ok := d.articles[revision.ArticleID]; !ok {
+		return apperr.Conflict("constraint_violation")
+	}
+	for _, rev := range d.revisions {
+		if rev.ArticleID == revision.ArticleID && rev.Revision == revision.Revision {
+			return ErrVersionConflict
+		}
+	}
+	d.nextRevisionID++
+	revision.ID = d.nextRevisionID
+	revision.CreatedAt = now
+	d.revisions[revision.ID] = *revision
+	return nil
+}
+
+// Append يضيف مراجعة برقم يلي آخر مراجعة للمقال
+func (r *memoryRevisionRepository) Append(ctx context.Context, revision *models.ArticleRevision) error {
+	return r.access.update(ctx, func(d *memoryData) error {
+		revision.Revision = 1
+		if existing := d.articleRevisions(revision.ArticleID); len(existing) > 0 {
+			revision.Revision = existing[len(existing)-1].Revision + 1
+		}
+		return d.insertRevision(revision, time.Now())
+	})
+}
+
+// CreateBatch يدرج مراجعات بأرقام يحددها المستدعي؛ لا يُحفظ شيء إذا فشلت إحداها
+func (r *memoryRevisionRepository) CreateBatch(ctx context.Context, revisions []*models.ArticleRevision, batchSize int) error {
+	return r.access.update(ctx, func(d *memoryData) error {
+		for _, revision := range revisions {
+			if _, ok := d.articles[revision.ArticleID]; !ok {
+				return apperr.Conflict("constraint_violation")
+			}
+		}
+		now := time.Now()
+		for _, revision := range revisions {
+			if err := d.insertRevision(revision, now); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// FindByArticle يجلب صفحة من مراجعات المقال مرتبة برقم المراجعة
+func (r *memoryRevisionRepository) FindByArticle(ctx context.Context, articleID uint, req pagination.Request) (*pagination.Page[models.ArticleRevision], error) {
+	var revisions []models.ArticleRevision
+	err := r.access.view(ctx, func(d *memoryData) error {
+		revisions = d.articleRevisions(articleID)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return memoryPage(revisions, req, func(rev *models.ArticleRevision) uint { return rev.Revision }, false)
+}
+
+// FindOne يجلب مراجعة واحدة من مراجعات المقال
+func (r *memoryRevisionRepository) FindOne(ctx context.Context, articleID, revision uint) (*models.ArticleRevision, error) {
+	var found *models.ArticleRevision
+	err := r.access.view(ctx, func(d *memoryData) error {
+		for _, rev := range d.revisions {
+			if rev.ArticleID == articleID && rev.Revision == revision {
+				found = &rev
+				return nil
+			}
+		}
+		return apperr.NotFound("revision_not_found", revision, articleID)
+	})
+	return found, err
+}
+
+// Latest يجلب آخر مراجعة للمقال
+func (r *memoryRevisionRepository) Latest(ctx context.Context, articleID uint) (*models.ArticleRevision, error) {
+	var latest *models.ArticleRevision
+	err := r.access.view(ctx, func(d *memoryData) error {
+		revisions := d.articleRevisions(articleID)
+		if len(revisions) == 0 {
+			return apperr.NotFound("article_has_no_revisions", articleID)
+		}
+		latest = &revisions[len(revisions)-1]
+		return nil
+	})
+	return latest, err
+}
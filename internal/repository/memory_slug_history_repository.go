@@ -0,0 +1,71 @@
+// my-article-app/internal/repository/memory_slug_history_repository.go
+package repository
+
+import (
+	"context"
+	"my-article-app/internal/apperr"
+	"my-article-app/internal/models"
+	"time"
+)
+
+// memorySlugHistoryRepository تنفيذ SlugHistoryRepository في الذاكرة بنفس سلوك تنفيذ GORM:
+// المعرف السابق فريد، ويُحذف مع الحذف النهائي لمقاله
+type memorySlugHistoryRepository struct {
+	access memoryAccess
+}
+
+// NewMemorySlugHistoryRepository ينشئ SlugHistoryRepository يعمل على تخزين في الذاكرة
+func NewMemorySlugHistoryRepository(store *MemoryStore) SlugHistoryRepository {
+	return &memorySlugHistoryRepository{access: store}
+}
+
+// Record يحفظ المعرف السابق للمقال ويحذف current من سجله
+func (r *memorySlugHistoryRepository) Record(ctx context.Context, articleID uint, previous, current string) error {
+	return r.access.update(ctx, func(d *memoryData) error {
+		if _, ok := d.articles[articleID]; !ok {
+			return apperr.Conflict("constraint_violation")
+		}
+		if owner, ok := d.slugHistory[previous]; ok && owner.ArticleID != articleID {
+			return apperr.Conflict("article_duplicate")
+		}
+		if owner, ok := d.slugHistory[current]; ok && owner.ArticleID == articleID {
+			delete(d.slugHistory, current)
+		}
+		d.nextSlugHistoryID++
+		d.slugHistory[previous] = models.ArticleSlugHistory{
+			ID:        d.nextSlugHistoryID,
+			ArticleID: articleID,
+			Slug:      previous,
+			CreatedAt: time.Now(),
+		}
+		return nil
+	})
+}
+
+// Resolve يعيد معرف المقال الذي كان يحمل المعرف النصي slug
+func (r *memorySlugHistoryRepository) Resolve(ctx context.Context, slug string) (uint, error) {
+	var articleID uint
+	err := r.access.view(ctx, func(d *memoryData) error {
+		entry, ok := d.slugHistory[slug]
+		if !ok {
+			return apperr.NotFound("article_slug_not_found", slug)
+		}
+		articleID = entry.ArticleID
+		return nil
+	})
+	return articleID, err
+}
+
+// TakenSlugs يعيد المعرفات السابقة التي قد تكون من مرشحي bases مع المقالات المحجوزة لها
+func (r *memorySlugHistoryRepository) TakenSlugs(ctx context.Context, bases []string) (map[string]uint, error) {
+	taken := make(map[string]uint)
+	err := r.access.view(ctx, func(d *memoryData) error {
+		for slug, entry := range d.slugHistory {
+			if slugFromAny(slug, bases) {
+				taken[slug] = entry.ArticleID
+			}
+		}
+		return nil
+	})
+	return taken, err
+}
@@ -0,0 +1,202 @@
+// my-article-app/internal/repository/memory_store.go
+package repository
+
+import (
+	"context"
+	"maps"
+	"my-article-app/internal/models"
+	"my-article-app/internal/pagination"
+	"sync"
+)
+
+// MemoryStore تخزين في الذاكرة يحل محل قاعدة البيانات في التطوير والاختبارات، وتضيع بياناته عند الإغلاق
+// القراءات المتزامنة مسموحة، والكتابات (ومنها المعاملات كاملة) متسلسلة بقفل واحد
+type MemoryStore struct {
+	mu   sync.RWMutex
+	data *memoryData
+}
+
+// memoryData جداول التخزين في الذاكرة؛ السجلات تُحفظ بالقيمة دون علاقاتها (Author و Articles)
+// وتُنسخ عند القراءة والكتابة حتى لا يعدّل المستدعي الحالة المشتركة من خارج القفل
+// سجل المعرفات السابقة (slugHistory) مفهرس بالمعرف النصي كما في قيده الفريد
+// وجدولا الربط (articleTags و articleCategories) مفهرسان بمعرف المقال، وقوائمهما لا تُعدّل في مكانها
+// بل تُستبدل كاملة، لأن نسخة المعاملة تشاركها مع الأصل
+// والتعليقات المحذوفة تبقى في comments بحقل DeletedAt كما في الحذف المنطقي
+type memoryData struct {
+	articles          map[uint]models.Article
+	authors           map[uint]models.Author
+	revisions         map[uint]models.ArticleRevision
+	slugHistory       map[string]models.ArticleSlugHistory
+	tags              map[uint]models.Tag
+	categories        map[uint]models.Category
+	articleTags       map[uint][]uint
+	articleCategories map[uint][]uint
+	comments          map[uint]models.Comment
+	nextArticleID     uint
+	nextAuthorID      uint
+	nextRevisionID    uint
+	nextSlugHistoryID uint
+	nextTagID         uint
+	nextCategoryID    uint
+	nextCommentID     uint
+}
+
+// NewMemoryStore ينشئ تخزينًا فارغًا في الذاكرة
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{data: &memoryData{
+		articles:          make(map[uint]models.Article),
+		authors:           make(map[uint]models.Author),
+		revisions:         make(map[uint]models.ArticleRevision),
+		slugHistory:       make(map[string]models.ArticleSlugHistory),
+		tags:              make(map[uint]models.Tag),
+		categories:        make(map[uint]models.Category),
+		articleTags:       make(map[uint][]uint),
+		articleCategories: make(map[uint][]uint),
+		comments:          make(map[uint]models.Comment),
+	}}
+}
+
+// clone ينسخ الجداول لمعاملة جديدة؛ النسخة تحل محل الأصل عند التثبيت وتُهمل عند التراجع
+func (d *memoryData) clone() *memoryData {
+	return &memoryData{
+		articles:          maps.Clone(d.articles),
+		authors:           maps.Clone(d.authors),
+		revisions:         maps.Clone(d.revisions),
+		slugHistory:       maps.Clone(d.slugHistory),
+		tags:              maps.Clone(d.tags),
+		categories:        maps.Clone(d.categories),
+		articleTags:       maps.Clone(d.articleTags),
+		articleCategories: maps.Clone(d.articleCategories),
+		comments:          maps.Clone(d.comments),
+		nextArticleID:     d.nextArticleID,
+		nextAuthorID:      d.nextAuthorID,
+		nextRevisionID:    d.nextRevisionID,
+		nextSlugHistoryID: d.nextSlugHistoryID,
+		nextTagID:         d.nextTagID,
+		nextCategoryID:    d.nextCategoryID,
+		nextCommentID:     d.nextCommentID,
+	}
+}
+
+// memoryAccess طريقة وصول المستودعات إلى الجداول: عبر أقفال التخزين، أو مباشرة داخل معاملة تملك القفل
+type memoryAccess interface {
+	view(ctx context.Context, fn func(d *memoryData) error) error
+	update(ctx context.Context, fn func(d *memoryData) error) error
+}
+
+// view يشغّل fn بقفل قراءة، بعد التحقق من أن السياق لم يُلغَ أو تنتهِ مهلته
+func (s *MemoryStore) view(ctx context.Context, fn func(d *memoryData) error) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return fn(s.data)
+}
+
+// update يشغّل fn بقفل كتابة؛ على fn أن تتحقق قبل أن تعدّل حتى لا تترك تعديلاً جزئيًا عند الخطأ
+func (s *MemoryStore) update(ctx context.Context, fn func(d *memoryData) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return fn(s.data)
+}
+
+// memoryTx وصول المستودعات داخل معاملة: نسخة خاصة من الجداول بلا أقفال لأن المعاملة تملك قفل الكتابة
+type memoryTx struct {
+	data *memoryData
+}
+
+func (t *memoryTx) view(ctx context.Context, fn func(d *memoryData) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return fn(t.data)
+}
+
+func (t *memoryTx) update(ctx context.Context, fn func(d *memoryData) error) error {
+	return t.view(ctx, fn)
+}
+
+// NewMemoryRepositories ينشئ جميع المستودعات فوق تخزين واحد في الذاكرة
+func NewMemoryRepositories(store *MemoryStore) Repositories {
+	return newMemoryRepositories(store)
+}
+
+func newMemoryRepositories(access memoryAccess) Repositories {
+	return Repositories{
+		Articles:    &memoryArticleRepository{access: access},
+		Authors:     &memoryAuthorRepository{access: access},
+		Revisions:   &memoryRevisionRepository{access: access},
+		SlugHistory: &memorySlugHistoryRepository{access: access},
+		Tags:        &memoryTagRepository{access: access},
+		Categories:  &memoryCategoryRepository{access: access},
+		Comments:    &memoryCommentRepository{access: access},
+	}
+}
+
+type memoryTxManager struct {
+	store *MemoryStore
+}
+
+// NewMemoryTxManager ينشئ مدير معاملات للتخزين في الذاكرة
+func NewMemoryTxManager(store *MemoryStore) TxManager {
+	return &memoryTxManager{store: store}
+}
+
+// WithinTx يمسك قفل الكتابة طوال المعاملة ويمرر إلى fn مستودعات تعمل على نسخة من الجداول،
+// فتُثبَّت النسخة إذا أعادت fn القيمة nil وتُهمل عند الخطأ أو panic (عزل تسلسلي كامل)
+// داخل fn يجب استخدام المستودعات الممررة فقط، لأن المستودعات العامة تنتظر القفل نفسه
+func (m *memoryTxManager) WithinTx(ctx context.Context, fn func(repos Repositories) error) error {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	tx := &memoryTx{data: m.store.data.clone()}
+	if err := fn(newMemoryRepositories(tx)); err != nil {
+		return err
+	}
+	m.store.data = tx.data
+	return nil
+}
+
+// memoryPage يقطع صفحة من سجلات مرتبة مسبقًا بنفس قواعد findPage: الإجمالي والإزاحة أو المؤشر والسجل الإضافي
+// customSort يعني أن الترتيب ليس بالمعرف، فلا يُسمح بالمؤشر ولا يُعاد مؤشر تالٍ
+func memoryPage[T any](items []T, req pagination.Request, idOf func(*T) uint, customSort bool) (*pagination.Page[T], error) {
+	req, err := req.Normalize()
+	if err != nil {
+		return nil, err
+	}
+	if req.IsKeyset() && customSort {
+		return nil, ErrCursorWithSort
+	}
+
+	page := &pagination.Page[T]{Total: int64(len(items)), Limit: req.Limit, Offset: req.Offset}
+	if req.IsKeyset() {
+		afterID, _ := req.AfterID()
+		start := len(items)
+		for i := range items {
+			if idOf(&items[i]) > afterID {
+				start = i
+				break
+			}
+		}
+		items = items[start:]
+	} else {
+		items = items[min(req.Offset, len(items)):]
+	}
+
+	if len(items) > req.Limit {
+		items = items[:req.Limit]
+		page.HasMore = true
+		if !customSort {
+			page.NextCursor = pagination.EncodeCursor(idOf(&items[len(items)-1]))
+		}
+	}
+	page.Items = items
+	return page, nil
+}
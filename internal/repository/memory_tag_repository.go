@@ -0,0 +1,222 @@
+// my-article-app/internal/repository/memory_tag_repository.go
+package repository
+
+import (
+	"cmp"
+	"context"
+	"my-article-app/internal/apperr"
+	"my-article-app/internal/models"
+	"my-article-app/internal/pagination"
+	"my-article-app/internal/textnorm"
+	"slices"
+	"strings"
+	"time"
+)
+
+// memoryTagRepository تنفيذ TagRepository في الذاكرة بنفس سلوك تنفيذ GORM:
+// المعرف النصي فريد، وحذف الوسم يزيل ربطه بالمقالات، والربط يتطلب وجود المقال والوسم كقيود المفاتيح الأجنبية
+type memoryTagRepository struct {
+	access memoryAccess
+}
+
+// tagBySlug يعيد الوسم الذي يحمل المعرف النصي
+func (d *memoryData) tagBySlug(slug string) (models.Tag, bool) {
+	for _, tag := range d.tags {
+		if tag.Slug == slug {
+			return tag, true
+		}
+	}
+	return models.Tag{}, false
+}
+
+// insertTag يضيف وسمًا جديدًا ويملأ معرفه وتواريخه
+func (d *memoryData) insertTag(tag *models.Tag) {
+	d.nextTagID++
+	now := time.Now()
+	tag.ID = d.nextTagID
+	tag.CreatedAt, tag.UpdatedAt = now, now
+	d.tags[tag.ID] = *tag
+}
+
+// Create ينشئ وسمًا جديدًا، ويعيد تعارضًا إذا وُجد وسم بالمعرف النصي نفسه
+func (r *memoryTagRepository) Create(ctx context.Context, tag *models.Tag) error {
+	return r.access.update(ctx, func(d *memoryData) error {
+		if _, taken := d.tagBySlug(tag.Slug); taken {
+			return apperr.Conflict("tag_exists", tag.Slug)
+		}
+		d.insertTag(tag)
+		return nil
+	})
+}
+
+// FindAll يجلب صفحة من الوسوم مع عدد المقالات المنشورة غير المحذوفة التي تحملها
+func (r *memoryTagRepository) FindAll(ctx context.Context, filter TagFilter, req pagination.Request) (*pagination.Page[TagWithUsage], error) {
+	if _, err := orderClauses(filter.Sort, tagSortColumns); err != nil {
+		return nil, err
+	}
+
+	slug := textnorm.Slug(filter.Name)
+	var items []TagWithUsage
+	err := r.access.view(ctx, func(d *memoryData) error {
+		counts := make(map[uint]int64)
+		for id, tagIDs := range d.articleTags {
+			if article := d.articles[id]; !article.DeletedAt.Valid && article.Status == models.ArticleStatusPublished {
+				for _, tagID := range tagIDs {
+					counts[tagID]++
+				}
+			}
+		}
+		for _, tag := range d.tags {
+			if strings.Contains(tag.Slug, slug) {
+				items = append(items, TagWithUsage{Tag: tag, ArticleCount: counts[tag.ID]})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(items, func(a, b TagWithUsage) int {
+		for _, s := range filter.Sort {
+			c := compareTags(&a, &b, s.Field)
+			if s.Desc {
+				c = -c
+			}
+			if c != 0 {
+				return c
+			}
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return memoryPage(items, req, func(t *TagWithUsage) uint { return t.ID }, len(filter.Sort) > 0)
+}
+
+// compareTags يقارن وسمين حسب حقل ترتيب من tagSortColumns
+func compareTags(a, b *TagWithUsage, field string) int {
+	switch field {
+	case "name":
+		return strings.Compare(a.Name, b.Name)
+	case "article_count":
+		return cmp.Compare(a.ArticleCount, b.ArticleCount)
+	}
+	return cmp.Compare(a.ID, b.ID)
+}
+
+// FindByID يجلب وسمًا واحدًا حسب المعرف
+func (r *memoryTagRepository) FindByID(ctx context.Context, id uint) (*models.Tag, error) {
+	var tag models.Tag
+	err := r.access.view(ctx, func(d *memoryData) error {
+		var ok bool
+		if tag, ok = d.tags[id]; !ok {
+			return apperr.NotFound("tag_not_found", id)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// Update يحفظ اسم الوسم ومعرفه النصي
+func (r *memoryTagRepository) Update(ctx context.Context, tag *models.Tag) error {
+	return r.access.update(ctx, func(d *memoryData) error {
+		stored, ok := d.tags[tag.ID]
+		if !ok {
+			return apperr.NotFound("tag_not_found", tag.ID)
+		}
+		if other, taken := d.tagBySlug(tag.Slug); taken && other.ID != tag.ID {
+			return apperr.Conflict("tag_exists", tag.Slug)
+		}
+
+		stored.Name = tag.Name
+		stored.Slug = tag.Slug
+		stored.UpdatedAt = time.Now()
+		d.tags[stored.ID] = stored
+		tag.UpdatedAt = stored.UpdatedAt
+		return nil
+	})
+}
+
+// Delete يحذف الوسم ويزيله من وسوم المقالات
+func (r *memoryTagRepository) Delete(ctx context.Context, id uint) error {
+	return r.access.update(ctx, func(d *memoryData) error {
+		if _, ok := d.tags[id]; !ok {
+			return apperr.NotFound("tag_not_found", id)
+		}
+		delete(d.tags, id)
+		for articleID, tagIDs := range d.articleTags {
+			if slices.Contains(tagIDs, id) {
+				d.articleTags[articleID] = slices.DeleteFunc(slices.Clone(tagIDs), func(t uint) bool { return t == id })
+			}
+		}
+		return nil
+	})
+}
+
+// Ensure يعيد الوسوم المطلوبة بمعرفاتها بترتيبها، وينشئ ما لم يوجد منها
+func (r *memoryTagRepository) Ensure(ctx context.Context, tags []models.Tag) ([]models.Tag, error) {
+	if len(tags) == 0 {
+		return nil, nil
+	}
+	ensured := make([]models.Tag, 0, len(tags))
+	err := r.access.update(ctx, func(d *memoryData) error {
+		for _, tag := range tags {
+			existing, ok := d.tagBySlug(tag.Slug)
+			if !ok {
+				existing = models.Tag{Name: tag.Name, Slug: tag.Slug}
+				d.insertTag(&existing)
+			}
+			ensured = append(ensured, existing)
+		}
+		return nil
+	})
+	return ensured, err
+}
+
+// ReplaceArticleTags يستبدل وسوم المقال بـ tagIDs
+func (r *memoryTagRepository) ReplaceArticleTags(ctx context.Context, articleID uint, tagIDs []uint) error {
+	return r.access.update(ctx, func(d *memoryData) error {
+		if err := d.checkArticleTags(articleID, tagIDs); err != nil {
+			return err
+		}
+		if len(tagIDs) == 0 {
+			delete(d.articleTags, articleID)
+		} else {
+			d.articleTags[articleID] = slices.Clone(tagIDs)
+		}
+		return nil
+	})
+}
+
+// LinkArticles يضيف صفوف ربط بين مقالات ووسوم؛ يتحقق منها كلها أولاً فلا يُضاف شيء إذا فشل أحدها
+func (r *memoryTagRepository) LinkArticles(ctx context.Context, links []models.ArticleTag, batchSize int) error {
+	return r.access.update(ctx, func(d *memoryData) error {
+		for _, link := range links {
+			if err := d.checkArticleTags(link.ArticleID, []uint{link.TagID}); err != nil {
+				return err
+			}
+			if slices.Contains(d.articleTags[link.ArticleID], link.TagID) {
+				return apperr.Conflict("constraint_violation")
+			}
+		}
+		for _, link := range links {
+			d.articleTags[link.ArticleID] = append(slices.Clone(d.articleTags[link.ArticleID]), link.TagID)
+		}
+		return nil
+	})
+}
+
+// checkArticleTags يتحقق من وجود المقال والوسوم كما تفعل قيود المفاتيح الأجنبية لجدول الربط
+func (d *memoryData) checkArticleTags(articleID uint, tagIDs []uint) error {
+	if _, ok := d.articles[articleID]; !ok {
+		return apperr.Conflict("constraint_violation")
+	}
+	for _, id := range tagIDs {
+		if _, ok := d.tags[id]; !ok {
+			return apperr.Conflict("constraint_violation")
+		}
+	}
+	return nil
+}
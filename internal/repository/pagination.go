@@ -0,0 +1,109 @@
+// my-article-app/internal/repository/pagination.go
+package repository
+
+import (
+	"fmt"
+	"my-article-app/internal/apperr"
+	"my-article-app/internal/pagination"
+
+	"gorm.io/gorm"
+)
+
+// ErrCursorWithSort يُرجع عند طلب ترقيم بالمؤشر مع ترتيب مخصص، لأن المؤشر مبني على المعرف فقط
+var ErrCursorWithSort = apperr.Validation("cursor_with_sort")
+
+// SortField حقل ترتيب واحد باسمه العام (وليس اسم العمود)
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// pageSpec يصف كيفية جلب صفحة من جدول معين
+type pageSpec[T any] struct {
+	// idColumn اسم عمود المعرف مؤهلاً باسم الجدول
+	idColumn string
+	idOf     func(*T) uint
+	// orders عبارات ترتيب إضافية (أعمدة من القائمة المسموحة فقط) تسبق الترتيب بالمعرف
+	orders []string
+	// preloads العلاقات المطلوب تحميلها مع النتائج
+	preloads []string
+}
+
+// findPage يجلب صفحة من نتائج الاستعلام مرتبة حسب المعرف، بالإزاحة أو بالمؤشر (keyset)
+func findPage[T any](query *gorm.DB, req pagination.Request, spec pageSpec[T]) (*pagination.Page[T], error) {
+	req, err := req.Normalize()
+	if err != nil {
+		return nil, err
+	}
+	if req.IsKeyset() && len(spec.orders) > 0 {
+		return nil, ErrCursorWithSort
+	}
+
+	// الإجمالي يُحسب على كامل النتائج المطابقة بغض النظر عن موضع الصفحة
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("فشل حساب إجمالي السجلات: %w", err)
+	}
+
+	// نجلب سجلاً إضافيًا لمعرفة ما إذا كانت هناك صفحة تالية
+	q := query.Session(&gorm.Session{}).Limit(req.Limit + 1)
+	for _, order := range spec.orders {
+		q = q.Order(order)
+	}
+	q = q.Order(spec.idColumn + " ASC")
+	if req.IsKeyset() {
+		afterID, _ := req.AfterID()
+		q = q.Where(spec.idColumn+" > ?", afterID)
+	} else {
+		q = q.Offset(req.Offset)
+	}
+	for _, p := range spec.preloads {
+		q = q.Preload(p)
+	}
+
+	var items []T
+	if err := q.Find(&items).Error; err != nil {
+		return nil, err
+	}
+
+	page := &pagination.Page[T]{Total: total, Limit: req.Limit, Offset: req.Offset}
+	if len(items) > req.Limit {
+		items = items[:req.Limit]
+		page.HasMore = true
+		// المؤشر مبني على المعرف، فلا معنى له إلا مع الترتيب الافتراضي
+		if len(spec.orders) == 0 {
+			page.NextCursor = pagination.EncodeCursor(spec.idOf(&items[len(items)-1]))
+		}
+	}
+	page.Items = items
+	return page, nil
+}
+
+// orderClauses يحوّل حقول الترتيب إلى عبارات ORDER BY آمنة باستخدام قائمة الأعمدة المسموحة
+func orderClauses(sort []SortField, columns map[string]string) ([]string, error) {
+	orders := make([]string, 0, len(sort))
+	for _, s := range sort {
+		column, ok := columns[s.Field]
+		if !ok {
+			return nil, apperr.Validation("invalid_sort_field", s.Field)
+		}
+		if s.Desc {
+			orders = append(orders, column+" DESC")
+		} else {
+			orders = append(orders, column+" ASC")
+		}
+	}
+	return orders, nil
+}
+
+// escapeLike يهرّب محارف LIKE الخاصة باستخدام "!" كمحرف هروب (يعمل على جميع قواعد البيانات المدعومة)
+func escapeLike(s string) string {
+	var out []rune
+	for _, r := range s {
+		if r == '%' || r == '_' || r == '!' {
+			out = append(out, '!')
+		}
+		out = append(out, r)
+	}
+	return string(out)
+}
@@ -0,0 +1,111 @@
+// my-article-app/internal/repository/revision_repository.go
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"my-article-app/internal/apperr"
+	"my-article-app/internal/models"
+	"my-article-app/internal/pagination"
+
+	"gorm.io/gorm"
+)
+
+// RevisionRepository سجل مراجعات المقالات؛ المراجعات تُضاف فقط ولا تُعدّل أو تُحذف إلا مع الحذف النهائي لمقالها
+type RevisionRepository interface {
+	Append(ctx context.Context, revision *models.ArticleRevision) error
+	CreateBatch(ctx context.Context, revisions []*models.ArticleRevision, batchSize int) error
+	FindByArticle(ctx context.Context, articleID uint, req pagination.Request) (*pagination.Page[models.ArticleRevision], error)
+	FindOne(ctx context.Context, articleID, revision uint) (*models.ArticleRevision, error)
+	Latest(ctx context.Context, articleID uint) (*models.ArticleRevision, error)
+}
+
+type revisionRepository struct {
+	db *gorm.DB
+}
+
+// NewRevisionRepository ينشئ RevisionRepository يعتمد على GORM
+func NewRevisionRepository(db *gorm.DB) RevisionRepository {
+	return &revisionRepository{db: db}
+}
+
+// Append يضيف مراجعة برقم يلي آخر مراجعة للمقال
+// يُستدعى داخل معاملة التحديث بعد التحديث المشروط بالنسخة، فلا يصل إليه طلبان متزامنان على المقال نفسه؛
+// والقيد الفريد (article_id, revision) يرفض أي تعارض متبقٍ
+func (r *revisionRepository) Append(ctx context.Context, revision *models.ArticleRevision) error {
+	var last uint
+	err := r.db.WithContext(ctx).Model(&models.ArticleRevision{}).
+		Where("article_id = ?", revision.ArticleID).
+		Select("COALESCE(MAX(revision), 0)").
+		Scan(&last).Error
+	if err != nil {
+		return fmt.Errorf("فشل حساب رقم المراجعة التالية: %w", err)
+	}
+
+	revision.Revision = last + 1
+	if err := r.db.WithContext(ctx).Create(revision).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			// مراجعة بالرقم نفسه أضافها طلب آخر: التعديل المتزامن نفسه الذي يكشفه رقم النسخة
+			return ErrVersionConflict
+		}
+		if err := constraintError(err, apperr.Conflict("constraint_violation")); err != nil {
+			return err
+		}
+		return fmt.Errorf("فشل حفظ مراجعة المقال: %w", err)
+	}
+	return nil
+}
+
+// CreateBatch يدرج مراجعات بأرقام يحددها المستدعي (المراجعة الأولى للمقالات المنشأة دفعة واحدة)
+func (r *revisionRepository) CreateBatch(ctx context.Context, revisions []*models.ArticleRevision, batchSize int) error {
+	if len(revisions) == 0 {
+		return nil
+	}
+	if err := r.db.WithContext(ctx).CreateInBatches(revisions, batchSize).Error; err != nil {
+		if err := constraintError(err, apperr.Conflict("constraint_violation")); err != nil {
+			return err
+		}
+		return fmt.Errorf("فشل حفظ مراجعات المقالات: %w", err)
+	}
+	return nil
+}
+
+// FindByArticle يجلب صفحة من مراجعات المقال مرتبة برقم المراجعة، والمؤشر مبني على رقمها
+func (r *revisionRepository) FindByArticle(ctx context.Context, articleID uint, req pagination.Request) (*pagination.Page[models.ArticleRevision], error) {
+	query := r.db.WithContext(ctx).Model(&models.ArticleRevision{}).Where("article_id = ?", articleID)
+	page, err := findPage(query, req, pageSpec[models.ArticleRevision]{
+		idColumn: "article_revisions.revision",
+		idOf:     func(rev *models.ArticleRevision) uint { return rev.Revision },
+	})
+	if err != nil {
+		return nil, fmt.Errorf("فشل جلب مراجعات المقال: %w", err)
+	}
+	return page, nil
+}
+
+// FindOne يجلب مراجعة واحدة من مراجعات المقال
+func (r *revisionRepository) FindOne(ctx context.Context, articleID, revision uint) (*models.ArticleRevision, error) {
+	var rev models.ArticleRevision
+	err := r.db.WithContext(ctx).Where("article_id = ? AND revision = ?", articleID, revision).First(&rev).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, notFoundIf(err, "revision_not_found", revision, articleID)
+		}
+		return nil, fmt.Errorf("فشل جلب المراجعة %d للمقال %d: %w", revision, articleID, err)
+	}
+	return &rev, nil
+}
+
+// Latest يجلب آخر مراجعة للمقال
+func (r *revisionRepository) Latest(ctx context.Context, articleID uint) (*models.ArticleRevision, error) {
+	var rev models.ArticleRevision
+	err := r.db.WithContext(ctx).Where("article_id = ?", articleID).Order("revision DESC").First(&rev).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, notFoundIf(err, "article_has_no_revisions", articleID)
+		}
+		return nil, fmt.Errorf("فشل جلب آخر مراجعة للمقال %d: %w", articleID, err)
+	}
+	return &rev, nil
+}
@@ -0,0 +1,28 @@
+// my-article-app/internal/repository/slug.go
+package repository
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// whereSlugFrom يقصر الاستعلام على المعرفات النصية التي قد تكون من مرشحي أحد bases:
+// الأساس نفسه أو ما يبدأ به متبوعًا بشرطة، والنتيجة أوسع قليلاً من المرشحين ولا يضر ذلك
+func whereSlugFrom(q *gorm.DB, column string, bases []string) *gorm.DB {
+	conditions := q.Session(&gorm.Session{NewDB: true}).Where(column+" IN ?", bases)
+	for _, base := range bases {
+		conditions = conditions.Or(column+" LIKE ? ESCAPE '!'", escapeLike(base)+"-%")
+	}
+	return q.Where(conditions)
+}
+
+// slugFromAny نظير whereSlugFrom في الذاكرة
+func slugFromAny(slug string, bases []string) bool {
+	for _, base := range bases {
+		if slug == base || strings.HasPrefix(slug, base+"-") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+// my-article-app/internal/repository/slug_history_repository.go
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"my-article-app/internal/apperr"
+	"my-article-app/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// SlugHistoryRepository سجل المعرفات النصية السابقة للمقالات
+type SlugHistoryRepository interface {
+	Record(ctx context.Context, articleID uint, previous, current string) error
+	Resolve(ctx context.Context, slug string) (uint, error)
+	TakenSlugs(ctx context.Context, bases []string) (map[string]uint, error)
+}
+
+type slugHistoryRepository struct {
+	db *gorm.DB
+}
+
+// NewSlugHistoryRepository ينشئ مثيلاً جديدًا من SlugHistoryRepository
+func NewSlugHistoryRepository(db *gorm.DB) SlugHistoryRepository {
+	return &slugHistoryRepository{db: db}
+}
+
+// Record يحفظ المعرف السابق للمقال بعد تغيير معرفه، ويحذف current من سجله إذا عاد المقال إلى معرف قديم له
+func (r *slugHistoryRepository) Record(ctx context.Context, articleID uint, previous, current string) error {
+	err := r.db.WithContext(ctx).
+		Where("article_id = ? AND slug = ?", articleID, current).
+		Delete(&models.ArticleSlugHistory{}).Error
+	if err != nil {
+		return fmt.Errorf("فشل تحديث سجل معرفات المقال: %w", err)
+	}
+
+	err = r.db.WithContext(ctx).Create(&models.ArticleSlugHistory{ArticleID: articleID, Slug: previous}).Error
+	if err != nil {
+		if err := constraintError(err, apperr.Conflict("article_duplicate")); err != nil {
+			return err
+		}
+		return fmt.Errorf("فشل حفظ المعرف السابق للمقال: %w", err)
+	}
+	return nil
+}
+
+// Resolve يعيد معرف المقال الذي كان يحمل المعرف النصي slug
+func (r *slugHistoryRepository) Resolve(ctx context.Context, slug string) (uint, error) {
+	var entry models.ArticleSlugHistory
+	err := r.db.WithContext(ctx).Where("slug = ?", slug).First(&entry).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, notFoundIf(err, "article_slug_not_found", slug)
+		}
+		return 0, fmt.Errorf("فشل البحث في سجل معرفات المقالات: %w", err)
+	}
+	return entry.ArticleID, nil
+}
+
+// TakenSlugs يعيد المعرفات السابقة التي قد تكون من مرشحي bases مع المقالات المحجوزة لها
+func (r *slugHistoryRepository) TakenSlugs(ctx context.Context, bases []string) (map[string]uint, error) {
+	taken := make(map[string]uint)
+	if len(bases) == 0 {
+		return taken, nil
+	}
+
+	var entries []models.ArticleSlugHistory
+	if err := whereSlugFrom(r.db.WithContext(ctx), "slug", bases).Find(&entries).Error; err != nil {
+		return nil, fmt.Errorf("فشل جلب المعرفات السابقة للمقالات: %w", err)
+	}
+	for _, entry := range entries {
+		taken[entry.Slug] = entry.ArticleID
+	}
+	return taken, nil
+}
@@ -0,0 +1,225 @@
+// my-article-app/internal/repository/tag_repository.go
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"my-article-app/internal/apperr"
+	"my-article-app/internal/models"
+	"my-article-app/internal/pagination"
+	"my-article-app/internal/textnorm"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TagRepository الوسوم وربطها بالمقالات
+type TagRepository interface {
+	Create(ctx context.Context, tag *models.Tag) error
+	FindAll(ctx context.Context, filter TagFilter, req pagination.Request) (*pagination.Page[TagWithUsage], error)
+	FindByID(ctx context.Context, id uint) (*models.Tag, error)
+	Update(ctx context.Context, tag *models.Tag) error
+	Delete(ctx context.Context, id uint) error
+	Ensure(ctx context.Context, tags []models.Tag) ([]models.Tag, error)
+	ReplaceArticleTags(ctx context.Context, articleID uint, tagIDs []uint) error
+	LinkArticles(ctx context.Context, links []models.ArticleTag, batchSize int) error
+}
+
+// TagFilter شروط تصفية قائمة الوسوم وترتيبها
+type TagFilter struct {
+	// Name جزء من اسم الوسم، يُقارن بالمعرف النصي فيطابق "برمجة" الوسم brmja
+	Name string
+	Sort []SortField
+}
+
+// TagWithUsage وسم مع عدد المقالات المنشورة غير المحذوفة التي تحمله، لسحابة الوسوم
+type TagWithUsage struct {
+	models.Tag
+	ArticleCount int64
+}
+
+// tagSortColumns القائمة المسموحة لحقول ترتيب الوسوم وأعمدتها المقابلة
+var tagSortColumns = map[string]string{
+	"id":            "tags.id",
+	"name":          "tags.name",
+	"article_count": "COALESCE(tag_usage.article_count, 0)",
+}
+
+// IsTagSortField يحدد ما إذا كان الحقل مسموحًا لترتيب الوسوم
+func IsTagSortField(field string) bool {
+	_, ok := tagSortColumns[field]
+	return ok
+}
+
+type tagRepository struct {
+	db *gorm.DB
+}
+
+// NewTagRepository ينشئ مثيلاً جديدًا من TagRepository
+func NewTagRepository(db *gorm.DB) TagRepository {
+	return &tagRepository{db: db}
+}
+
+// Create ينشئ وسمًا جديدًا، ويعيد تعارضًا إذا وُجد وسم بالمعرف النصي نفسه
+func (r *tagRepository) Create(ctx context.Context, tag *models.Tag) error {
+	if err := r.db.WithContext(ctx).Create(tag).Error; err != nil {
+		if err := constraintError(err, apperr.Conflict("tag_exists", tag.Slug)); err != nil {
+			return err
+		}
+		return fmt.Errorf("فشل إنشاء الوسم: %w", err)
+	}
+	return nil
+}
+
+// tagUsage استعلام مجمّع يحسب عدد المقالات المنشورة غير المحذوفة لكل وسم
+func tagUsage(db *gorm.DB) *gorm.DB {
+	return db.Table("article_tags").
+		Select("article_tags.tag_id, COUNT(*) AS article_count").
+		Joins("JOIN articles ON articles.id = article_tags.article_id").
+		Where("articles.deleted_at IS NULL AND articles.status = ?", models.ArticleStatusPublished).
+		Group("article_tags.tag_id")
+}
+
+// FindAll يجلب صفحة من الوسوم مع عدد مقالاتها، محسوبًا باستعلام مجمّع واحد مضموم إلى الصفحة
+// الوسم الذي لا مقالات منشورة له يظهر بعدد 0
+func (r *tagRepository) FindAll(ctx context.Context, filter TagFilter, req pagination.Request) (*pagination.Page[TagWithUsage], error) {
+	orders, err := orderClauses(filter.Sort, tagSortColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	query := r.db.WithContext(ctx).Model(&models.Tag{})
+	query = query.
+		Joins("LEFT JOIN (?) AS tag_usage ON tag_usage.tag_id = tags.id", tagUsage(query.Session(&gorm.Session{NewDB: true}))).
+		Select("tags.*, COALESCE(tag_usage.article_count, 0) AS article_count")
+	if slug := textnorm.Slug(filter.Name); slug != "" {
+		query = query.Where("tags.slug LIKE ? ESCAPE '!'", "%"+escapeLike(slug)+"%")
+	}
+
+	page, err := findPage(query, req, pageSpec[TagWithUsage]{
+		idColumn: "tags.id",
+		idOf:     func(t *TagWithUsage) uint { return t.ID },
+		orders:   orders,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("فشل جلب الوسوم: %w", err)
+	}
+	return page, nil
+}
+
+// FindByID يجلب وسمًا واحدًا حسب المعرف
+func (r *tagRepository) FindByID(ctx context.Context, id uint) (*models.Tag, error) {
+	var tag models.Tag
+	if err := r.db.WithContext(ctx).First(&tag, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, notFoundIf(err, "tag_not_found", id)
+		}
+		return nil, fmt.Errorf("فشل جلب الوسم بالمعرف %d: %w", id, err)
+	}
+	return &tag, nil
+}
+
+// Update يحفظ اسم الوسم ومعرفه النصي
+func (r *tagRepository) Update(ctx context.Context, tag *models.Tag) error {
+	result := r.db.WithContext(ctx).Model(tag).Select("name", "slug", "updated_at").Updates(tag)
+	if result.Error != nil {
+		if err := constraintError(result.Error, apperr.Conflict("tag_exists", tag.Slug)); err != nil {
+			return err
+		}
+		return fmt.Errorf("فشل تحديث الوسم: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return apperr.NotFound("tag_not_found", tag.ID)
+	}
+	return nil
+}
+
+// Delete يحذف الوسم نهائيًا، وتُحذف صفوف ربطه بالمقالات بقيد ON DELETE CASCADE
+func (r *tagRepository) Delete(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Delete(&models.Tag{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("فشل حذف الوسم: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return apperr.NotFound("tag_not_found", id)
+	}
+	return nil
+}
+
+// Ensure يعيد الوسوم المطلوبة بمعرفاتها بترتيبها، وينشئ ما لم يوجد منها
+// الإدخال يتجاهل التعارض (ON CONFLICT DO NOTHING) ثم تُقرأ الوسوم من جديد،
+// فإذا أنشأ طلب متزامن الوسم نفسه استُخدم وسمه بدل فشل المعاملة
+func (r *tagRepository) Ensure(ctx context.Context, tags []models.Tag) ([]models.Tag, error) {
+	if len(tags) == 0 {
+		return nil, nil
+	}
+	slugs := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		slugs = append(slugs, tag.Slug)
+	}
+
+	found, err := r.findBySlugs(ctx, slugs)
+	if err != nil {
+		return nil, err
+	}
+	var missing []models.Tag
+	for _, tag := range tags {
+		if _, ok := found[tag.Slug]; !ok {
+			missing = append(missing, models.Tag{Name: tag.Name, Slug: tag.Slug})
+		}
+	}
+	if len(missing) > 0 {
+		if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&missing).Error; err != nil {
+			return nil, fmt.Errorf("فشل إنشاء الوسوم: %w", err)
+		}
+		if found, err = r.findBySlugs(ctx, slugs); err != nil {
+			return nil, err
+		}
+	}
+
+	ensured := make([]models.Tag, 0, len(tags))
+	for _, tag := range tags {
+		ensured = append(ensured, found[tag.Slug])
+	}
+	return ensured, nil
+}
+
+// findBySlugs يجلب الوسوم الموجودة من بين slugs مفهرسة بمعرفها النصي
+func (r *tagRepository) findBySlugs(ctx context.Context, slugs []string) (map[string]models.Tag, error) {
+	var tags []models.Tag
+	if err := r.db.WithContext(ctx).Where("slug IN ?", slugs).Find(&tags).Error; err != nil {
+		return nil, fmt.Errorf("فشل جلب الوسوم: %w", err)
+	}
+	found := make(map[string]models.Tag, len(tags))
+	for _, tag := range tags {
+		found[tag.Slug] = tag
+	}
+	return found, nil
+}
+
+// ReplaceArticleTags يستبدل وسوم المقال بـ tagIDs، والقائمة الفارغة تزيل وسومه كلها
+func (r *tagRepository) ReplaceArticleTags(ctx context.Context, articleID uint, tagIDs []uint) error {
+	if err := r.db.WithContext(ctx).Where("article_id = ?", articleID).Delete(&models.ArticleTag{}).Error; err != nil {
+		return fmt.Errorf("فشل إزالة وسوم المقال: %w", err)
+	}
+	links := make([]models.ArticleTag, 0, len(tagIDs))
+	for _, id := range tagIDs {
+		links = append(links, models.ArticleTag{ArticleID: articleID, TagID: id})
+	}
+	return r.LinkArticles(ctx, links, len(links))
+}
+
+// LinkArticles يضيف صفوف ربط بين مقالات ووسوم بإدخالات مجمّعة من batchSize صف
+func (r *tagRepository) LinkArticles(ctx context.Context, links []models.ArticleTag, batchSize int) error {
+	if len(links) == 0 {
+		return nil
+	}
+	if err := r.db.WithContext(ctx).CreateInBatches(links, batchSize).Error; err != nil {
+		if err := constraintError(err, apperr.Conflict("constraint_violation")); err != nil {
+			return err
+		}
+		return fmt.Errorf("فشل ربط الوسوم بالمقالات: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+// my-article-app/internal/repository/tx.go
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// Repositories مجموعة المستودعات المرتبطة بنفس الاتصال، أو بنفس المعاملة داخل WithinTx
+type Repositories struct {
+	Articles    ArticleRepository
+	Authors     AuthorRepository
+	Revisions   RevisionRepository
+	SlugHistory SlugHistoryRepository
+	Tags        TagRepository
+	Categories  CategoryRepository
+	Comments    CommentRepository
+}
+
+// NewRepositories ينشئ جميع المستودعات فوق اتصال (أو معاملة) واحد
+func NewRepositories(db *gorm.DB) Repositories {
+	return Repositories{
+		Articles:    NewArticleRepository(db),
+		Authors:     NewAuthorRepository(db),
+		Revisions:   NewRevisionRepository(db),
+		SlugHistory: NewSlugHistoryRepository(db),
+		Tags:        NewTagRepository(db),
+		Categories:  NewCategoryRepository(db),
+		Comments:    NewCommentRepository(db),
+	}
+}
+
+// TxManager يشغّل وحدة عمل (Unit of Work) تمتد عبر عدة مستودعات داخل معاملة واحدة
+type TxManager interface {
+	// WithinTx يبدأ معاملة ويمرر إلى fn مستودعات مرتبطة بها؛
+	// تُثبَّت المعاملة إذا أعادت fn القيمة nil، ويُتراجع عنها إذا أعادت خطأ أو حدث panic
+	WithinTx(ctx context.Context, fn func(repos Repositories) error) error
+}
+
+type gormTxManager struct {
+	db *gorm.DB
+}
+
+// NewTxManager ينشئ مدير معاملات يعتمد على GORM
+func NewTxManager(db *gorm.DB) TxManager {
+	return &gormTxManager{db: db}
+}
+
+// WithinTx يستخدم db.Transaction الذي يتولى التثبيت والتراجع، والسياق يلغي المعاملة عند انتهاء المهلة
+func (m *gormTxManager) WithinTx(ctx context.Context, fn func(repos Repositories) error) error {
+	return m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(NewRepositories(tx))
+	})
+}
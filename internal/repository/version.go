@@ -0,0 +1,25 @@
+// my-article-app/internal/repository/version.go
+package repository
+
+import (
+	"fmt"
+	"my-article-app/internal/apperr"
+
+	"gorm.io/gorm"
+)
+
+// ErrVersionConflict يُرجع عندما يفشل التحديث المشروط لأن السجل عُدّل من طلب آخر منذ قراءته
+var ErrVersionConflict = apperr.Conflict("record_modified")
+
+// missedUpdateError يفسّر تحديثًا مشروطًا لم يؤثر على أي صف: إذا كان السجل ما زال موجودًا
+// فرقم نسخته تغيّر (ErrVersionConflict)، وإلا فقد حُذف (gorm.ErrRecordNotFound)
+func missedUpdateError(exists *gorm.DB) error {
+	var count int64
+	if err := exists.Count(&count).Error; err != nil {
+		return fmt.Errorf("فشل التحقق من وجود السجل: %w", err)
+	}
+	if count > 0 {
+		return ErrVersionConflict
+	}
+	return gorm.ErrRecordNotFound
+}
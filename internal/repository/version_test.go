@@ -0,0 +1,84 @@
+// my-article-app/internal/repository/version_test.go
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"my-article-app/internal/apperr"
+	"sync"
+	"testing"
+)
+
+// TestConcurrentUpdatesSameVersion يتحقق أن تحديثين قرآ النسخة نفسها لا ينجح منهما إلا واحد
+// (UPDATE ... WHERE version = ?)، والآخر يعود بـ ErrVersionConflict دون أن يكتب فوق الأول
+func TestConcurrentUpdatesSameVersion(t *testing.T) {
+	forEachStore(t, func(t *testing.T, repos Repositories) {
+		ctx := context.Background()
+		author := createAuthor(t, repos, "race-author")
+		article := createArticle(t, repos, author.ID, 1)
+
+		const writers = 2
+		var (
+			wg    sync.WaitGroup
+			start = make(chan struct{})
+			errs  = make([]error, writers)
+		)
+		for i := range writers {
+			// كل كاتب يقرأ المقال قبل أن يبدأ أي منهما بالكتابة، فكلاهما يحمل النسخة 1
+			stale, err := repos.Articles.FindByID(ctx, article.ID)
+			if err != nil {
+				t.Fatalf("FindByID: %v", err)
+			}
+			stale.Content = fmt.Sprintf("written by writer %d", i)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				<-start
+				errs[i] = repos.Articles.Update(ctx, stale)
+			}()
+		}
+		close(start)
+		wg.Wait()
+
+		winner := -1
+		for i, err := range errs {
+			switch {
+			case err == nil:
+				if winner >= 0 {
+					t.Fatalf("both writers succeeded")
+				}
+				winner = i
+			case !errors.Is(err, ErrVersionConflict):
+				t.Fatalf("writer %d: err = %v, want ErrVersionConflict", i, err)
+			}
+		}
+		if winner < 0 {
+			t.Fatal("no writer succeeded")
+		}
+
+		current, err := repos.Articles.FindByID(ctx, article.ID)
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		if want := fmt.Sprintf("written by writer %d", winner); current.Version != 2 || current.Content != want {
+			t.Errorf("after race version=%d content=%q, want 2 %q", current.Version, current.Content, want)
+		}
+	})
+}
+
+func TestUpdateDeletedArticleIsNotFound(t *testing.T) {
+	forEachStore(t, func(t *testing.T, repos Repositories) {
+		ctx := context.Background()
+		author := createAuthor(t, repos, "gone-author")
+		article := createArticle(t, repos, author.ID, 1)
+		if err := repos.Articles.Delete(ctx, article.ID); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		// التحديث المشروط لم يجد السجل لأنه حُذف لا لأن نسخته تغيرت
+		article.Content = "too late"
+		if err := repos.Articles.Update(ctx, article); !apperr.Is(err, apperr.KindNotFound) {
+			t.Errorf("Update of deleted article: err = %v, want not found", err)
+		}
+	})
+}
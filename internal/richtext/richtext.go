@@ -0,0 +1,113 @@
+// my-article-app/internal/richtext/richtext.go
+package richtext
+
+import (
+	"bytes"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	xhtml "golang.org/x/net/html"
+)
+
+// صيغ محتوى المقال؛ الصيغة تحدد كيف يُحوَّل المحتوى إلى HTML ولا تغيّر المحتوى المخزن إلا صيغة html التي تُنقّى
+const (
+	FormatPlain    = "plain"
+	FormatMarkdown = "markdown"
+	FormatHTML     = "html"
+)
+
+// markdown محوّل CommonMark مع امتدادات GFM (الجداول والشطب والروابط التلقائية وقوائم المهام)
+// HTML الخام داخل Markdown لا يُمرَّر (الخيار الافتراضي لـ goldmark)، والناتج يُنقّى بعد ذلك على أي حال
+var markdown = goldmark.New(goldmark.WithExtensions(extension.GFM))
+
+// policy القائمة المسموحة من العناصر والسمات: سياسة bluemonday لمحتوى المستخدمين
+// مع لغة كتل الشيفرة ومربعات قوائم المهام التي يولّدها Markdown؛ السياسة آمنة للاستخدام المتزامن بعد بنائها
+var policy = newPolicy()
+
+func newPolicy() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w-]+$`)).OnElements("code")
+	p.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	p.AllowAttrs("checked", "disabled").OnElements("input")
+	return p
+}
+
+// paragraphBreak سطر فارغ واحد أو أكثر يفصل فقرات النص العادي
+var paragraphBreak = regexp.MustCompile(`\n[ \t]*\n\s*`)
+
+// IsFormat يحدد ما إذا كانت الصيغة مدعومة
+func IsFormat(format string) bool {
+	switch format {
+	case FormatPlain, FormatMarkdown, FormatHTML:
+		return true
+	}
+	return false
+}
+
+// Sanitize يزيل من HTML كل عنصر أو سمة خارج القائمة المسموحة، ومنها السكربتات ومعالجات الأحداث وروابط javascript:
+func Sanitize(source string) string {
+	return policy.Sanitize(source)
+}
+
+// Render يحوّل المحتوى بصيغته إلى HTML منقّى؛ الصيغة الفارغة أو غير المعروفة تُعامل كنص عادي
+func Render(content, format string) string {
+	switch format {
+	case FormatMarkdown:
+		var buf bytes.Buffer
+		if err := markdown.Convert([]byte(content), &buf); err != nil {
+			// الكتابة في الذاكرة لا تفشل، وإن فشل التحويل عُرض المحتوى كنص عادي
+			return plainHTML(content)
+		}
+		return Sanitize(buf.String())
+	case FormatHTML:
+		return Sanitize(content)
+	}
+	return plainHTML(content)
+}
+
+// plainHTML يحوّل النص العادي إلى فقرات: الأسطر الفارغة تفصل الفقرات، ونهاية السطر داخل الفقرة <br>
+func plainHTML(content string) string {
+	content = strings.TrimSpace(strings.ReplaceAll(content, "\r\n", "\n"))
+	if content == "" {
+		return ""
+	}
+	var b strings.Builder
+	for _, paragraph := range paragraphBreak.Split(content, -1) {
+		b.WriteString("<p>")
+		b.WriteString(strings.ReplaceAll(html.EscapeString(paragraph), "\n", "<br>\n"))
+		b.WriteString("</p>\n")
+	}
+	return b.String()
+}
+
+// blockElements العناصر التي تفصل ما قبلها عما بعدها عند استخراج النص، حتى لا تلتصق كلمات فقرتين
+var blockElements = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true, "br": true, "dd": true,
+	"div": true, "dl": true, "dt": true, "figcaption": true, "figure": true, "footer": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true, "header": true,
+	"hr": true, "li": true, "ol": true, "p": true, "pre": true, "section": true,
+	"table": true, "td": true, "th": true, "tr": true, "ul": true,
+}
+
+// Text يستخرج النص المقروء من HTML لأغراض البحث وعدّ الكلمات، دون الوسوم
+// الكيانات تُفك (&lt; تصبح <)، فالناتج نص عادي وليس HTML، ويجب تهريبه قبل إدراجه في أي HTML
+func Text(source string) string {
+	var b strings.Builder
+	tokens := xhtml.NewTokenizer(strings.NewReader(source))
+	for {
+		switch tokens.Next() {
+		case xhtml.ErrorToken:
+			return strings.TrimSpace(b.String())
+		case xhtml.TextToken:
+			b.Write(tokens.Text())
+		case xhtml.StartTagToken, xhtml.EndTagToken, xhtml.SelfClosingTagToken:
+			if name, _ := tokens.TagName(); blockElements[string(name)] {
+				b.WriteByte('\n')
+			}
+		}
+	}
+}
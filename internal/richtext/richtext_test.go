@@ -0,0 +1,116 @@
+// my-article-app/internal/richtext/richtext_test.go
+package richtext
+
+import (
+	"strings"
+	"testing"
+
+	xhtml "golang.org/x/net/html"
+)
+
+// forbiddenElements عناصر لا يجوز أن تبقى في أي HTML منقّى
+var forbiddenElements = map[string]bool{
+	"script": true, "iframe": true, "style": true, "object": true, "embed": true, "svg": true,
+}
+
+// assertSafe يحلل الناتج ويتحقق من عناصره وسماته الفعلية؛ النص المهرَّب مثل &lt;script&gt; مسموح
+func assertSafe(t *testing.T, out string) {
+	t.Helper()
+	tokens := xhtml.NewTokenizer(strings.NewReader(out))
+	for {
+		switch tokens.Next() {
+		case xhtml.ErrorToken:
+			return
+		case xhtml.StartTagToken, xhtml.SelfClosingTagToken:
+			token := tokens.Token()
+			if forbiddenElements[token.Data] {
+				t.Errorf("output keeps <%s>: %s", token.Data, out)
+			}
+			for _, attr := range token.Attr {
+				value := strings.ToLower(strings.TrimSpace(attr.Val))
+				if strings.HasPrefix(attr.Key, "on") || attr.Key == "style" || strings.HasPrefix(value, "javascript:") {
+					t.Errorf("output keeps %s=%q on <%s>: %s", attr.Key, attr.Val, token.Data, out)
+				}
+			}
+		}
+	}
+}
+
+func TestRenderRemovesXSS(t *testing.T) {
+	tests := []struct {
+		name, format, content string
+	}{
+		{"html script", FormatHTML, `<p>hi</p><script>alert(1)</script>`},
+		{"html event handler", FormatHTML, `<img src="x.png" onerror="alert(1)">`},
+		{"html javascript link", FormatHTML, `<a href="javascript:alert(1)">click</a>`},
+		{"html encoded javascript link", FormatHTML, `<a href="&#106;avascript:alert(1)">click</a>`},
+		{"html iframe", FormatHTML, `<iframe src="https://evil.example"></iframe>`},
+		{"html style", FormatHTML, `<style>body{display:none}</style><p style="color:red">x</p>`},
+		{"html svg onload", FormatHTML, `<svg onload="alert(1)"><circle/></svg>`},
+		{"html object", FormatHTML, `<object data="x.swf"></object><embed src="x.swf">`},
+		{"markdown raw html", FormatMarkdown, "# Title\n\n<script>alert(1)</script>\n\n<img src=x onerror=alert(1)>"},
+		{"markdown javascript link", FormatMarkdown, "[click](javascript:alert(1))"},
+		{"markdown javascript image", FormatMarkdown, "![x](javascript:alert(1))"},
+		{"markdown inline html", FormatMarkdown, `text <span onclick="alert(1)">x</span>`},
+		{"plain tags", FormatPlain, `<script>alert(1)</script> <img src=x onerror=alert(1)>`},
+		{"unknown format is plain", "", `<iframe src="https://evil.example"></iframe>`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertSafe(t, Render(tt.content, tt.format))
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name, format, content, want string
+	}{
+		{"plain paragraphs", FormatPlain, "first line\nsecond line\n\nnext", "<p>first line<br>\nsecond line</p>\n<p>next</p>\n"},
+		{"plain escapes", FormatPlain, `a < b & "c"`, "<p>a &lt; b &amp; &#34;c&#34;</p>\n"},
+		{"plain empty", FormatPlain, "  \n ", ""},
+		{"markdown emphasis", FormatMarkdown, "**bold** text", "<p><strong>bold</strong> text</p>\n"},
+		{"markdown link gets nofollow", FormatMarkdown, "[site](https://example.com)", `<p><a href="https://example.com" rel="nofollow">site</a></p>` + "\n"},
+		{"markdown code language", FormatMarkdown, "```go\nx := 1\n```", "<pre><code class=\"language-go\">x := 1\n</code></pre>\n"},
+		{"html keeps allowed markup", FormatHTML, `<p><em>kept</em></p>`, `<p><em>kept</em></p>`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Render(tt.content, tt.format); got != tt.want {
+				t.Errorf("Render(%q, %q) = %q, want %q", tt.content, tt.format, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestText(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+	}{
+		{"strips tags", "<p>Hello <strong>world</strong></p>", "Hello world"},
+		{"separates blocks", "<h1>Title</h1><p>body</p>", "Title\n\nbody"},
+		// Text يعيد نصًا عاديًا، فالكيانات تُفك ويجب تهريب الناتج قبل عرضه
+		{"decodes entities", "<p>&lt;img src=x onerror=alert(1)&gt;</p>", "<img src=x onerror=alert(1)>"},
+		{"empty", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Text(tt.in); got != tt.want {
+				t.Errorf("Text(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsFormat(t *testing.T) {
+	for _, format := range []string{FormatPlain, FormatMarkdown, FormatHTML} {
+		if !IsFormat(format) {
+			t.Errorf("IsFormat(%q) = false", format)
+		}
+	}
+	for _, format := range []string{"", "rtf", "Markdown"} {
+		if IsFormat(format) {
+			t.Errorf("IsFormat(%q) = true", format)
+		}
+	}
+}
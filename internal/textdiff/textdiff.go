@@ -0,0 +1,176 @@
+// my-article-app/internal/textdiff/textdiff.go
+package textdiff
+
+import (
+	"strings"
+	"unicode"
+)
+
+// أنواع التعديل في نتيجة المقارنة
+const (
+	Equal  = "equal"
+	Insert = "insert"
+	Delete = "delete"
+)
+
+// maxEdits أقصى عدد تعديلات يبحث عنه الخوارزم قبل أن يعتبر الجزء المختلف استبدالاً كاملاً،
+// حتى تبقى الذاكرة والوقت محدودين مع النصوص المختلفة كليًا (الذاكرة تنمو مع مربع عدد التعديلات)
+const maxEdits = 1000
+
+// Edit مقطع متصل من النص بنوع واحد: مشترك، أو مضاف في النص الجديد، أو محذوف من القديم
+type Edit struct {
+	Kind string
+	Text string
+}
+
+// Lines يقارن النصين سطرًا بسطر؛ كل سطر يحتفظ بنهايته فيُعاد بناء النصين من المقاطع كما هما
+func Lines(a, b string) []Edit {
+	return diff(splitLines(a), splitLines(b))
+}
+
+// Words يقارن النصين كلمة بكلمة؛ المسافات مقاطع مستقلة فلا يظهر تغيير المسافة كتغيير للكلمة المجاورة
+func Words(a, b string) []Edit {
+	return diff(splitWords(a), splitWords(b))
+}
+
+// splitLines يقسم النص إلى أسطر مع إبقاء "\n" في نهاية كل سطر
+func splitLines(s string) []string {
+	lines := strings.SplitAfter(s, "\n")
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// splitWords يقسم النص إلى كلمات ومسافات متتابعة
+func splitWords(s string) []string {
+	var tokens []string
+	start, inSpace := 0, false
+	for i, r := range s {
+		space := unicode.IsSpace(r)
+		if i > start && space != inSpace {
+			tokens = append(tokens, s[start:i])
+			start = i
+		}
+		inSpace = space
+	}
+	if start < len(s) {
+		tokens = append(tokens, s[start:])
+	}
+	return tokens
+}
+
+// diff يقارن تسلسلين من المقاطع: يستبعد البداية والنهاية المشتركتين ثم يطبق خوارزم Myers على الباقي
+func diff(a, b []string) []Edit {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	var edits []Edit
+	for _, token := range a[:prefix] {
+		edits = append(edits, Edit{Kind: Equal, Text: token})
+	}
+	middleA, middleB := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	if middle, ok := myers(middleA, middleB); ok {
+		edits = append(edits, middle...)
+	} else {
+		for _, token := range middleA {
+			edits = append(edits, Edit{Kind: Delete, Text: token})
+		}
+		for _, token := range middleB {
+			edits = append(edits, Edit{Kind: Insert, Text: token})
+		}
+	}
+	for _, token := range a[len(a)-suffix:] {
+		edits = append(edits, Edit{Kind: Equal, Text: token})
+	}
+	return merge(edits)
+}
+
+// myers يجد أقصر سلسلة تعديلات بخوارزم Myers، ويعيد false إذا تجاوز عدد التعديلات maxEdits
+// trace[d] نسخة من أبعد x على كل قطر k في المدى [-d-1, d+1] قبل الخطوة d، وتُستخدم لتتبع المسار عكسيًا
+func myers(a, b []string) ([]Edit, bool) {
+	n, m := len(a), len(b)
+	limit := min(n+m, maxEdits)
+	offset := limit + 1
+	v := make([]int, 2*limit+3)
+	var trace [][]int
+
+	for d := 0; d <= limit; d++ {
+		trace = append(trace, append([]int(nil), v[offset-d-1:offset+d+2]...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x, y = x+1, y+1
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return backtrack(trace, a, b), true
+			}
+		}
+	}
+	return nil, false
+}
+
+// backtrack يعيد بناء التعديلات من آخر خطوة إلى أولها ثم يعكس ترتيبها
+func backtrack(trace [][]int, a, b []string) []Edit {
+	x, y := len(a), len(b)
+	var reversed []Edit
+	for d := len(trace) - 1; d > 0; d-- {
+		v := trace[d]
+		at := func(k int) int { return v[k+d+1] }
+
+		k := x - y
+		prevK := k - 1
+		if k == -d || (k != d && at(k-1) < at(k+1)) {
+			prevK = k + 1
+		}
+		prevX := at(prevK)
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			reversed = append(reversed, Edit{Kind: Equal, Text: a[x-1]})
+			x, y = x-1, y-1
+		}
+		if x == prevX {
+			reversed = append(reversed, Edit{Kind: Insert, Text: b[y-1]})
+		} else {
+			reversed = append(reversed, Edit{Kind: Delete, Text: a[x-1]})
+		}
+		x, y = prevX, prevY
+	}
+	for x > 0 && y > 0 {
+		reversed = append(reversed, Edit{Kind: Equal, Text: a[x-1]})
+		x, y = x-1, y-1
+	}
+
+	edits := make([]Edit, 0, len(reversed))
+	for i := len(reversed) - 1; i >= 0; i-- {
+		edits = append(edits, reversed[i])
+	}
+	return edits
+}
+
+// merge يدمج المقاطع المتتالية من النوع نفسه في مقطع واحد
+func merge(edits []Edit) []Edit {
+	merged := make([]Edit, 0, len(edits))
+	for _, e := range edits {
+		if n := len(merged); n > 0 && merged[n-1].Kind == e.Kind {
+			merged[n-1].Text += e.Text
+			continue
+		}
+		merged = append(merged, e)
+	}
+	return merged
+}
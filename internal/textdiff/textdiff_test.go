@@ -0,0 +1,80 @@
+// my-article-app/internal/textdiff/textdiff_test.go
+package textdiff
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// rebuild يعيد بناء النص القديم (بلا المضاف) والجديد (بلا المحذوف) من المقاطع
+func rebuild(edits []Edit) (oldText, newText string) {
+	var a, b strings.Builder
+	for _, e := range edits {
+		if e.Kind != Insert {
+			a.WriteString(e.Text)
+		}
+		if e.Kind != Delete {
+			b.WriteString(e.Text)
+		}
+	}
+	return a.String(), b.String()
+}
+
+func TestDiff(t *testing.T) {
+	manyLines := strings.Repeat("old line\n", maxEdits+10)
+	otherLines := strings.Repeat("new line\n", maxEdits+10)
+
+	tests := []struct {
+		name    string
+		compare func(a, b string) []Edit
+		a, b    string
+		want    []Edit
+	}{
+		{"empty texts", Lines, "", "", []Edit{}},
+		{"identical lines", Lines, "one\ntwo\n", "one\ntwo\n", []Edit{{Equal, "one\ntwo\n"}}},
+		{"identical words", Words, "same words here", "same words here", []Edit{{Equal, "same words here"}}},
+		{"line changed", Lines, "first\nsecond\nthird\n", "first\nSECOND\nthird\n",
+			[]Edit{{Equal, "first\n"}, {Delete, "second\n"}, {Insert, "SECOND\n"}, {Equal, "third\n"}}},
+		{"line added at end", Lines, "first\n", "first\nsecond\n", []Edit{{Equal, "first\n"}, {Insert, "second\n"}}},
+		{"line removed", Lines, "first\nsecond\nthird\n", "first\nthird\n",
+			[]Edit{{Equal, "first\n"}, {Delete, "second\n"}, {Equal, "third\n"}}},
+		{"from empty", Lines, "", "new\n", []Edit{{Insert, "new\n"}}},
+		{"to empty", Lines, "old\n", "", []Edit{{Delete, "old\n"}}},
+		{"missing final newline", Lines, "a\nb", "a\nb\n", []Edit{{Equal, "a\n"}, {Delete, "b"}, {Insert, "b\n"}}},
+		{"word changed", Words, "the quick fox", "the slow fox",
+			[]Edit{{Equal, "the "}, {Delete, "quick"}, {Insert, "slow"}, {Equal, " fox"}}},
+		{"arabic words", Words, "مقدمة في البرمجة", "مقدمة في الخوارزميات",
+			[]Edit{{Equal, "مقدمة في "}, {Delete, "البرمجة"}, {Insert, "الخوارزميات"}}},
+		{"whitespace change", Words, "a b", "a  b", []Edit{{Equal, "a"}, {Delete, " "}, {Insert, "  "}, {Equal, "b"}}},
+		// تجاوز maxEdits يجعل الجزء المختلف استبدالًا كاملًا بدل البحث عن أقصر مسار
+		{"beyond max edits", Lines, manyLines, otherLines, []Edit{{Delete, manyLines}, {Insert, otherLines}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.compare(tt.a, tt.b)
+			if !reflect.DeepEqual(got, tt.want) {
+				if len(tt.a) > 100 {
+					t.Fatalf("diff returned %d edits, want %d", len(got), len(tt.want))
+				}
+				t.Fatalf("diff(%q, %q) = %q, want %q", tt.a, tt.b, got, tt.want)
+			}
+			if a, b := rebuild(got); a != tt.a || b != tt.b {
+				t.Errorf("edits rebuild %q, %q; want %q, %q", a, b, tt.a, tt.b)
+			}
+		})
+	}
+}
+
+// TestDiffIdenticalHasNoChanges يتحقق أن النصين المتطابقين لا ينتجان أي إضافة أو حذف
+func TestDiffIdenticalHasNoChanges(t *testing.T) {
+	for _, text := range []string{"", "single line", "one\ntwo\n\nfour\n", "  spaced   words  "} {
+		for _, compare := range []func(a, b string) []Edit{Lines, Words} {
+			for _, e := range compare(text, text) {
+				if e.Kind != Equal {
+					t.Errorf("diff of identical %q has %s %q", text, e.Kind, e.Text)
+				}
+			}
+		}
+	}
+}
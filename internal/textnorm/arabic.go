@@ -0,0 +1,137 @@
+// my-article-app/internal/textnorm/arabic.go
+package textnorm
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// tatweel حرف التطويل (الكشيدة) الذي يُحذف دائمًا
+const tatweel = 'ـ'
+
+// letterMap توحيد أشكال الحروف المتقاربة إلى شكل واحد
+var letterMap = map[rune]rune{
+	'آ': 'ا', // آ → ا
+	'أ': 'ا', // أ → ا
+	'إ': 'ا', // إ → ا
+	'ٱ': 'ا', // ٱ → ا
+	'ؤ': 'و', // ؤ → و
+	'ئ': 'ي', // ئ → ي
+	'ة': 'ه', // ة → ه
+	'ى': 'ي', // ى → ي
+}
+
+// isTashkeel يحدد ما إذا كان الحرف من علامات التشكيل (الحركات والتنوين والشدة والسكون وما شابهها)
+func isTashkeel(r rune) bool {
+	return (r >= 'ً' && r <= 'ٟ') || r == 'ٰ' ||
+		(r >= 'ؐ' && r <= 'ؚ') || (r >= 'ۖ' && r <= 'ۭ')
+}
+
+// digit يحوّل الأرقام العربية الهندية (٠-٩) والفارسية (۰-۹) إلى أرقام لاتينية
+func digit(r rune) (rune, bool) {
+	switch {
+	case r >= '٠' && r <= '٩':
+		return '0' + (r - '٠'), true
+	case r >= '۰' && r <= '۹':
+		return '0' + (r - '۰'), true
+	}
+	return r, false
+}
+
+// normalizeRune يطبّق قواعد التوحيد على حرف واحد ويكتب الناتج (قد يكون فارغًا)
+func normalizeRune(b *strings.Builder, r rune) {
+	// أشكال العرض (Presentation Forms) والحروف المركبة تتحول إلى حروفها الأساسية
+	for _, c := range norm.NFKC.String(string(r)) {
+		switch {
+		case c == tatweel || isTashkeel(c):
+			continue
+		case unicode.IsSpace(c):
+			b.WriteRune(' ')
+			continue
+		}
+		if d, ok := digit(c); ok {
+			b.WriteRune(d)
+			continue
+		}
+		if m, ok := letterMap[c]; ok {
+			b.WriteRune(m)
+			continue
+		}
+		b.WriteRune(unicode.ToLower(c))
+	}
+}
+
+// Normalize يعيد شكلاً موحدًا من النص يُستخدم للبحث والمقارنة:
+// حذف التشكيل والتطويل، توحيد الألف والهمزات، التاء المربوطة إلى هاء، الألف المقصورة إلى ياء،
+// تحويل الأرقام العربية الهندية إلى لاتينية، تصغير الأحرف اللاتينية، وضغط المسافات
+func Normalize(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		normalizeRune(&b, r)
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// Span مدى بايتات [Start, End) في النص الأصلي
+type Span struct {
+	Start, End int
+}
+
+// FindAll يبحث عن جميع مواضع query في text بعد توحيد الطرفين،
+// ويعيد المواضع بالنسبة للنص الأصلي حتى يمكن تمييزها دون فقدان التشكيل
+func FindAll(text, query string) []Span {
+	q := Normalize(query)
+	if q == "" {
+		return nil
+	}
+
+	// نبني النص الموحد حرفًا حرفًا مع خريطة من كل بايت فيه إلى بداية الحرف الأصلي ونهايته،
+	// ونضغط المسافات المتتالية كما يفعل Normalize بالعبارة وإلا لم تطابق عبارة من عدة كلمات
+	var normalized []byte
+	var starts, ends []int
+	var b strings.Builder
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		b.Reset()
+		normalizeRune(&b, r)
+		for _, c := range []byte(b.String()) {
+			if c == ' ' && len(normalized) > 0 && normalized[len(normalized)-1] == ' ' {
+				continue
+			}
+			normalized = append(normalized, c)
+			starts = append(starts, i)
+			ends = append(ends, i+size)
+		}
+		i += size
+	}
+	haystack := string(normalized)
+
+	var spans []Span
+	for offset := 0; offset < len(haystack); {
+		i := strings.Index(haystack[offset:], q)
+		if i < 0 {
+			break
+		}
+		start, end := offset+i, offset+i+len(q)
+		spans = append(spans, Span{Start: starts[start], End: extendOverMarks(text, ends[end-1])})
+		offset = end
+	}
+	return spans
+}
+
+// extendOverMarks يمد نهاية التطابق لتشمل علامات التشكيل والتطويل التالية مباشرة
+// حتى لا تنفصل الحركة عن حرفها عند إدراج علامات التمييز
+func extendOverMarks(text string, end int) int {
+	for end < len(text) {
+		r, size := utf8.DecodeRuneInString(text[end:])
+		if r != tatweel && !isTashkeel(r) {
+			break
+		}
+		end += size
+	}
+	return end
+}
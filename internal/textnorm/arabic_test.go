@@ -0,0 +1,83 @@
+// my-article-app/internal/textnorm/arabic_test.go
+package textnorm
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+	}{
+		{"empty", "", ""},
+		{"tashkeel", "مُقَدِّمَةٌ", "مقدمه"},
+		{"tanween and sukun", "كِتَابًا مَكْتُوْبٌ", "كتابا مكتوب"},
+		{"superscript alef", "هٰذا", "هذا"},
+		{"tatweel", "مـــقـــال", "مقال"},
+		{"alef madda", "آمن", "امن"},
+		{"alef hamza above", "أحمد", "احمد"},
+		{"alef hamza below", "إسلام", "اسلام"},
+		{"alef wasla", "ٱلكتاب", "الكتاب"},
+		{"waw hamza", "مؤمن", "مومن"},
+		{"yeh hamza", "سائل", "سايل"},
+		{"taa marbuta", "مدرسة", "مدرسه"},
+		{"alef maqsura", "مستشفى", "مستشفي"},
+		{"arabic-indic digits", "٢٠٢٤", "2024"},
+		{"persian digits", "۱۲۳", "123"},
+		{"presentation forms", "ﻻ", "لا"},
+		{"latin lowercase", "Hello WORLD", "hello world"},
+		{"collapses whitespace", "  مقال \t\n جديد  ", "مقال جديد"},
+		{"mixed", "الإصدارُ ٣ مِنْ مَكتبةِ Go", "الاصدار 3 من مكتبه go"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Normalize(tt.in); got != tt.want {
+				t.Errorf("Normalize(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindAll(t *testing.T) {
+	tests := []struct {
+		name, text, query string
+		want              []string
+	}{
+		{"empty query", "أي نص", "   ", nil},
+		{"no match", "مقال عن البرمجة", "شعر", nil},
+		{"plain match", "مقال عن البرمجة", "البرمجة", []string{"البرمجة"}},
+		{"keeps tashkeel of the original", "مُقَدِّمَة قصيرة", "مقدمه", []string{"مُقَدِّمَة"}},
+		{"trailing tashkeel stays with its letter", "كتابٌ جديد", "كتاب", []string{"كتابٌ"}},
+		{"tatweel inside match", "مـــقال", "مقال", []string{"مـــقال"}},
+		{"hamza variants", "أحمد وإحسان", "احمد", []string{"أحمد"}},
+		{"alef maqsura and taa marbuta", "مستشفى المدينة", "مستشفي المدينه", []string{"مستشفى المدينة"}},
+		{"digits", "الفصل ٣ والفصل 3", "3", []string{"٣", "3"}},
+		{"case insensitive", "Go and GO", "go", []string{"Go", "GO"}},
+		{"every occurrence", "علم وعلم", "علم", []string{"علم", "علم"}},
+		{"collapsed whitespace in text", "مقدمة   في\t\nالبرمجة", "مقدمة في البرمجة", []string{"مقدمة   في\t\nالبرمجة"}},
+		{"collapsed whitespace in query", "مقدمة في البرمجة", "مقدمة    في البرمجة", []string{"مقدمة في البرمجة"}},
+		{"whitespace around tashkeel", "سلامٌ  ،  عليكم", "، عليكم", []string{"،  عليكم"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, span := range FindAll(tt.text, tt.query) {
+				got = append(got, tt.text[span.Start:span.End])
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindAll(%q, %q) = %q, want %q", tt.text, tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindAllSpans(t *testing.T) {
+	// المواضع بالبايت في النص الأصلي: كل حرف عربي بايتان
+	text := "أ  ب"
+	got := FindAll(text, "ا ب")
+	want := []Span{{Start: 0, End: len(text)}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FindAll(%q) = %v, want %v", text, got, want)
+	}
+}
@@ -0,0 +1,104 @@
+// my-article-app/internal/textnorm/slug.go
+package textnorm
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// MaxSlugLength أقصى طول للمعرف النصي بالحروف، بما فيه لاحقة التمييز مثل -2
+const MaxSlugLength = 80
+
+// arabicLatin نقل الحروف العربية (والفارسية الشائعة) إلى حروف لاتينية مبسطة بلا علامات
+// الهمزات فوق الحروف وتحتها تُفصل عنها بالتفكيك (NFKD) فتصل هنا حروفها الأساسية
+var arabicLatin = map[rune]string{
+	'ا': "a", 'ب': "b", 'ت': "t", 'ث': "th", 'ج': "j", 'ح': "h", 'خ': "kh",
+	'د': "d", 'ذ': "dh", 'ر': "r", 'ز': "z", 'س': "s", 'ش': "sh", 'ص': "s",
+	'ض': "d", 'ط': "t", 'ظ': "z", 'ع': "a", 'غ': "gh", 'ف': "f", 'ق': "q",
+	'ك': "k", 'ل': "l", 'م': "m", 'ن': "n", 'ه': "h", 'و': "w", 'ي': "y",
+	'ى': "a", 'ة': "a", 'ء': "",
+	'پ': "p", 'چ': "ch", 'ژ': "zh", 'گ': "g", 'ک': "k", 'ی': "y",
+}
+
+// Slug يحوّل النص إلى معرف نصي صالح للروابط: الحروف العربية تُنقل إلى اللاتينية، والحروف اللاتينية
+// تفقد علاماتها وتُصغّر، وحروف اللغات الأخرى تبقى كما هي (تُرمّز في الرابط)، وما سوى ذلك يصبح شرطة واحدة
+// يعيد نصًا فارغًا إذا لم يبقَ من النص حرف أو رقم
+func Slug(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	dash := false
+	for _, r := range norm.NFC.String(s) {
+		for _, c := range slugRunes(r) {
+			if d, ok := digit(c); ok {
+				c = d
+			}
+			if latin, ok := arabicLatin[c]; ok {
+				b.WriteString(latin)
+				dash = false
+				continue
+			}
+			if unicode.IsLetter(c) || unicode.IsDigit(c) || unicode.IsMark(c) {
+				b.WriteRune(unicode.ToLower(c))
+				dash = false
+				continue
+			}
+			if !dash && b.Len() > 0 {
+				b.WriteByte('-')
+				dash = true
+			}
+		}
+	}
+	return truncateSlug(b.String(), MaxSlugLength)
+}
+
+// slugRunes يفكك الحرف اللاتيني أو العربي (NFKD) ويحذف علاماته، ويحذف التشكيل والتطويل والفواصل العليا
+// والعلامات المشتركة بين اللغات (Inherited)، ويعيد حروف اللغات الأخرى وعلاماتها كما هي لأنها جزء من الكلمة
+func slugRunes(r rune) []rune {
+	switch {
+	case r == tatweel || r == '\'' || r == '’' || unicode.Is(unicode.Inherited, r):
+		return nil
+	case !unicode.In(r, unicode.Latin, unicode.Arabic, unicode.Common):
+		return []rune{r}
+	}
+	var runes []rune
+	for _, c := range norm.NFKD.String(string(r)) {
+		if !unicode.Is(unicode.Mn, c) {
+			runes = append(runes, c)
+		}
+	}
+	return runes
+}
+
+// SlugCandidate يعيد المرشح رقم n للمعرف النصي base: الأول هو base نفسه، وما بعده base-2 و base-3...
+// ويقصّر base عند الحاجة حتى لا يتجاوز المرشح MaxSlugLength مع لاحقته
+func SlugCandidate(base string, n int) string {
+	if n <= 1 {
+		return base
+	}
+	suffix := "-" + strconv.Itoa(n)
+	return truncateSlug(base, MaxSlugLength-len(suffix)) + suffix
+}
+
+// SlugFrom يحدد ما إذا كان slug أحد مرشحي base، فلا يتغير المعرف النصي إذا لم يتغير أساسه
+func SlugFrom(slug, base string) bool {
+	if slug == base {
+		return true
+	}
+	i := strings.LastIndexByte(slug, '-')
+	if i < 0 {
+		return false
+	}
+	n, err := strconv.Atoi(slug[i+1:])
+	return err == nil && n >= 2 && SlugCandidate(base, n) == slug
+}
+
+// truncateSlug يقصّر المعرف إلى max حرفًا ويحذف الشرطات من طرفيه
+func truncateSlug(slug string, max int) string {
+	if runes := []rune(slug); len(runes) > max {
+		slug = string(runes[:max])
+	}
+	return strings.Trim(slug, "-")
+}
@@ -0,0 +1,90 @@
+// my-article-app/internal/textnorm/slug_test.go
+package textnorm
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSlug(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+	}{
+		{"empty", "", ""},
+		{"only symbols", "!!! ... ???", ""},
+		{"only tashkeel and tatweel", "ـــ َ ُ ـ", ""},
+		{"latin", "Hello, World!", "hello-world"},
+		{"latin accents", "Café Déjà Vu", "cafe-deja-vu"},
+		{"apostrophe joins the word", "Don't stop", "dont-stop"},
+		{"arabic", "مقدمة في البرمجة", "mqdma-fy-albrmja"},
+		{"arabic hamzas", "أحمد وإسلام", "ahmd-waslam"},
+		{"arabic tashkeel and tatweel", "مُقَدِّمَـــة", "mqdma"},
+		{"arabic-indic digits", "الإصدار ٣", "alasdar-3"},
+		{"mixed scripts", "دليل Go ٢٠٢٤", "dlyl-go-2024"},
+		{"other scripts kept", "Привет мир", "привет-мир"},
+		{"collapses separators", "  a -- b__c  ", "a-b-c"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Slug(tt.in); got != tt.want {
+				t.Errorf("Slug(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestSlugLength يتحقق أن المعرف الطويل يُقصّر بلا شرطة في آخره، وأن لاحقة المرشح لا تتجاوز الحد
+func TestSlugLength(t *testing.T) {
+	long := Slug(strings.Repeat("ab ", 100))
+	if n := utf8.RuneCountInString(long); n > MaxSlugLength || strings.HasSuffix(long, "-") {
+		t.Fatalf("Slug of a long title = %q (%d runes), want at most %d without a trailing dash", long, n, MaxSlugLength)
+	}
+	candidate := SlugCandidate(long, 12)
+	if n := utf8.RuneCountInString(candidate); n > MaxSlugLength || !strings.HasSuffix(candidate, "-12") || strings.Contains(candidate, "--") {
+		t.Errorf("SlugCandidate(long, 12) = %q (%d runes), want at most %d ending in -12", candidate, n, MaxSlugLength)
+	}
+	if !SlugFrom(candidate, long) {
+		t.Errorf("SlugFrom(%q, base) = false, want the shortened candidate recognised", candidate)
+	}
+}
+
+func TestSlugCandidate(t *testing.T) {
+	tests := []struct {
+		n    int
+		want string
+	}{
+		{0, "mqdma"},
+		{1, "mqdma"},
+		{2, "mqdma-2"},
+		{3, "mqdma-3"},
+		{10, "mqdma-10"},
+	}
+	for _, tt := range tests {
+		if got := SlugCandidate("mqdma", tt.n); got != tt.want {
+			t.Errorf("SlugCandidate(%q, %d) = %q, want %q", "mqdma", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestSlugFrom(t *testing.T) {
+	tests := []struct {
+		slug, base string
+		want       bool
+	}{
+		{"go-basics", "go-basics", true},
+		{"go-basics-2", "go-basics", true},
+		{"go-basics-17", "go-basics", true},
+		// -1 ليس لاحقة مرشح، والرقم جزء من العنوان نفسه في "go-2"
+		{"go-basics-1", "go-basics", false},
+		{"go-2", "go", true},
+		{"go-basics-x", "go-basics", false},
+		{"go-basics", "go", false},
+		{"other-2", "go-basics", false},
+	}
+	for _, tt := range tests {
+		if got := SlugFrom(tt.slug, tt.base); got != tt.want {
+			t.Errorf("SlugFrom(%q, %q) = %v, want %v", tt.slug, tt.base, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,432 @@
+// my-article-app/internal/usecase/article_bulk.go
+package usecase
+
+import (
+	"context"
+	"errors"
+	"my-article-app/internal/apperr"
+	"my-article-app/internal/dto"
+	"my-article-app/internal/models"
+	"my-article-app/internal/repository"
+	"my-article-app/internal/textnorm"
+	"slices"
+	"time"
+)
+
+// bulkBatchSize عدد الصفوف في كل INSERT مجمّع، وعدد العناصر في كل معاملة عند الإنشاء بوضع best_effort
+const bulkBatchSize = 100
+
+// errBulkAborted نتيجة العناصر التي لم تُنفذ (أو تُراجع عنها) لأن عنصرًا آخر في دفعة atomic فشل
+var errBulkAborted = apperr.Aborted("bulk_aborted")
+
+// bulkResults نتائج العناصر بترتيبها في الطلب؛ العنصر الذي يحمل Err فاشل
+type bulkResults []dto.BulkItemResult
+
+// newBulkResults ينشئ النتائج ويعلّم العناصر التي رفضها التحقق من المدخلات قبل الوصول إلى هنا
+func newBulkResults(n int, rejected map[int]error) bulkResults {
+	results := make(bulkResults, n)
+	for i := range results {
+		results[i].Index = i
+		results[i].Err = rejected[i]
+	}
+	return results
+}
+
+// failed يحدد ما إذا فشل أي عنصر
+func (r bulkResults) failed() bool {
+	for _, item := range r {
+		if item.Err != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// abort يعلّم كل عنصر لم يفشل بنفسه كعنصر ملغى، بعد التراجع عن معاملة دفعة atomic
+func (r bulkResults) abort() {
+	for i := range r {
+		if r[i].Err == nil {
+			r[i].Err = errBulkAborted
+			r[i].Article = nil
+		}
+	}
+}
+
+// rejectDuplicateIDs يرفض تكرار المعرف نفسه في الطلب، فيبقى التنفيذ الأول وحده
+func (r bulkResults) rejectDuplicateIDs(ids []uint) {
+	seen := make(map[uint]bool, len(ids))
+	for i, id := range ids {
+		if r[i].Err == nil && seen[id] {
+			r[i].Err = apperr.Validation("bulk_duplicate_id", id)
+		}
+		seen[id] = true
+	}
+}
+
+// response يبني التقرير النهائي مع عدد العناصر الناجحة والفاشلة
+func (r bulkResults) response(mode string) *dto.BulkResponse {
+	resp := &dto.BulkResponse{Mode: mode, Results: r}
+	for _, item := range r {
+		if item.Err != nil {
+			resp.Failed++
+		} else {
+			resp.Succeeded++
+		}
+	}
+	return resp
+}
+
+// bulkMode يعيد الوضع الافتراضي atomic عند عدم تحديده
+func bulkMode(mode string) string {
+	if mode == "" {
+		return dto.BulkModeAtomic
+	}
+	return mode
+}
+
+// runBulk ينفذ apply لكل عنصر لم يُرفض مسبقًا:
+// في وضع atomic داخل معاملة واحدة تُلغى كاملة عند أول فشل، وفي best_effort كل عنصر في معاملته الخاصة
+func (uc *articleUseCase) runBulk(ctx context.Context, mode string, results bulkResults, apply func(repos repository.Repositories, i int) error) *dto.BulkResponse {
+	if mode == dto.BulkModeBestEffort {
+		for i := range results {
+			if results[i].Err != nil {
+				continue
+			}
+			results[i].Err = uc.txManager.WithinTx(ctx, func(repos repository.Repositories) error {
+				return apply(repos, i)
+			})
+			if results[i].Err != nil {
+				results[i].Article = nil
+			}
+		}
+		return results.response(mode)
+	}
+
+	if results.failed() {
+		results.abort()
+		return results.response(mode)
+	}
+	err := uc.txManager.WithinTx(ctx, func(repos repository.Repositories) error {
+		for i := range results {
+			if err := apply(repos, i); err != nil {
+				results[i].Err = err
+				return errBulkAborted
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		// فشل التثبيت نفسه دون فشل أي عنصر يُنسب إلى كل العناصر
+		if !errors.Is(err, errBulkAborted) {
+			for i := range results {
+				results[i].Err = err
+			}
+		}
+		results.abort()
+	}
+	return results.response(mode)
+}
+
+// BulkCreateArticles ينشئ عدة مقالات: المؤلفون والعناوين يُتحقق منها باستعلام واحد لكل دفعة،
+// والمقالات تُدرج بـ INSERT مجمّع؛ rejected العناصر التي فشلت في التحقق من المدخلات مفهرسة بترتيبها
+func (uc *articleUseCase) BulkCreateArticles(ctx context.Context, mode string, items []dto.CreateArticleRequest, rejected map[int]error) (*dto.BulkResponse, error) {
+	mode = bulkMode(mode)
+	b := &bulkCreate{
+		items:      items,
+		results:    newBulkResults(len(items), rejected),
+		authors:    make(map[uint]models.Author),
+		tags:       make(map[int][]models.Tag),
+		categories: make(map[uint]models.Category),
+		seen:       make(map[string]int),
+	}
+
+	if mode == dto.BulkModeBestEffort {
+		for start := 0; start < len(items); start += bulkBatchSize {
+			uc.createChunk(ctx, b, b.pending(start, min(start+bulkBatchSize, len(items))))
+		}
+		return b.results.response(mode), nil
+	}
+
+	if b.results.failed() {
+		b.results.abort()
+		return b.results.response(mode), nil
+	}
+	var ready []int
+	var articles []*models.Article
+	err := uc.txManager.WithinTx(ctx, func(repos repository.Repositories) error {
+		var err error
+		if ready, err = b.check(ctx, repos, b.pending(0, len(items))); err != nil {
+			return err
+		}
+		if b.results.failed() {
+			return errBulkAborted
+		}
+		articles, err = b.insert(ctx, repos, ready)
+		return err
+	})
+	if errors.Is(err, errBulkAborted) {
+		b.results.abort()
+		return b.results.response(mode), nil
+	}
+	if err != nil {
+		// فشل الإدراج المجمّع لا يحدد العنصر المسبب (مثل تعارض عنوان مع طلب متزامن)، فيُعاد كخطأ للطلب كله
+		return nil, err
+	}
+	b.succeed(ready, articles)
+	return b.results.response(mode), nil
+}
+
+// createChunk ينشئ دفعة واحدة في وضع best_effort داخل معاملتها الخاصة؛
+// إذا فشل الإدراج المجمّع يُعاد إنشاء عناصرها واحدًا واحدًا لتحديد العنصر الفاشل دون إسقاط البقية
+func (uc *articleUseCase) createChunk(ctx context.Context, b *bulkCreate, chunk []int) {
+	if len(chunk) == 0 {
+		return
+	}
+
+	var ready []int
+	var articles []*models.Article
+	err := uc.txManager.WithinTx(ctx, func(repos repository.Repositories) error {
+		var err error
+		if ready, err = b.check(ctx, repos, chunk); err != nil {
+			return err
+		}
+		articles, err = b.insert(ctx, repos, ready)
+		return err
+	})
+	if err == nil {
+		b.succeed(ready, articles)
+		return
+	}
+
+	for _, i := range chunk {
+		if b.results[i].Err != nil {
+			continue
+		}
+		article, err := uc.CreateArticle(ctx, &b.items[i])
+		if err != nil {
+			b.results[i].Err = err
+			// العنصر لم يُنشأ، فلا يُعد عنوانه مكررًا لما بعده
+			if title := textnorm.Normalize(b.items[i].Title); b.seen[title] == i {
+				delete(b.seen, title)
+			}
+			continue
+		}
+		b.results[i].ID = article.ID
+		b.results[i].Article = article
+	}
+}
+
+// bulkCreate حالة إنشاء مجمّع واحد عبر دفعاته
+type bulkCreate struct {
+	items   []dto.CreateArticleRequest
+	results bulkResults
+	// authors المؤلفون الذين جُلبوا للتحقق، لبناء الاستجابة دون استعلام آخر
+	authors map[uint]models.Author
+	// tags وسوم كل عنصر بعد توحيدها، مفهرسة بترتيبه، و categories التصنيفات التي جُلبت للتحقق
+	tags       map[int][]models.Tag
+	categories map[uint]models.Category
+	// seen العناوين الموحدة المقبولة في هذا الطلب مع ترتيب أول عنصر حملها
+	seen map[string]int
+}
+
+// pending يعيد ترتيب العناصر غير المرفوضة في المدى [start, end)
+func (b *bulkCreate) pending(start, end int) []int {
+	indexes := make([]int, 0, end-start)
+	for i := start; i < end; i++ {
+		if b.results[i].Err == nil {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+// check يتحقق من المؤلفين (مع قفلهم حتى نهاية المعاملة) ومن تكرار العناوين في قاعدة البيانات وفي الطلب نفسه
+// ومن أسماء الوسوم ووجود التصنيفات، ويعلّم العناصر الفاشلة ويعيد الصالحة منها
+func (b *bulkCreate) check(ctx context.Context, repos repository.Repositories, indexes []int) ([]int, error) {
+	authorIDs := make([]uint, 0, len(indexes))
+	titles := make([]string, 0, len(indexes))
+	var categoryIDs []uint
+	for _, i := range indexes {
+		authorIDs = append(authorIDs, b.items[i].AuthorID)
+		titles = append(titles, b.items[i].Title)
+		categoryIDs = append(categoryIDs, b.items[i].CategoryIDs...)
+	}
+
+	authors, err := repos.Authors.FindByIDsForShare(ctx, authorIDs)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := repos.Articles.ExistingTitles(ctx, titles)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := repos.Categories.FindByIDs(ctx, categoryIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	ready := make([]int, 0, len(indexes))
+	for _, i := range indexes {
+		item := &b.items[i]
+		title := textnorm.Normalize(item.Title)
+		author, ok := authors[item.AuthorID]
+		if err := validateInitialStatus(item, now); err != nil {
+			b.results[i].Err = err
+			continue
+		}
+		tags, err := tagsFromNames(item.Tags)
+		if err != nil {
+			b.results[i].Err = err
+			continue
+		}
+		if err := missingCategory(categories, item.CategoryIDs); err != nil {
+			b.results[i].Err = err
+			continue
+		}
+		switch {
+		case !ok:
+			b.results[i].Err = apperr.Validation("article_author_not_found", item.AuthorID)
+		case existing[title]:
+			b.results[i].Err = ErrDuplicateTitle
+		default:
+			if first, dup := b.seen[title]; dup {
+				b.results[i].Err = apperr.Conflict("bulk_duplicate_title", first)
+				continue
+			}
+			b.seen[title] = i
+			b.authors[author.ID] = author
+			b.tags[i] = tags
+			for _, id := range item.CategoryIDs {
+				b.categories[id] = categories[id]
+			}
+			ready = append(ready, i)
+		}
+	}
+	return ready, nil
+}
+
+// insert يدرج العناصر الصالحة ومراجعاتها الأولى بـ INSERT مجمّع ويعيد المقالات بنفس ترتيب ready
+// المعرفات النصية المحجوزة لكل عناوين الدفعة تُجلب مرة واحدة، وتُحجز معرفات الدفعة بترتيبها
+func (b *bulkCreate) insert(ctx context.Context, repos repository.Repositories, ready []int) ([]*models.Article, error) {
+	now := time.Now()
+	articles := make([]*models.Article, 0, len(ready))
+	bases := make([]string, 0, len(ready))
+	for _, i := range ready {
+		article := newArticle(&b.items[i], now)
+		articles = append(articles, article)
+		bases = append(bases, slugBase(article.Title, fallbackArticleSlug))
+	}
+	claims, err := articleSlugClaims(ctx, repos, bases)
+	if err != nil {
+		return nil, err
+	}
+	for k, article := range articles {
+		article.Slug = claims.claim(bases[k], 0)
+	}
+	if err := repos.Articles.CreateBatch(ctx, articles, bulkBatchSize); err != nil {
+		return nil, err
+	}
+	if err := b.classify(ctx, repos, ready, articles); err != nil {
+		return nil, err
+	}
+
+	// المراجعة الأولى لكل مقال جديد، بإدراج مجمّع أيضًا
+	revisions := make([]*models.ArticleRevision, 0, len(articles))
+	for _, article := range articles {
+		revision := newRevision(ctx, article, nil)
+		revision.Revision = 1
+		revisions = append(revisions, revision)
+	}
+	return articles, repos.Revisions.CreateBatch(ctx, revisions, bulkBatchSize)
+}
+
+// classify يربط المقالات الجديدة بوسومها وتصنيفاتها بإدخالات مجمّعة؛
+// وسوم الدفعة كلها تُنشأ عند الحاجة باستدعاء Ensure واحد
+func (b *bulkCreate) classify(ctx context.Context, repos repository.Repositories, ready []int, articles []*models.Article) error {
+	var wanted []models.Tag
+	seen := make(map[string]bool)
+	for _, i := range ready {
+		for _, tag := range b.tags[i] {
+			if !seen[tag.Slug] {
+				seen[tag.Slug] = true
+				wanted = append(wanted, tag)
+			}
+		}
+	}
+	ensured, err := repos.Tags.Ensure(ctx, wanted)
+	if err != nil {
+		return err
+	}
+	bySlug := make(map[string]models.Tag, len(ensured))
+	for _, tag := range ensured {
+		bySlug[tag.Slug] = tag
+	}
+
+	var tagLinks []models.ArticleTag
+	var categoryLinks []models.ArticleCategory
+	for k, i := range ready {
+		article := articles[k]
+		for _, tag := range b.tags[i] {
+			article.Tags = append(article.Tags, bySlug[tag.Slug])
+			tagLinks = append(tagLinks, models.ArticleTag{ArticleID: article.ID, TagID: bySlug[tag.Slug].ID})
+		}
+		for _, id := range slices.Compact(slices.Sorted(slices.Values(b.items[i].CategoryIDs))) {
+			article.Categories = append(article.Categories, b.categories[id])
+			categoryLinks = append(categoryLinks, models.ArticleCategory{ArticleID: article.ID, CategoryID: id})
+		}
+	}
+	if err := repos.Tags.LinkArticles(ctx, tagLinks, bulkBatchSize); err != nil {
+		return err
+	}
+	return repos.Categories.LinkArticles(ctx, categoryLinks, bulkBatchSize)
+}
+
+// succeed يسجل نتائج المقالات التي أُنشئت بعد تثبيت معاملتها
+func (b *bulkCreate) succeed(ready []int, articles []*models.Article) {
+	for k, i := range ready {
+		author := b.authors[articles[k].AuthorID]
+		b.results[i].ID = articles[k].ID
+		b.results[i].Article = mapArticleToResponse(articles[k], &author)
+	}
+}
+
+// BulkUpdateArticles يحدّث عدة مقالات، ولكل عنصر رقم نسخة اختياري يقوم مقام If-Match
+func (uc *articleUseCase) BulkUpdateArticles(ctx context.Context, mode string, items []dto.BulkUpdateArticleItem, rejected map[int]error) (*dto.BulkResponse, error) {
+	results := newBulkResults(len(items), rejected)
+	ids := make([]uint, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+		results[i].ID = item.ID
+	}
+	results.rejectDuplicateIDs(ids)
+
+	return uc.runBulk(ctx, bulkMode(mode), results, func(repos repository.Repositories, i int) error {
+		item := &items[i]
+		article, err := updateArticle(ctx, repos, item.ID, &dto.UpdateArticleRequest{
+			Title:         item.Title,
+			Content:       item.Content,
+			ContentFormat: item.ContentFormat,
+			Tags:          item.Tags,
+			CategoryIDs:   item.CategoryIDs,
+		}, item.Version)
+		if err != nil {
+			return err
+		}
+		results[i].Article = mapArticleToResponse(article, &article.Author)
+		return nil
+	}), nil
+}
+
+// BulkDeleteArticles ينقل عدة مقالات إلى سلة المحذوفات
+func (uc *articleUseCase) BulkDeleteArticles(ctx context.Context, mode string, ids []uint, rejected map[int]error) (*dto.BulkResponse, error) {
+	results := newBulkResults(len(ids), rejected)
+	for i, id := range ids {
+		results[i].ID = id
+	}
+	results.rejectDuplicateIDs(ids)
+
+	return uc.runBulk(ctx, bulkMode(mode), results, func(repos repository.Repositories, i int) error {
+		return repos.Articles.Delete(ctx, ids[i])
+	}), nil
+}
@@ -0,0 +1,233 @@
+// my-article-app/internal/usecase/article_bulk_test.go
+package usecase
+
+import (
+	"context"
+	"errors"
+	"my-article-app/internal/apperr"
+	"my-article-app/internal/dto"
+	"my-article-app/internal/pagination"
+	"testing"
+)
+
+// articleTotal يعيد عدد المقالات غير المحذوفة بكل حالاتها
+func (e *testEnv) articleTotal(t *testing.T) int64 {
+	t.Helper()
+	page, err := e.articles.GetAllArticles(context.Background(), &dto.ArticleListQuery{Status: statusFilterAll}, pagination.Request{}, true)
+	if err != nil {
+		t.Fatalf("GetAllArticles: %v", err)
+	}
+	return page.Meta.Total
+}
+
+// wantItemErrors يقارن خطأ كل عنصر بالمتوقع بترتيبه، و nil يعني أن العنصر نجح
+func wantItemErrors(t *testing.T, resp *dto.BulkResponse, want ...error) {
+	t.Helper()
+	if len(resp.Results) != len(want) {
+		t.Fatalf("results = %d items, want %d", len(resp.Results), len(want))
+	}
+	failed := 0
+	for i, item := range resp.Results {
+		if item.Index != i {
+			t.Errorf("result %d has index %d", i, item.Index)
+		}
+		switch {
+		case want[i] == nil && item.Err != nil:
+			t.Errorf("item %d: err = %v, want success", i, item.Err)
+		case want[i] != nil && !errors.Is(item.Err, want[i]):
+			t.Errorf("item %d: err = %v, want %v", i, item.Err, want[i])
+		case want[i] != nil && item.Article != nil:
+			t.Errorf("failed item %d still carries an article", i)
+		}
+		if want[i] != nil {
+			failed++
+		}
+	}
+	if resp.Failed != failed || resp.Succeeded != len(want)-failed {
+		t.Errorf("succeeded, failed = %d, %d; want %d, %d", resp.Succeeded, resp.Failed, len(want)-failed, failed)
+	}
+}
+
+func TestBulkCreateModes(t *testing.T) {
+	missingAuthor := apperr.Validation("article_author_not_found")
+	tests := []struct {
+		name      string
+		mode      string
+		titles    []string
+		badAuthor int // ترتيب العنصر الذي يحمل مؤلفًا غير موجود، أو -1
+		want      []error
+		created   int64
+	}{
+		{
+			name: "atomic aborts on one bad item", mode: dto.BulkModeAtomic,
+			titles: []string{"First bulk article", "Second bulk article", "Third bulk article"}, badAuthor: 1,
+			want: []error{errBulkAborted, missingAuthor, errBulkAborted},
+		},
+		{
+			name: "default mode is atomic", mode: "",
+			titles: []string{"First bulk article", "Second bulk article"}, badAuthor: 0,
+			want: []error{missingAuthor, errBulkAborted},
+		},
+		{
+			name: "best effort keeps the valid items", mode: dto.BulkModeBestEffort,
+			titles: []string{"First bulk article", "Second bulk article", "Third bulk article"}, badAuthor: 1,
+			want: []error{nil, missingAuthor, nil}, created: 2,
+		},
+		{
+			name: "atomic duplicate titles in one request", mode: dto.BulkModeAtomic,
+			titles: []string{"Repeated bulk title", "Other bulk article", "repeated  BULK title"}, badAuthor: -1,
+			want: []error{errBulkAborted, errBulkAborted, apperr.Conflict("bulk_duplicate_title")},
+		},
+		{
+			name: "best effort duplicate titles in one request", mode: dto.BulkModeBestEffort,
+			titles: []string{"Repeated bulk title", "Other bulk article", "repeated  BULK title"}, badAuthor: -1,
+			want: []error{nil, nil, apperr.Conflict("bulk_duplicate_title")}, created: 2,
+		},
+		{
+			name: "title taken by an existing article", mode: dto.BulkModeBestEffort,
+			titles: []string{"Existing article title", "Fresh bulk article"}, badAuthor: -1,
+			want: []error{ErrDuplicateTitle, nil}, created: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			forEachBackend(t, func(t *testing.T, env *testEnv) {
+				ctx := context.Background()
+				authorID := env.createAuthor(t, "bulk-author")
+				if _, err := env.articles.CreateArticle(ctx, &dto.CreateArticleRequest{Title: "Existing article title", Content: "already stored", AuthorID: authorID}); err != nil {
+					t.Fatalf("CreateArticle: %v", err)
+				}
+
+				items := make([]dto.CreateArticleRequest, len(tt.titles))
+				for i, title := range tt.titles {
+					items[i] = dto.CreateArticleRequest{Title: title, Content: "bulk article content", AuthorID: authorID}
+					if i == tt.badAuthor {
+						items[i].AuthorID = authorID + 100
+					}
+				}
+				resp, err := env.articles.BulkCreateArticles(ctx, tt.mode, items, nil)
+				if err != nil {
+					t.Fatalf("BulkCreateArticles: %v", err)
+				}
+				wantItemErrors(t, resp, tt.want...)
+				for i, item := range resp.Results {
+					if tt.want[i] == nil && (item.Article == nil || item.Article.ID != item.ID || item.Article.Title != tt.titles[i]) {
+						t.Errorf("item %d = %+v, want the created article", i, item)
+					}
+				}
+				if resp.Mode != bulkMode(tt.mode) {
+					t.Errorf("mode = %q, want %q", resp.Mode, bulkMode(tt.mode))
+				}
+				if got := env.articleTotal(t) - 1; got != tt.created {
+					t.Errorf("articles created = %d, want %d", got, tt.created)
+				}
+			})
+		})
+	}
+}
+
+// TestBulkCreateRejectedItems يتحقق أن العناصر التي رفضها المعالج تظهر بخطئها ولا تُنفذ
+func TestBulkCreateRejectedItems(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, env *testEnv) {
+		ctx := context.Background()
+		authorID := env.createAuthor(t, "bulk-author")
+		invalid := apperr.Validation("validation_failed")
+		items := []dto.CreateArticleRequest{
+			{Title: "Valid bulk article", Content: "bulk article content", AuthorID: authorID},
+			{Title: "bad", AuthorID: authorID},
+		}
+		rejected := map[int]error{1: invalid}
+
+		resp, err := env.articles.BulkCreateArticles(ctx, dto.BulkModeAtomic, items, rejected)
+		if err != nil {
+			t.Fatalf("atomic BulkCreateArticles: %v", err)
+		}
+		wantItemErrors(t, resp, errBulkAborted, invalid)
+		if n := env.articleTotal(t); n != 0 {
+			t.Errorf("atomic batch with a rejected item created %d articles", n)
+		}
+
+		resp, err = env.articles.BulkCreateArticles(ctx, dto.BulkModeBestEffort, items, rejected)
+		if err != nil {
+			t.Fatalf("best effort BulkCreateArticles: %v", err)
+		}
+		wantItemErrors(t, resp, nil, invalid)
+	})
+}
+
+func TestBulkUpdateVersions(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, env *testEnv) {
+		ctx := context.Background()
+		authorID := env.createAuthor(t, "bulk-author")
+		var ids []uint
+		for _, title := range []string{"First versioned article", "Second versioned article"} {
+			created, err := env.articles.CreateArticle(ctx, &dto.CreateArticleRequest{Title: title, Content: "original content", AuthorID: authorID})
+			if err != nil {
+				t.Fatalf("CreateArticle: %v", err)
+			}
+			ids = append(ids, created.ID)
+		}
+		current, stale := uint(1), uint(0)
+
+		// النسخة القديمة في عنصر واحد تلغي الدفعة atomic كاملة
+		resp, err := env.articles.BulkUpdateArticles(ctx, dto.BulkModeAtomic, []dto.BulkUpdateArticleItem{
+			{ID: ids[0], Version: &current, Content: "updated content one"},
+			{ID: ids[1], Version: &stale, Content: "updated content two"},
+		}, nil)
+		if err != nil {
+			t.Fatalf("BulkUpdateArticles: %v", err)
+		}
+		wantItemErrors(t, resp, errBulkAborted, apperr.PreconditionFailed("version_mismatch"))
+		article, err := env.articles.GetArticleByID(ctx, ids[0], nil, true)
+		if err != nil {
+			t.Fatalf("GetArticleByID: %v", err)
+		}
+		if article.Version != 1 || article.Content != "original content" {
+			t.Errorf("article after aborted batch = v%d %q, want v1 with the original content", article.Version, article.Content)
+		}
+
+		resp, err = env.articles.BulkUpdateArticles(ctx, dto.BulkModeBestEffort, []dto.BulkUpdateArticleItem{
+			{ID: ids[0], Version: &current, Content: "updated content one"},
+			{ID: ids[1], Version: &stale, Content: "updated content two"},
+			{ID: ids[0], Content: "same id again here"},
+		}, nil)
+		if err != nil {
+			t.Fatalf("BulkUpdateArticles: %v", err)
+		}
+		wantItemErrors(t, resp, nil, apperr.PreconditionFailed("version_mismatch"), apperr.Validation("bulk_duplicate_id"))
+		if got := resp.Results[0].Article; got.Version != 2 || got.Content != "updated content one" {
+			t.Errorf("updated article = v%d %q, want v2 with the new content", got.Version, got.Content)
+		}
+	})
+}
+
+func TestBulkDelete(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, env *testEnv) {
+		ctx := context.Background()
+		authorID := env.createAuthor(t, "bulk-author")
+		created, err := env.articles.CreateArticle(ctx, &dto.CreateArticleRequest{Title: "Deleted in bulk", Content: "bulk article content", AuthorID: authorID})
+		if err != nil {
+			t.Fatalf("CreateArticle: %v", err)
+		}
+
+		resp, err := env.articles.BulkDeleteArticles(ctx, dto.BulkModeAtomic, []uint{created.ID, created.ID + 100}, nil)
+		if err != nil {
+			t.Fatalf("BulkDeleteArticles: %v", err)
+		}
+		if resp.Failed != 2 || !apperr.Is(resp.Results[1].Err, apperr.KindNotFound) || !errors.Is(resp.Results[0].Err, errBulkAborted) {
+			t.Fatalf("atomic delete with a missing id = %+v, want both failed", resp.Results)
+		}
+		if n := env.articleTotal(t); n != 1 {
+			t.Errorf("articles after aborted delete = %d, want 1", n)
+		}
+
+		resp, err = env.articles.BulkDeleteArticles(ctx, dto.BulkModeBestEffort, []uint{created.ID, created.ID}, nil)
+		if err != nil {
+			t.Fatalf("BulkDeleteArticles: %v", err)
+		}
+		wantItemErrors(t, resp, nil, apperr.Validation("bulk_duplicate_id"))
+		if n := env.articleTotal(t); n != 0 {
+			t.Errorf("articles after delete = %d, want 0", n)
+		}
+	})
+}
@@ -0,0 +1,112 @@
+// my-article-app/internal/usecase/article_filter.go
+package usecase
+
+import (
+	"my-article-app/internal/apperr"
+	"my-article-app/internal/dto"
+	"my-article-app/internal/models"
+	"my-article-app/internal/repository"
+	"my-article-app/internal/textnorm"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// صيغ التاريخ المقبولة في معاملات التصفية
+var filterTimeLayouts = []string{time.RFC3339, "2006-01-02"}
+
+// parseArticleFilter يحوّل معاملات الاستعلام النصية إلى شروط تصفية مُتحقق منها
+func parseArticleFilter(q *dto.ArticleListQuery) (repository.ArticleFilter, error) {
+	filter := repository.ArticleFilter{Status: models.ArticleStatusPublished}
+	if q == nil {
+		return filter, nil
+	}
+
+	var err error
+	if filter.Status, err = parseStatusFilter(q.Status); err != nil {
+		return filter, err
+	}
+
+	if q.AuthorID != "" {
+		id, err := strconv.ParseUint(q.AuthorID, 10, 32)
+		if err != nil || id == 0 {
+			return filter, apperr.Validation("invalid_author_filter", q.AuthorID)
+		}
+		filter.AuthorID = uint(id)
+	}
+
+	if filter.CreatedAfter, err = parseFilterTime("created_after", q.CreatedAfter); err != nil {
+		return filter, err
+	}
+	if filter.CreatedBefore, err = parseFilterTime("created_before", q.CreatedBefore); err != nil {
+		return filter, err
+	}
+	if filter.UpdatedSince, err = parseFilterTime("updated_since", q.UpdatedSince); err != nil {
+		return filter, err
+	}
+	if filter.CreatedAfter != nil && filter.CreatedBefore != nil && !filter.CreatedAfter.Before(*filter.CreatedBefore) {
+		return filter, apperr.Validation("invalid_created_range")
+	}
+
+	filter.TitleContains = strings.TrimSpace(q.Title)
+
+	// الوسم والتصنيف يُطابقان بمعرفيهما النصيين، فيقبلان الاسم أيضًا: ?tag=Go يطابق الوسم go
+	if q.Tag != "" {
+		if filter.Tag = textnorm.Slug(q.Tag); filter.Tag == "" {
+			return filter, apperr.Validation("invalid_tag_filter", q.Tag)
+		}
+	}
+	if q.Category != "" {
+		if filter.Category = textnorm.Slug(q.Category); filter.Category == "" {
+			return filter, apperr.Validation("invalid_category_filter", q.Category)
+		}
+	}
+
+	if filter.Sort, err = parseSort(q.Sort, repository.IsArticleSortField); err != nil {
+		return filter, err
+	}
+	return filter, nil
+}
+
+// parseFilterTime يحلل تاريخًا اختياريًا بصيغة RFC3339 أو YYYY-MM-DD
+func parseFilterTime(name, value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	for _, layout := range filterTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return &t, nil
+		}
+	}
+	return nil, apperr.Validation("invalid_date_filter", name, value)
+}
+
+// parseSort يحلل معامل الترتيب بصيغة "-created_at,title" (البادئة "-" تعني تنازليًا)
+// ويرفض الحقول غير الموجودة في القائمة المسموحة أو المكررة
+func parseSort(value string, allowed func(string) bool) ([]repository.SortField, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+
+	var fields []repository.SortField
+	seen := map[string]bool{}
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		desc := false
+		switch {
+		case strings.HasPrefix(part, "-"):
+			desc, part = true, part[1:]
+		case strings.HasPrefix(part, "+"):
+			part = part[1:]
+		}
+		if !allowed(part) {
+			return nil, apperr.Validation("unsortable_field", part)
+		}
+		if seen[part] {
+			return nil, apperr.Validation("duplicate_sort_field", part)
+		}
+		seen[part] = true
+		fields = append(fields, repository.SortField{Field: part, Desc: desc})
+	}
+	return fields, nil
+}
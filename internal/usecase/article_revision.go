@@ -0,0 +1,193 @@
+// my-article-app/internal/usecase/article_revision.go
+package usecase
+
+import (
+	"context"
+	"my-article-app/internal/apperr"
+	"my-article-app/internal/dto"
+	"my-article-app/internal/models"
+	"my-article-app/internal/pagination"
+	"my-article-app/internal/repository"
+	"my-article-app/internal/textdiff"
+	"strconv"
+)
+
+// درجات المقارنة المدعومة بين مراجعتين
+const (
+	diffByLine = "line"
+	diffByWord = "word"
+)
+
+// newRevision يبني مراجعة من حالة المقال بعد حفظه، منسوبة إلى محرر الطلب
+func newRevision(ctx context.Context, article *models.Article, revertedFrom *uint) *models.ArticleRevision {
+	return &models.ArticleRevision{
+		ArticleID:      article.ID,
+		Title:          article.Title,
+		Content:        article.Content,
+		ContentFormat:  contentFormat(article),
+		EditedBy:       editorFrom(ctx),
+		RevertedFrom:   revertedFrom,
+		ArticleVersion: article.Version,
+	}
+}
+
+func mapRevisionSummary(rev *models.ArticleRevision) dto.ArticleRevisionSummary {
+	return dto.ArticleRevisionSummary{
+		Revision:       rev.Revision,
+		ArticleVersion: rev.ArticleVersion,
+		Title:          rev.Title,
+		EditedBy:       rev.EditedBy,
+		RevertedFrom:   rev.RevertedFrom,
+		CreatedAt:      rev.CreatedAt,
+	}
+}
+
+func mapRevisionToResponse(rev *models.ArticleRevision) *dto.ArticleRevisionResponse {
+	return &dto.ArticleRevisionResponse{
+		ArticleRevisionSummary: mapRevisionSummary(rev),
+		Content:                rev.Content,
+		ContentFormat:          rev.ContentFormat,
+	}
+}
+
+// visibleArticle يتحقق من وجود المقال وظهوره للطلب قبل عرض مراجعاته؛ مراجعات غير المنشور للمشرف فقط
+func (uc *articleUseCase) visibleArticle(ctx context.Context, id uint, includeUnpublished bool) error {
+	article, err := uc.articleRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !isVisible(article, includeUnpublished) {
+		return apperr.NotFound("article_not_found", id)
+	}
+	return nil
+}
+
+// GetArticleRevisions يجلب صفحة من مراجعات المقال مرتبة من الأقدم
+func (uc *articleUseCase) GetArticleRevisions(ctx context.Context, id uint, req pagination.Request, includeUnpublished bool) (*dto.PageResponse[dto.ArticleRevisionSummary], error) {
+	if err := uc.visibleArticle(ctx, id, includeUnpublished); err != nil {
+		return nil, err
+	}
+
+	page, err := uc.revisionRepo.FindByArticle(ctx, id, req)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.ArticleRevisionSummary, 0, len(page.Items))
+	for i := range page.Items {
+		responses = append(responses, mapRevisionSummary(&page.Items[i]))
+	}
+	return &dto.PageResponse[dto.ArticleRevisionSummary]{Data: responses, Meta: mapPageMeta(page)}, nil
+}
+
+// GetArticleRevision يجلب مراجعة واحدة مع محتواها
+func (uc *articleUseCase) GetArticleRevision(ctx context.Context, id, revision uint, includeUnpublished bool) (*dto.ArticleRevisionResponse, error) {
+	if err := uc.visibleArticle(ctx, id, includeUnpublished); err != nil {
+		return nil, err
+	}
+
+	rev, err := uc.revisionRepo.FindOne(ctx, id, revision)
+	if err != nil {
+		return nil, err
+	}
+	return mapRevisionToResponse(rev), nil
+}
+
+// DiffArticleRevisions يقارن عنوان مراجعتين ومحتواهما سطرًا بسطر أو كلمة بكلمة
+func (uc *articleUseCase) DiffArticleRevisions(ctx context.Context, id uint, query *dto.RevisionDiffQuery, includeUnpublished bool) (*dto.RevisionDiffResponse, error) {
+	granularity := query.Granularity
+	switch granularity {
+	case "":
+		granularity = diffByLine
+	case diffByLine, diffByWord:
+	default:
+		return nil, apperr.Validation("invalid_diff_granularity", granularity)
+	}
+	from, err := parseRevisionParam("from", query.From)
+	if err != nil {
+		return nil, err
+	}
+	to, err := parseRevisionParam("to", query.To)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.visibleArticle(ctx, id, includeUnpublished); err != nil {
+		return nil, err
+	}
+
+	var newer, older *models.ArticleRevision
+	if to == 0 {
+		newer, err = uc.revisionRepo.Latest(ctx, id)
+	} else {
+		newer, err = uc.revisionRepo.FindOne(ctx, id, to)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if from == 0 {
+		// بلا from تُقارن المراجعة بالتي قبلها، والمراجعة الأولى تُقارن بنفسها
+		from = max(newer.Revision-1, 1)
+	}
+	if older, err = uc.revisionRepo.FindOne(ctx, id, from); err != nil {
+		return nil, err
+	}
+
+	compare := textdiff.Lines
+	if granularity == diffByWord {
+		compare = textdiff.Words
+	}
+	return &dto.RevisionDiffResponse{
+		From:        older.Revision,
+		To:          newer.Revision,
+		Granularity: granularity,
+		Title:       mapDiff(compare(older.Title, newer.Title)),
+		Content:     mapDiff(compare(older.Content, newer.Content)),
+	}, nil
+}
+
+// parseRevisionParam يحلل رقم مراجعة اختياريًا من معاملات الاستعلام (0 يعني غير محدد)
+func parseRevisionParam(name, value string) (uint, error) {
+	if value == "" {
+		return 0, nil
+	}
+	rev, err := strconv.ParseUint(value, 10, 32)
+	if err != nil || rev == 0 {
+		return 0, apperr.Validation("invalid_revision_param", name, value)
+	}
+	return uint(rev), nil
+}
+
+func mapDiff(edits []textdiff.Edit) []dto.DiffSegment {
+	segments := make([]dto.DiffSegment, 0, len(edits))
+	for _, e := range edits {
+		segments = append(segments, dto.DiffSegment{Op: e.Kind, Text: e.Text})
+	}
+	return segments
+}
+
+// RevertArticle يعيد عنوان المقال ومحتواه إلى مراجعة سابقة كتحديث جديد، فيضيف مراجعة جديدة ولا يحذف ما بعدها
+// expectedVersion رقم النسخة من ترويسة If-Match كما في التحديث
+func (uc *articleUseCase) RevertArticle(ctx context.Context, id, revision uint, expectedVersion *uint) (*dto.ArticleResponse, error) {
+	var article *models.Article
+	err := uc.txManager.WithinTx(ctx, func(repos repository.Repositories) error {
+		var err error
+		article, err = editArticle(ctx, repos, id, expectedVersion, func(article *models.Article) (*uint, error) {
+			rev, err := repos.Revisions.FindOne(ctx, id, revision)
+			if err != nil {
+				return nil, err
+			}
+			// عنوان المراجعة القديمة قد يكون استُخدم لمقال آخر منذ ذلك الحين
+			if err := ensureUniqueTitle(ctx, repos.Articles, rev.Title, id); err != nil {
+				return nil, err
+			}
+			article.Title, article.Content, article.ContentFormat = rev.Title, rev.Content, rev.ContentFormat
+			return &rev.Revision, nil
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapArticleToResponse(article, &article.Author), nil
+}
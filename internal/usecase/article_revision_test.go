@@ -0,0 +1,114 @@
+// my-article-app/internal/usecase/article_revision_test.go
+package usecase
+
+import (
+	"context"
+	"errors"
+	"my-article-app/internal/apperr"
+	"my-article-app/internal/dto"
+	"my-article-app/internal/pagination"
+	"my-article-app/internal/textdiff"
+	"testing"
+)
+
+// TestRevertAddsRevision يتحقق أن الاسترجاع يضيف مراجعة جديدة ويزيد النسخة ولا يعيد كتابة السجل
+func TestRevertAddsRevision(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, env *testEnv) {
+		ctx := context.Background()
+		authorID := env.createAuthor(t, "revision-author")
+		created, err := env.articles.CreateArticle(ctx, &dto.CreateArticleRequest{Title: "Original title", Content: "original content\nsecond line", AuthorID: authorID})
+		if err != nil {
+			t.Fatalf("CreateArticle: %v", err)
+		}
+		v := func(n uint) *uint { return &n }
+		if _, err := env.articles.UpdateArticle(ctx, created.ID, &dto.UpdateArticleRequest{Title: "Edited title", Content: "edited content\nsecond line"}, v(1)); err != nil {
+			t.Fatalf("UpdateArticle: %v", err)
+		}
+
+		if _, err := env.articles.RevertArticle(ctx, created.ID, 1, v(1)); !apperr.Is(err, apperr.KindPreconditionFailed) {
+			t.Errorf("RevertArticle with a stale version: err = %v, want precondition failed", err)
+		}
+		if _, err := env.articles.RevertArticle(ctx, created.ID, 9, v(2)); !apperr.Is(err, apperr.KindNotFound) {
+			t.Errorf("RevertArticle to a missing revision: err = %v, want not found", err)
+		}
+
+		reverted, err := env.articles.RevertArticle(ctx, created.ID, 1, v(2))
+		if err != nil {
+			t.Fatalf("RevertArticle: %v", err)
+		}
+		if reverted.Version != 3 || reverted.Title != "Original title" || reverted.Content != "original content\nsecond line" {
+			t.Errorf("reverted article = v%d %q %q, want v3 with the original title and content", reverted.Version, reverted.Title, reverted.Content)
+		}
+
+		page, err := env.articles.GetArticleRevisions(ctx, created.ID, pagination.Request{}, true)
+		if err != nil {
+			t.Fatalf("GetArticleRevisions: %v", err)
+		}
+		if len(page.Data) != 3 {
+			t.Fatalf("revisions = %d, want 3 (the revert is added, nothing is rewritten)", len(page.Data))
+		}
+		for i, rev := range page.Data {
+			if rev.Revision != uint(i+1) || rev.ArticleVersion != uint(i+1) {
+				t.Errorf("revision %d = %+v, want revision and article version %d", i, rev, i+1)
+			}
+		}
+		if from := page.Data[2].RevertedFrom; from == nil || *from != 1 {
+			t.Errorf("revert revision reverted_from = %v, want 1", from)
+		}
+		if page.Data[1].RevertedFrom != nil || page.Data[1].Title != "Edited title" {
+			t.Errorf("edited revision = %+v, want it unchanged", page.Data[1])
+		}
+
+		// المراجعة الجديدة مطابقة للأولى، فمقارنتهما بلا إضافة ولا حذف
+		diff, err := env.articles.DiffArticleRevisions(ctx, created.ID, &dto.RevisionDiffQuery{From: "1", To: "3"}, true)
+		if err != nil {
+			t.Fatalf("DiffArticleRevisions: %v", err)
+		}
+		for _, seg := range append(diff.Title, diff.Content...) {
+			if seg.Op != textdiff.Equal {
+				t.Errorf("diff 1..3 has %s %q, want only equal segments", seg.Op, seg.Text)
+			}
+		}
+		diff, err = env.articles.DiffArticleRevisions(ctx, created.ID, &dto.RevisionDiffQuery{From: "1", To: "2"}, true)
+		if err != nil {
+			t.Fatalf("DiffArticleRevisions: %v", err)
+		}
+		want := []dto.DiffSegment{{Op: textdiff.Delete, Text: "original content\n"}, {Op: textdiff.Insert, Text: "edited content\n"}, {Op: textdiff.Equal, Text: "second line"}}
+		if len(diff.Content) != len(want) {
+			t.Fatalf("diff 1..2 content = %+v, want %+v", diff.Content, want)
+		}
+		for i := range want {
+			if diff.Content[i] != want[i] {
+				t.Errorf("diff 1..2 content[%d] = %+v, want %+v", i, diff.Content[i], want[i])
+			}
+		}
+	})
+}
+
+func TestRevertRejectsTakenTitle(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, env *testEnv) {
+		ctx := context.Background()
+		authorID := env.createAuthor(t, "revision-author")
+		created, err := env.articles.CreateArticle(ctx, &dto.CreateArticleRequest{Title: "Reused title", Content: "original content", AuthorID: authorID})
+		if err != nil {
+			t.Fatalf("CreateArticle: %v", err)
+		}
+		if _, err := env.articles.UpdateArticle(ctx, created.ID, &dto.UpdateArticleRequest{Title: "Renamed title"}, nil); err != nil {
+			t.Fatalf("UpdateArticle: %v", err)
+		}
+		if _, err := env.articles.CreateArticle(ctx, &dto.CreateArticleRequest{Title: "Reused title", Content: "another article", AuthorID: authorID}); err != nil {
+			t.Fatalf("CreateArticle: %v", err)
+		}
+
+		if _, err := env.articles.RevertArticle(ctx, created.ID, 1, nil); !errors.Is(err, ErrDuplicateTitle) {
+			t.Fatalf("RevertArticle: err = %v, want duplicate_title", err)
+		}
+		page, err := env.articles.GetArticleRevisions(ctx, created.ID, pagination.Request{}, true)
+		if err != nil {
+			t.Fatalf("GetArticleRevisions: %v", err)
+		}
+		if len(page.Data) != 2 {
+			t.Errorf("revisions after refused revert = %d, want 2", len(page.Data))
+		}
+	})
+}
@@ -0,0 +1,156 @@
+// my-article-app/internal/usecase/article_status.go
+package usecase
+
+import (
+	"context"
+	"my-article-app/internal/apperr"
+	"my-article-app/internal/dto"
+	"my-article-app/internal/models"
+	"my-article-app/internal/repository"
+	"slices"
+	"time"
+)
+
+// statusFilterAll قيمة ?status= التي تعرض المقالات بكل حالاتها (للمشرف فقط)
+const statusFilterAll = "all"
+
+// articleTransitions الانتقالات المسموحة من كل حالة؛ أي انتقال غيرها يُرفض بـ 409
+// المجدول يمكن إعادة جدولته، والمؤرشف يعود مسودة أو يُنشر مباشرة
+var articleTransitions = map[string][]string{
+	models.ArticleStatusDraft:     {models.ArticleStatusPublished, models.ArticleStatusScheduled, models.ArticleStatusArchived},
+	models.ArticleStatusScheduled: {models.ArticleStatusPublished, models.ArticleStatusScheduled, models.ArticleStatusDraft, models.ArticleStatusArchived},
+	models.ArticleStatusPublished: {models.ArticleStatusDraft, models.ArticleStatusArchived},
+	models.ArticleStatusArchived:  {models.ArticleStatusDraft, models.ArticleStatusPublished},
+}
+
+// isArticleStatus يحدد ما إذا كانت القيمة حالة مقال معروفة
+func isArticleStatus(status string) bool {
+	_, ok := articleTransitions[status]
+	return ok
+}
+
+// parseStatusFilter يحوّل معامل ?status= إلى شرط التصفية: الفارغ يعني published و all يعني كل الحالات
+func parseStatusFilter(value string) (string, error) {
+	switch {
+	case value == "":
+		return models.ArticleStatusPublished, nil
+	case value == statusFilterAll:
+		return "", nil
+	case isArticleStatus(value):
+		return value, nil
+	}
+	return "", apperr.Validation("invalid_status_filter", value)
+}
+
+// validatePublishAt يرفض موعد النشر الذي لا يقع في المستقبل
+func validatePublishAt(publishAt, now time.Time) error {
+	if !publishAt.After(now) {
+		return apperr.Validation("publish_at_in_past", publishAt.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// validateInitialStatus يتحقق من الحالة الأولى في طلب الإنشاء: publish_at مطلوب مع scheduled فقط
+func validateInitialStatus(req *dto.CreateArticleRequest, now time.Time) error {
+	if req.Status != models.ArticleStatusScheduled {
+		if req.PublishAt != nil {
+			return apperr.Validation("publish_at_requires_scheduled")
+		}
+		return nil
+	}
+	if req.PublishAt == nil {
+		return apperr.Validation("publish_at_required")
+	}
+	return validatePublishAt(*req.PublishAt, now)
+}
+
+// newArticle يبني المقال من طلب إنشاء تحقق منه validateInitialStatus، بحالته الأولى (draft افتراضيًا)
+func newArticle(req *dto.CreateArticleRequest, now time.Time) *models.Article {
+	article := &models.Article{
+		Title:         req.Title,
+		Content:       req.Content,
+		ContentFormat: req.ContentFormat,
+		AuthorID:      req.AuthorID,
+		Status:        models.ArticleStatusDraft,
+	}
+	if req.Status != "" {
+		applyStatus(article, req.Status, req.PublishAt, now)
+	}
+	return article
+}
+
+// applyStatus ينقل المقال إلى الحالة to ويضبط موعد النشر ووقته بما يناسبها
+func applyStatus(article *models.Article, to string, publishAt *time.Time, now time.Time) {
+	article.Status = to
+	switch to {
+	case models.ArticleStatusPublished:
+		article.PublishAt, article.PublishedAt = nil, &now
+	case models.ArticleStatusScheduled:
+		// يُحفظ الموعد بتوقيت الخادم مثل بقية التواريخ، لأن SQLite يقارن التواريخ كنصوص تتضمن فرق التوقيت
+		local := publishAt.Local()
+		article.PublishAt, article.PublishedAt = &local, nil
+	case models.ArticleStatusDraft:
+		article.PublishAt, article.PublishedAt = nil, nil
+	case models.ArticleStatusArchived:
+		// وقت النشر يبقى مع المؤرشف ليعرف متى نُشر
+		article.PublishAt = nil
+	}
+}
+
+// isVisible يحدد ما إذا كان المقال يُعرض لطلب عام؛ غير المنشور يظهر للمشرف فقط
+func isVisible(article *models.Article, includeUnpublished bool) bool {
+	return includeUnpublished || article.Status == models.ArticleStatusPublished
+}
+
+// PublishArticle ينشر المقال فورًا
+func (uc *articleUseCase) PublishArticle(ctx context.Context, id uint, expectedVersion *uint) (*dto.ArticleResponse, error) {
+	return uc.transition(ctx, id, models.ArticleStatusPublished, nil, expectedVersion)
+}
+
+// UnpublishArticle يعيد المقال مسودة، ويلغي جدولته إن كان مجدولًا
+func (uc *articleUseCase) UnpublishArticle(ctx context.Context, id uint, expectedVersion *uint) (*dto.ArticleResponse, error) {
+	return uc.transition(ctx, id, models.ArticleStatusDraft, nil, expectedVersion)
+}
+
+// ArchiveArticle يؤرشف المقال فيختفي من القوائم العامة دون حذفه
+func (uc *articleUseCase) ArchiveArticle(ctx context.Context, id uint, expectedVersion *uint) (*dto.ArticleResponse, error) {
+	return uc.transition(ctx, id, models.ArticleStatusArchived, nil, expectedVersion)
+}
+
+// ScheduleArticle يجدول نشر المقال في publishAt، وتنشره مهمة الجدولة عند حلول موعده
+func (uc *articleUseCase) ScheduleArticle(ctx context.Context, id uint, publishAt time.Time, expectedVersion *uint) (*dto.ArticleResponse, error) {
+	if err := validatePublishAt(publishAt, time.Now()); err != nil {
+		return nil, err
+	}
+	return uc.transition(ctx, id, models.ArticleStatusScheduled, &publishAt, expectedVersion)
+}
+
+// transition يطبق انتقال حالة مسموحًا في معاملة، مشروطًا بالنسخة المتوقعة كما في التحديث
+func (uc *articleUseCase) transition(ctx context.Context, id uint, to string, publishAt *time.Time, expectedVersion *uint) (*dto.ArticleResponse, error) {
+	var article *models.Article
+	err := uc.txManager.WithinTx(ctx, func(repos repository.Repositories) error {
+		var err error
+		article, err = repos.Articles.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := checkVersion(article.Version, expectedVersion); err != nil {
+			return err
+		}
+		if !slices.Contains(articleTransitions[article.Status], to) {
+			return apperr.Conflict("invalid_status_transition", article.Status, to)
+		}
+
+		applyStatus(article, to, publishAt, time.Now())
+		return mapVersionConflict(repos.Articles.Update(ctx, article))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapArticleToResponse(article, &article.Author), nil
+}
+
+// PublishDueArticles ينشر المقالات المجدولة التي حان موعدها ويعيد عددها
+func (uc *articleUseCase) PublishDueArticles(ctx context.Context) (int64, error) {
+	return uc.articleRepo.PublishDue(ctx, time.Now())
+}
@@ -0,0 +1,146 @@
+// my-article-app/internal/usecase/article_taxonomy.go
+package usecase
+
+import (
+	"cmp"
+	"context"
+	"my-article-app/internal/apperr"
+	"my-article-app/internal/dto"
+	"my-article-app/internal/models"
+	"my-article-app/internal/repository"
+	"my-article-app/internal/textnorm"
+	"slices"
+	"strings"
+)
+
+// tagsFromNames يحوّل أسماء الوسوم المرسلة إلى وسوم بمعرفاتها النصية، ويحذف المكرر منها بعد التوحيد
+// فيبقى أول اسم أُرسل لكل وسم؛ الاسم الذي لا يبقى منه حرف أو رقم مرفوض
+func tagsFromNames(names []string) ([]models.Tag, error) {
+	tags := make([]models.Tag, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		slug := textnorm.Slug(name)
+		if slug == "" {
+			return nil, apperr.Validation("invalid_tag_name", name)
+		}
+		if !seen[slug] {
+			seen[slug] = true
+			tags = append(tags, models.Tag{Name: name, Slug: slug})
+		}
+	}
+	return tags, nil
+}
+
+// setArticleTags يستبدل وسوم المقال بالوسوم المسماة في names، وينشئ ما لم يوجد منها
+func setArticleTags(ctx context.Context, repos repository.Repositories, article *models.Article, names []string) error {
+	tags, err := tagsFromNames(names)
+	if err != nil {
+		return err
+	}
+	if tags, err = repos.Tags.Ensure(ctx, tags); err != nil {
+		return err
+	}
+	if err := repos.Tags.ReplaceArticleTags(ctx, article.ID, tagIDs(tags)); err != nil {
+		return err
+	}
+	article.Tags = tags
+	return nil
+}
+
+// tagIDs يعيد معرفات الوسوم بترتيبها
+func tagIDs(tags []models.Tag) []uint {
+	ids := make([]uint, 0, len(tags))
+	for _, tag := range tags {
+		ids = append(ids, tag.ID)
+	}
+	return ids
+}
+
+// findArticleCategories يجلب التصنيفات المطلوبة بعد حذف المكرر منها، والمعرف غير الموجود خطأ في مدخلات الطلب
+func findArticleCategories(ctx context.Context, repos repository.Repositories, ids []uint) ([]models.Category, error) {
+	ids = slices.Compact(slices.Sorted(slices.Values(ids)))
+	found, err := repos.Categories.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	if err := missingCategory(found, ids); err != nil {
+		return nil, err
+	}
+	categories := make([]models.Category, 0, len(ids))
+	for _, id := range ids {
+		categories = append(categories, found[id])
+	}
+	return categories, nil
+}
+
+// missingCategory يعيد خطأ تحقق بأول معرف من ids غير موجود بين التصنيفات found
+// غياب التصنيف خطأ في مدخلات الطلب وليس في المسار، لذا لا يُعاد كـ 404
+func missingCategory(found map[uint]models.Category, ids []uint) error {
+	for _, id := range ids {
+		if _, ok := found[id]; !ok {
+			return apperr.Validation("article_category_not_found", id)
+		}
+	}
+	return nil
+}
+
+// setArticleCategories يستبدل تصنيفات المقال بالتصنيفات ids
+func setArticleCategories(ctx context.Context, repos repository.Repositories, article *models.Article, ids []uint) error {
+	categories, err := findArticleCategories(ctx, repos, ids)
+	if err != nil {
+		return err
+	}
+	categoryIDs := make([]uint, 0, len(categories))
+	for _, category := range categories {
+		categoryIDs = append(categoryIDs, category.ID)
+	}
+	if err := repos.Categories.ReplaceArticleCategories(ctx, article.ID, categoryIDs); err != nil {
+		return err
+	}
+	article.Categories = categories
+	return nil
+}
+
+// classifyArticle يطبق وسوم المقال وتصنيفاته المرسلة في طلب إنشاء أو تحديث؛ القائمة nil تعني عدم التغيير
+func classifyArticle(ctx context.Context, repos repository.Repositories, article *models.Article, tags []string, categoryIDs []uint) error {
+	if tags != nil {
+		if err := setArticleTags(ctx, repos, article, tags); err != nil {
+			return err
+		}
+	}
+	if categoryIDs != nil {
+		return setArticleCategories(ctx, repos, article, categoryIDs)
+	}
+	return nil
+}
+
+// mapTags يحوّل وسوم المقال إلى DTO مرتبة حسب المعرف النصي، فلا يتأثر ترتيبها بمخزن البيانات
+func mapTags(tags []models.Tag) []dto.TagResponse {
+	responses := make([]dto.TagResponse, 0, len(tags))
+	for _, tag := range tags {
+		responses = append(responses, dto.TagResponse{ID: tag.ID, Name: tag.Name, Slug: tag.Slug})
+	}
+	slices.SortFunc(responses, func(a, b dto.TagResponse) int { return cmp.Compare(a.Slug, b.Slug) })
+	return responses
+}
+
+// mapCategories يحوّل تصنيفات المقال إلى DTO مرتبة حسب المعرف النصي
+func mapCategories(categories []models.Category) []dto.CategoryResponse {
+	responses := make([]dto.CategoryResponse, 0, len(categories))
+	for _, category := range categories {
+		responses = append(responses, mapCategory(&category))
+	}
+	slices.SortFunc(responses, func(a, b dto.CategoryResponse) int { return cmp.Compare(a.Slug, b.Slug) })
+	return responses
+}
+
+// mapCategory يحوّل التصنيف إلى DTO دون تصنيفاته الفرعية
+func mapCategory(category *models.Category) dto.CategoryResponse {
+	return dto.CategoryResponse{
+		ID:       category.ID,
+		Name:     category.Name,
+		Slug:     category.Slug,
+		ParentID: category.ParentID,
+	}
+}
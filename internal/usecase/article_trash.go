@@ -0,0 +1,87 @@
+// my-article-app/internal/usecase/article_trash.go
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"my-article-app/internal/apperr"
+	"my-article-app/internal/dto"
+	"my-article-app/internal/models"
+	"my-article-app/internal/pagination"
+	"my-article-app/internal/repository"
+	"time"
+)
+
+// ErrAuthorDeleted يُرجع عند استعادة مقال حُذف مؤلفه
+var ErrAuthorDeleted = apperr.Conflict("article_author_deleted")
+
+// deletedAt يعيد وقت الحذف المنطقي للمقال أو nil إذا لم يكن محذوفًا
+func deletedAt(article *models.Article) *time.Time {
+	if !article.DeletedAt.Valid {
+		return nil
+	}
+	t := article.DeletedAt.Time
+	return &t
+}
+
+// GetTrash يجلب صفحة من المقالات المحذوفة منطقيًا
+func (uc *articleUseCase) GetTrash(ctx context.Context, req pagination.Request) (*dto.PageResponse[dto.ArticleResponse], error) {
+	page, err := uc.articleRepo.FindTrash(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.ArticleResponse, 0, len(page.Items))
+	for _, article := range page.Items {
+		current := article
+		responses = append(responses, *mapArticleToResponse(&current, &current.Author))
+	}
+	return &dto.PageResponse[dto.ArticleResponse]{Data: responses, Meta: mapPageMeta(page)}, nil
+}
+
+// RestoreArticle يعيد مقالاً من سلة المحذوفات، ويرفض الاستعادة إذا أُنشئ في الأثناء مقال بنفس العنوان
+func (uc *articleUseCase) RestoreArticle(ctx context.Context, id uint) (*dto.ArticleResponse, error) {
+	var article *models.Article
+	err := uc.txManager.WithinTx(ctx, func(repos repository.Repositories) error {
+		var err error
+		article, err = repos.Articles.FindTrashedByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		// المؤلف يُقفل حتى لا يُحذف قبل اكتمال الاستعادة، كما في إنشاء المقال
+		author, err := repos.Authors.FindByIDForShare(ctx, article.AuthorID)
+		if apperr.Is(err, apperr.KindNotFound) {
+			return ErrAuthorDeleted
+		}
+		if err != nil {
+			return err
+		}
+		article.Author = *author
+
+		if err := ensureUniqueTitle(ctx, repos.Articles, article.Title, article.ID); err != nil {
+			return err
+		}
+		// الاستعادة لا تحمل If-Match، فتعارضها مع طلب متزامن يبقى 409 لا 412
+		return repos.Articles.Restore(ctx, article)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapArticleToResponse(article, &article.Author), nil
+}
+
+// PurgeArticle يحذف المقال نهائيًا دون المرور بسلة المحذوفات (عملية إدارية)
+func (uc *articleUseCase) PurgeArticle(ctx context.Context, id uint) error {
+	return uc.txManager.WithinTx(ctx, func(repos repository.Repositories) error {
+		return repos.Articles.Purge(ctx, id)
+	})
+}
+
+// PurgeTrash يحذف نهائيًا المقالات التي مضى على حذفها المنطقي أكثر من retention
+func (uc *articleUseCase) PurgeTrash(ctx context.Context, retention time.Duration) (int64, error) {
+	if retention <= 0 {
+		return 0, fmt.Errorf("مدة الاحتفاظ يجب أن تكون موجبة: %s", retention)
+	}
+	return uc.articleRepo.PurgeDeletedBefore(ctx, time.Now().Add(-retention))
+}
@@ -0,0 +1,153 @@
+// my-article-app/internal/usecase/article_trash_test.go
+package usecase
+
+import (
+	"context"
+	"errors"
+	"my-article-app/internal/apperr"
+	"my-article-app/internal/dto"
+	"my-article-app/internal/pagination"
+	"testing"
+	"time"
+)
+
+// createTrashArticle ينشئ مقالًا بالعنوان المعطى ويعيد معرفه
+func (e *testEnv) createTrashArticle(t *testing.T, authorID uint, title string) uint {
+	t.Helper()
+	created, err := e.articles.CreateArticle(context.Background(), &dto.CreateArticleRequest{Title: title, Content: "content of " + title, AuthorID: authorID})
+	if err != nil {
+		t.Fatalf("CreateArticle(%q): %v", title, err)
+	}
+	return created.ID
+}
+
+// trashIDs يعيد معرفات المقالات الموجودة في سلة المحذوفات
+func (e *testEnv) trashIDs(t *testing.T) []uint {
+	t.Helper()
+	page, err := e.articles.GetTrash(context.Background(), pagination.Request{})
+	if err != nil {
+		t.Fatalf("GetTrash: %v", err)
+	}
+	ids := make([]uint, 0, len(page.Data))
+	for _, article := range page.Data {
+		if article.DeletedAt == nil {
+			t.Errorf("trashed article %d has no deleted_at", article.ID)
+		}
+		ids = append(ids, article.ID)
+	}
+	return ids
+}
+
+func TestTrashRestorePurge(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, env *testEnv) {
+		ctx := context.Background()
+		authorID := env.createAuthor(t, "trash-author")
+		id := env.createTrashArticle(t, authorID, "Trashed article")
+
+		if err := env.articles.DeleteArticle(ctx, id); err != nil {
+			t.Fatalf("DeleteArticle: %v", err)
+		}
+		if _, err := env.articles.GetArticleByID(ctx, id, nil, true); !apperr.Is(err, apperr.KindNotFound) {
+			t.Fatalf("GetArticleByID after delete: err = %v, want not found", err)
+		}
+		if ids := env.trashIDs(t); len(ids) != 1 || ids[0] != id {
+			t.Fatalf("trash = %v, want [%d]", ids, id)
+		}
+
+		restored, err := env.articles.RestoreArticle(ctx, id)
+		if err != nil {
+			t.Fatalf("RestoreArticle: %v", err)
+		}
+		if restored.DeletedAt != nil || restored.Title != "Trashed article" {
+			t.Errorf("restored article = %+v, want it live with its title", restored)
+		}
+		if _, err := env.articles.GetArticleByID(ctx, id, nil, true); err != nil {
+			t.Fatalf("GetArticleByID after restore: %v", err)
+		}
+		if ids := env.trashIDs(t); len(ids) != 0 {
+			t.Errorf("trash after restore = %v, want empty", ids)
+		}
+		if _, err := env.articles.RestoreArticle(ctx, id); !apperr.Is(err, apperr.KindNotFound) {
+			t.Errorf("RestoreArticle of a live article: err = %v, want not found", err)
+		}
+
+		// الحذف النهائي يتجاوز السلة، فلا يمكن استعادة المقال بعده
+		if err := env.articles.PurgeArticle(ctx, id); err != nil {
+			t.Fatalf("PurgeArticle: %v", err)
+		}
+		if _, err := env.repos.Articles.FindTrashedByID(ctx, id); !apperr.Is(err, apperr.KindNotFound) {
+			t.Errorf("FindTrashedByID after purge: err = %v, want not found", err)
+		}
+		if _, err := env.articles.RestoreArticle(ctx, id); !apperr.Is(err, apperr.KindNotFound) {
+			t.Errorf("RestoreArticle after purge: err = %v, want not found", err)
+		}
+		if err := env.articles.PurgeArticle(ctx, id); !apperr.Is(err, apperr.KindNotFound) {
+			t.Errorf("second PurgeArticle: err = %v, want not found", err)
+		}
+	})
+}
+
+func TestRestoreRejectsTakenTitle(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, env *testEnv) {
+		ctx := context.Background()
+		authorID := env.createAuthor(t, "trash-author")
+		id := env.createTrashArticle(t, authorID, "Shared title")
+		if err := env.articles.DeleteArticle(ctx, id); err != nil {
+			t.Fatalf("DeleteArticle: %v", err)
+		}
+		env.createTrashArticle(t, authorID, "Shared title")
+
+		if _, err := env.articles.RestoreArticle(ctx, id); !errors.Is(err, ErrDuplicateTitle) {
+			t.Fatalf("RestoreArticle: err = %v, want duplicate_title", err)
+		}
+		if ids := env.trashIDs(t); len(ids) != 1 || ids[0] != id {
+			t.Errorf("trash after refused restore = %v, want [%d]", ids, id)
+		}
+	})
+}
+
+// TestPurgeTrashRetention يتحقق أن التنظيف الدوري لا يحذف إلا ما تجاوز عمره في السلة مدة الاحتفاظ
+func TestPurgeTrashRetention(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, env *testEnv) {
+		ctx := context.Background()
+		if _, err := env.articles.PurgeTrash(ctx, 0); err == nil {
+			t.Error("PurgeTrash(0) succeeded, want error")
+		}
+		authorID := env.createAuthor(t, "retention-author")
+
+		older := env.createTrashArticle(t, authorID, "Older trashed article")
+		newer := env.createTrashArticle(t, authorID, "Newer trashed article")
+		if err := env.articles.DeleteArticle(ctx, older); err != nil {
+			t.Fatalf("DeleteArticle(older): %v", err)
+		}
+		time.Sleep(100 * time.Millisecond)
+		if err := env.articles.DeleteArticle(ctx, newer); err != nil {
+			t.Fatalf("DeleteArticle(newer): %v", err)
+		}
+
+		if n, err := env.articles.PurgeTrash(ctx, time.Hour); err != nil || n != 0 {
+			t.Fatalf("PurgeTrash(1h) = %d, %v; want nothing purged", n, err)
+		}
+
+		// حد القطع بين وقتي الحذف: الأقدم تجاوز مدة الاحتفاظ والأحدث لم يبلغها
+		first, err := env.repos.Articles.FindTrashedByID(ctx, older)
+		if err != nil {
+			t.Fatalf("FindTrashedByID(older): %v", err)
+		}
+		second, err := env.repos.Articles.FindTrashedByID(ctx, newer)
+		if err != nil {
+			t.Fatalf("FindTrashedByID(newer): %v", err)
+		}
+		middle := first.DeletedAt.Time.Add(second.DeletedAt.Time.Sub(first.DeletedAt.Time) / 2)
+		n, err := env.articles.PurgeTrash(ctx, time.Since(middle))
+		if err != nil {
+			t.Fatalf("PurgeTrash: %v", err)
+		}
+		if n != 1 {
+			t.Errorf("PurgeTrash purged %d articles, want 1", n)
+		}
+		if ids := env.trashIDs(t); len(ids) != 1 || ids[0] != newer {
+			t.Errorf("trash after PurgeTrash = %v, want only [%d]", ids, newer)
+		}
+	})
+}
@@ -2,30 +2,61 @@
 package usecase
 
 import (
-	"errors"
+	"context"
+	"my-article-app/internal/apperr"
 	"my-article-app/internal/dto"
 	"my-article-app/internal/models"
+	"my-article-app/internal/pagination"
 	"my-article-app/internal/repository"
+	"strings"
+	"time"
 )
 
 // ArticleUseCase interface remains the same
 type ArticleUseCase interface {
-	CreateArticle(req *dto.CreateArticleRequest) (*dto.ArticleResponse, error)
-	GetAllArticles() ([]dto.ArticleResponse, error)
-	GetArticleByID(id uint) (*dto.ArticleResponse, error)
-	UpdateArticle(id uint, req *dto.UpdateArticleRequest) (*dto.ArticleResponse, error)
-	DeleteArticle(id uint) error
+	CreateArticle(ctx context.Context, req *dto.CreateArticleRequest) (*dto.ArticleResponse, error)
+	GetAllArticles(ctx context.Context, query *dto.ArticleListQuery, req pagination.Request, includeUnpublished bool) (*dto.PageResponse[dto.ArticleResponse], error)
+	SearchArticles(ctx context.Context, query string, req pagination.Request) (*dto.PageResponse[dto.ArticleSearchResponse], error)
+	GetArticleByID(ctx context.Context, id uint, query *dto.ArticleReadQuery, includeUnpublished bool) (*dto.ArticleResponse, error)
+	GetArticleBySlug(ctx context.Context, slug string, query *dto.ArticleReadQuery, includeUnpublished bool) (*dto.ArticleResponse, bool, error)
+	UpdateArticle(ctx context.Context, id uint, req *dto.UpdateArticleRequest, expectedVersion *uint) (*dto.ArticleResponse, error)
+	DeleteArticle(ctx context.Context, id uint) error
+	GetTrash(ctx context.Context, req pagination.Request) (*dto.PageResponse[dto.ArticleResponse], error)
+	RestoreArticle(ctx context.Context, id uint) (*dto.ArticleResponse, error)
+	PurgeArticle(ctx context.Context, id uint) error
+	PurgeTrash(ctx context.Context, retention time.Duration) (int64, error)
+	BulkCreateArticles(ctx context.Context, mode string, items []dto.CreateArticleRequest, rejected map[int]error) (*dto.BulkResponse, error)
+	BulkUpdateArticles(ctx context.Context, mode string, items []dto.BulkUpdateArticleItem, rejected map[int]error) (*dto.BulkResponse, error)
+	BulkDeleteArticles(ctx context.Context, mode string, ids []uint, rejected map[int]error) (*dto.BulkResponse, error)
+	PublishArticle(ctx context.Context, id uint, expectedVersion *uint) (*dto.ArticleResponse, error)
+	UnpublishArticle(ctx context.Context, id uint, expectedVersion *uint) (*dto.ArticleResponse, error)
+	ArchiveArticle(ctx context.Context, id uint, expectedVersion *uint) (*dto.ArticleResponse, error)
+	ScheduleArticle(ctx context.Context, id uint, publishAt time.Time, expectedVersion *uint) (*dto.ArticleResponse, error)
+	PublishDueArticles(ctx context.Context) (int64, error)
+	GetArticleRevisions(ctx context.Context, id uint, req pagination.Request, includeUnpublished bool) (*dto.PageResponse[dto.ArticleRevisionSummary], error)
+	GetArticleRevision(ctx context.Context, id, revision uint, includeUnpublished bool) (*dto.ArticleRevisionResponse, error)
+	DiffArticleRevisions(ctx context.Context, id uint, query *dto.RevisionDiffQuery, includeUnpublished bool) (*dto.RevisionDiffResponse, error)
+	RevertArticle(ctx context.Context, id, revision uint, expectedVersion *uint) (*dto.ArticleResponse, error)
 }
 
+// ErrDuplicateTitle يُرجع عند وجود مقال آخر بنفس العنوان بعد توحيد النص
+var ErrDuplicateTitle = apperr.Conflict("duplicate_title")
+
 type articleUseCase struct {
-	articleRepo repository.ArticleRepository
-	authorRepo  repository.AuthorRepository
+	articleRepo     repository.ArticleRepository
+	authorRepo      repository.AuthorRepository
+	revisionRepo    repository.RevisionRepository
+	slugHistoryRepo repository.SlugHistoryRepository
+	txManager       repository.TxManager // عمليات الكتابة تمر عبر معاملة واحدة تشمل جميع المستودعات
 }
 
-func NewArticleUseCase(articleRepo repository.ArticleRepository, authorRepo repository.AuthorRepository) ArticleUseCase {
+func NewArticleUseCase(articleRepo repository.ArticleRepository, authorRepo repository.AuthorRepository, revisionRepo repository.RevisionRepository, slugHistoryRepo repository.SlugHistoryRepository, txManager repository.TxManager) ArticleUseCase {
 	return &articleUseCase{
-		articleRepo: articleRepo,
-		authorRepo:  authorRepo,
+		articleRepo:     articleRepo,
+		authorRepo:      authorRepo,
+		revisionRepo:    revisionRepo,
+		slugHistoryRepo: slugHistoryRepo,
+		txManager:       txManager,
 	}
 }
 
@@ -35,34 +66,69 @@ func mapArticleToResponse(article *models.Article, author *models.Author) *dto.A
 		return nil
 	}
 	return &dto.ArticleResponse{
-		ID:        article.ID,
-		Title:     article.Title,
-		Content:   article.Content,
-		CreatedAt: article.CreatedAt,
-		UpdatedAt: article.UpdatedAt,
+		ID:            article.ID,
+		Title:         article.Title,
+		Slug:          article.Slug,
+		Content:       article.Content,
+		ContentFormat: contentFormat(article),
+		CreatedAt:     article.CreatedAt,
+		UpdatedAt:     article.UpdatedAt,
+		DeletedAt:     deletedAt(article),
+		Version:       article.Version,
+		Status:        article.Status,
+		PublishAt:     article.PublishAt,
+		PublishedAt:   article.PublishedAt,
 		Author: dto.AuthorResponse{ // استخدم بيانات المؤلف التي تم تمريرها مباشرة
-			ID:    author.ID,
-			Name:  author.Name,
-			Email: author.Email,
+			ID:      author.ID,
+			Name:    author.Name,
+			Slug:    author.Slug,
+			Email:   author.Email,
+			Version: author.Version,
 		},
+		Tags:         mapTags(article.Tags),
+		Categories:   mapCategories(article.Categories),
+		CommentCount: article.CommentCount,
 	}
 }
 
 // CreateArticle (الحالة الخاصة التي تتطلب جلب المؤلف بشكل منفصل)
-func (uc *articleUseCase) CreateArticle(req *dto.CreateArticleRequest) (*dto.ArticleResponse, error) {
-	// نجلب المؤلف بشكل صريح للتحقق منه
-	author, err := uc.authorRepo.FindByID(req.AuthorID)
-	if err != nil || author == nil {
-		return nil, errors.New("المؤلف بالمعرف المحدد غير موجود")
+// التحقق من المؤلف وفحص العنوان والإنشاء تتم في معاملة واحدة، وصف المؤلف مقفل حتى نهايتها
+// حتى لا يُحذف المؤلف بين التحقق والإنشاء فيبقى المقال يتيمًا
+func (uc *articleUseCase) CreateArticle(ctx context.Context, req *dto.CreateArticleRequest) (*dto.ArticleResponse, error) {
+	now := time.Now()
+	if err := validateInitialStatus(req, now); err != nil {
+		return nil, err
 	}
+	article := newArticle(req, now)
 
-	article := &models.Article{
-		Title:    req.Title,
-		Content:  req.Content,
-		AuthorID: req.AuthorID,
-	}
+	var author *models.Author
+	err := uc.txManager.WithinTx(ctx, func(repos repository.Repositories) error {
+		var err error
+		// نجلب المؤلف بشكل صريح للتحقق منه
+		// غياب المؤلف خطأ في مدخلات الطلب وليس في المسار، لذا يُعاد كخطأ تحقق لا 404
+		author, err = repos.Authors.FindByIDForShare(ctx, req.AuthorID)
+		if apperr.Is(err, apperr.KindNotFound) {
+			return apperr.Validation("article_author_not_found", req.AuthorID)
+		}
+		if err != nil {
+			return err
+		}
 
-	if err := uc.articleRepo.Create(article); err != nil {
+		if err := ensureUniqueTitle(ctx, repos.Articles, req.Title, 0); err != nil {
+			return err
+		}
+		if err := assignArticleSlug(ctx, repos, article); err != nil {
+			return err
+		}
+		if err := repos.Articles.Create(ctx, article); err != nil {
+			return err
+		}
+		if err := classifyArticle(ctx, repos, article, req.Tags, req.CategoryIDs); err != nil {
+			return err
+		}
+		return repos.Revisions.Append(ctx, newRevision(ctx, article, nil))
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -71,60 +137,201 @@ func (uc *articleUseCase) CreateArticle(req *dto.CreateArticleRequest) (*dto.Art
 }
 
 // GetAllArticles (الحالة العادية)
-func (uc *articleUseCase) GetAllArticles() ([]dto.ArticleResponse, error) {
+// القائمة للمقالات المنشورة افتراضيًا، والتصفية بحالة أخرى تتطلب includeUnpublished (طلب المشرف)
+func (uc *articleUseCase) GetAllArticles(ctx context.Context, query *dto.ArticleListQuery, req pagination.Request, includeUnpublished bool) (*dto.PageResponse[dto.ArticleResponse], error) {
+	filter, err := parseArticleFilter(query)
+	if err != nil {
+		return nil, err
+	}
+	if filter.Status != models.ArticleStatusPublished && !includeUnpublished {
+		return nil, apperr.Forbidden("status_filter_forbidden")
+	}
+	if req.IsKeyset() && len(filter.Sort) > 0 {
+		return nil, repository.ErrCursorWithSort
+	}
+
 	// Repository's FindAll already preloads the author into each article
-	articles, err := uc.articleRepo.FindAll()
+	page, err := uc.articleRepo.FindAll(ctx, filter, req)
 	if err != nil {
 		return nil, err
 	}
 
-	var responses []dto.ArticleResponse
-	for _,article := range articles{
+	responses := make([]dto.ArticleResponse, 0, len(page.Items))
+	for _, article := range page.Items {
+		currentArticle := article
+		responses = append(responses, *mapArticleToResponse(&currentArticle, &currentArticle.Author))
+	}
+	return &dto.PageResponse[dto.ArticleResponse]{Data: responses, Meta: mapPageMeta(page)}, nil
+}
 
+// SearchArticles يبحث في عناوين المقالات ومحتواها ويرتب النتائج حسب الصلة
+func (uc *articleUseCase) SearchArticles(ctx context.Context, query string, req pagination.Request) (*dto.PageResponse[dto.ArticleSearchResponse], error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, apperr.Validation("search_query_required")
+	}
+	if req.IsKeyset() {
+		return nil, repository.ErrCursorWithSearch
+	}
 
-		currentArticle :=article
-		responses =append(responses, *mapArticleToResponse(&currentArticle,&currentArticle.Author))
+	page, err := uc.articleRepo.Search(ctx, query, req)
+	if err != nil {
+		return nil, err
 	}
-	return responses ,nil
+
+	responses := make([]dto.ArticleSearchResponse, 0, len(page.Items))
+	for _, result := range page.Items {
+		current := result
+		responses = append(responses, dto.ArticleSearchResponse{
+			Article:        *mapArticleToResponse(&current.Article, &current.Author),
+			Rank:           current.Rank,
+			TitleHighlight: current.TitleHighlight,
+			Snippet:        current.Snippet,
+		})
+	}
+	return &dto.PageResponse[dto.ArticleSearchResponse]{Data: responses, Meta: mapPageMeta(page)}, nil
 }
 
 // GetArticleByID (الحالة العادية)
-func (uc *articleUseCase) GetArticleByID(id uint) (*dto.ArticleResponse, error) {
+// المقال غير المنشور يُعامل كغير موجود ما لم يكن includeUnpublished (طلب المشرف)
+// query.Render=html يضيف إلى الاستجابة المحتوى محوَّلًا إلى HTML منقّى
+func (uc *articleUseCase) GetArticleByID(ctx context.Context, id uint, query *dto.ArticleReadQuery, includeUnpublished bool) (*dto.ArticleResponse, error) {
+	render, err := parseRender(query)
+	if err != nil {
+		return nil, err
+	}
+
 	// Repository's FindByID already preloads the author
-	article, err := uc.articleRepo.FindByID(id)
-	if err != nil || article == nil {
+	article, err := uc.articleRepo.FindByID(ctx, id)
+	if err != nil {
 		return nil, err
 	}
+	if !isVisible(article, includeUnpublished) {
+		return nil, apperr.NotFound("article_not_found", id)
+	}
 
-	// نمرر المقال والمؤلف المدمج بداخله (&article.Author) إلى دالة التحويل
-	return mapArticleToResponse(article, &article.Author), nil
+	return renderArticle(article, render), nil
 }
 
-// UpdateArticle (الحالة العادية)
-func (uc *articleUseCase) UpdateArticle(id uint, req *dto.UpdateArticleRequest) (*dto.ArticleResponse, error) {
-	// Repository's FindByID already preloads the author
-	article, err := uc.articleRepo.FindByID(id)
-	if err != nil || article == nil {
-		return nil, err
+// GetArticleBySlug يجلب المقال بمعرفه النصي الحالي، أو بمعرف سابق له بعد تغيير عنوانه
+// moved يعني أن slug معرف سابق، فيحمل المقال المعاد معرفه الحالي ليُعاد توجيه العميل إليه
+// ظهور المقال غير المنشور و query.Render كما في GetArticleByID، وأي مقال غير ظاهر يُعامل كمعرف غير موجود
+func (uc *articleUseCase) GetArticleBySlug(ctx context.Context, slug string, query *dto.ArticleReadQuery, includeUnpublished bool) (article *dto.ArticleResponse, moved bool, err error) {
+	render, err := parseRender(query)
+	if err != nil {
+		return nil, false, err
 	}
 
-	// تحديث الحقول
-	if req.Title != "" {
-		article.Title = req.Title
+	found, err := uc.articleRepo.FindBySlug(ctx, slug)
+	if apperr.Is(err, apperr.KindNotFound) {
+		var id uint
+		if id, err = uc.slugHistoryRepo.Resolve(ctx, slug); err != nil {
+			return nil, false, err
+		}
+		found, err = uc.articleRepo.FindByID(ctx, id)
+		if apperr.Is(err, apperr.KindNotFound) {
+			return nil, false, apperr.NotFound("article_slug_not_found", slug)
+		}
+		moved = true
+	}
+	if err != nil {
+		return nil, false, err
 	}
-	if req.Content != "" {
-		article.Content = req.Content
+	if !isVisible(found, includeUnpublished) {
+		return nil, false, apperr.NotFound("article_slug_not_found", slug)
 	}
+	return renderArticle(found, render), moved, nil
+}
 
-	if err := uc.articleRepo.Update(article); err != nil {
+// UpdateArticle (الحالة العادية)
+// القراءة وفحص العنوان والحفظ تتم في معاملة واحدة
+// expectedVersion رقم النسخة من ترويسة If-Match (nil عند غيابها)، ولا يُحفظ التعديل إلا إذا طابق النسخة الحالية
+func (uc *articleUseCase) UpdateArticle(ctx context.Context, id uint, req *dto.UpdateArticleRequest, expectedVersion *uint) (*dto.ArticleResponse, error) {
+	var article *models.Article
+	err := uc.txManager.WithinTx(ctx, func(repos repository.Repositories) error {
+		var err error
+		article, err = updateArticle(ctx, repos, id, req, expectedVersion)
+		return err
+	})
+	if err != nil {
 		return nil, err
 	}
-	
+
 	// نمرر المقال المحدّث والمؤلف المدمج بداخله (&article.Author) إلى دالة التحويل
 	return mapArticleToResponse(article, &article.Author), nil
 }
 
-// DeleteArticle remains the same
-func (uc *articleUseCase) DeleteArticle(id uint) error {
-	return uc.articleRepo.Delete(id)
+// updateArticle يقرأ المقال ويطبق التعديل ويحفظه داخل المعاملة الجارية، ويُستخدم أيضًا في التحديث المجمّع
+func updateArticle(ctx context.Context, repos repository.Repositories, id uint, req *dto.UpdateArticleRequest, expectedVersion *uint) (*models.Article, error) {
+	return editArticle(ctx, repos, id, expectedVersion, func(article *models.Article) (*uint, error) {
+		// تحديث الحقول
+		if req.Title != "" {
+			if err := ensureUniqueTitle(ctx, repos.Articles, req.Title, article.ID); err != nil {
+				return nil, err
+			}
+			article.Title = req.Title
+		}
+		if req.Content != "" {
+			article.Content = req.Content
+		}
+		if req.ContentFormat != "" {
+			article.ContentFormat = req.ContentFormat
+		}
+		return nil, classifyArticle(ctx, repos, article, req.Tags, req.CategoryIDs)
+	})
+}
+
+// editArticle يقرأ المقال ويتحقق من نسخته ثم يطبق edit ويحفظه ويضيف مراجعة بالعنوان والمحتوى الجديدين،
+// ويغيّر معرفه النصي إذا تغيّر عنوانه مع حفظ المعرف السابق لإعادة التوجيه
+// edit يعيد رقم المراجعة المسترجعة عند الاسترجاع، أو nil للتعديل العادي
+func editArticle(ctx context.Context, repos repository.Repositories, id uint, expectedVersion *uint, edit func(article *models.Article) (*uint, error)) (*models.Article, error) {
+	// Repository's FindByID already preloads the author
+	article, err := repos.Articles.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkVersion(article.Version, expectedVersion); err != nil {
+		return nil, err
+	}
+
+	title, slug := article.Title, article.Slug
+	revertedFrom, err := edit(article)
+	if err != nil {
+		return nil, err
+	}
+	if article.Title != title {
+		if err := reslugArticle(ctx, repos, article); err != nil {
+			return nil, err
+		}
+	}
+	if err := mapVersionConflict(repos.Articles.Update(ctx, article)); err != nil {
+		return nil, err
+	}
+	if err := recordSlugChange(ctx, repos, article, slug); err != nil {
+		return nil, err
+	}
+	if err := mapVersionConflict(repos.Revisions.Append(ctx, newRevision(ctx, article, revertedFrom))); err != nil {
+		return nil, err
+	}
+	return article, nil
+}
+
+// ensureUniqueTitle يرفض العنوان إذا طابق عنوان مقال آخر بعد التوحيد (التشكيل والهمزات وغيرها)
+// يستقبل المستودع صراحة حتى يعمل داخل المعاملة الجارية
+func ensureUniqueTitle(ctx context.Context, articles repository.ArticleRepository, title string, excludeID uint) error {
+	exists, err := articles.ExistsByTitle(ctx, title, excludeID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return ErrDuplicateTitle
+	}
+	return nil
+}
+
+// DeleteArticle ينقل المقال إلى سلة المحذوفات داخل معاملة، حتى تُضاف إليها لاحقًا أي عمليات مرتبطة بالحذف
+func (uc *articleUseCase) DeleteArticle(ctx context.Context, id uint) error {
+	return uc.txManager.WithinTx(ctx, func(repos repository.Repositories) error {
+		return repos.Articles.Delete(ctx, id)
+	})
 }
@@ -0,0 +1,153 @@
+// my-article-app/internal/usecase/article_usecase_test.go
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"my-article-app/internal/apperr"
+	"my-article-app/internal/dto"
+	"my-article-app/internal/models"
+	"my-article-app/internal/pagination"
+	"testing"
+)
+
+func TestArticleUseCaseCRUD(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, env *testEnv) {
+		ctx := context.Background()
+		authorID := env.createAuthor(t, "crud author")
+
+		created, err := env.articles.CreateArticle(ctx, &dto.CreateArticleRequest{
+			Title:    "مقدمة في البرمجة",
+			Content:  "محتوى المقال الأول للتجربة",
+			AuthorID: authorID,
+		})
+		if err != nil {
+			t.Fatalf("CreateArticle: %v", err)
+		}
+		if created.Slug != "mqdma-fy-albrmja" || created.Status != models.ArticleStatusDraft || created.Version != 1 {
+			t.Errorf("created slug=%q status=%q version=%d", created.Slug, created.Status, created.Version)
+		}
+
+		// المسودة لا تظهر للقراء وتظهر للمشرف
+		if _, err := env.articles.GetArticleByID(ctx, created.ID, nil, false); !apperr.Is(err, apperr.KindNotFound) {
+			t.Fatalf("public GetArticleByID of draft: err = %v, want not found", err)
+		}
+		if _, err := env.articles.GetArticleByID(ctx, created.ID, nil, true); err != nil {
+			t.Fatalf("admin GetArticleByID: %v", err)
+		}
+
+		version := created.Version
+		updated, err := env.articles.UpdateArticle(ctx, created.ID, &dto.UpdateArticleRequest{Title: "Introduction to programming"}, &version)
+		if err != nil {
+			t.Fatalf("UpdateArticle: %v", err)
+		}
+		if updated.Version != 2 || updated.Slug != "introduction-to-programming" {
+			t.Errorf("updated version=%d slug=%q", updated.Version, updated.Slug)
+		}
+		if _, err := env.articles.UpdateArticle(ctx, created.ID, &dto.UpdateArticleRequest{Content: "late content edit"}, &version); !apperr.Is(err, apperr.KindPreconditionFailed) {
+			t.Fatalf("UpdateArticle with stale If-Match: err = %v, want precondition failed", err)
+		}
+
+		// المعرف النصي السابق يقود إلى المقال نفسه
+		bySlug, moved, err := env.articles.GetArticleBySlug(ctx, "mqdma-fy-albrmja", nil, true)
+		if err != nil || !moved || bySlug.ID != created.ID {
+			t.Fatalf("GetArticleBySlug(old slug) = %v moved=%v err=%v", bySlug, moved, err)
+		}
+
+		if err := env.articles.DeleteArticle(ctx, created.ID); err != nil {
+			t.Fatalf("DeleteArticle: %v", err)
+		}
+		if _, err := env.articles.GetArticleByID(ctx, created.ID, nil, true); !apperr.Is(err, apperr.KindNotFound) {
+			t.Fatalf("GetArticleByID after delete: err = %v, want not found", err)
+		}
+		if _, err := env.articles.RestoreArticle(ctx, created.ID); err != nil {
+			t.Fatalf("RestoreArticle: %v", err)
+		}
+	})
+}
+
+func TestArticleUseCaseUniqueTitle(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, env *testEnv) {
+		ctx := context.Background()
+		authorID := env.createAuthor(t, "title author")
+
+		first, err := env.articles.CreateArticle(ctx, &dto.CreateArticleRequest{Title: "مقدمة في البرمجة", Content: "المحتوى الأول للمقال", AuthorID: authorID})
+		if err != nil {
+			t.Fatalf("CreateArticle: %v", err)
+		}
+		second, err := env.articles.CreateArticle(ctx, &dto.CreateArticleRequest{Title: "Second title", Content: "the second content", AuthorID: authorID})
+		if err != nil {
+			t.Fatalf("CreateArticle: %v", err)
+		}
+
+		// العناوين تُقارن بعد التوحيد، فالتشكيل والتاء المربوطة لا يصنعان عنوانًا جديدًا
+		for _, title := range []string{"مقدمة في البرمجة", "مُقَدِّمَة في البرمجه", "مقدمـــة  في البرمجة"} {
+			_, err := env.articles.CreateArticle(ctx, &dto.CreateArticleRequest{Title: title, Content: "محتوى مختلف تمامًا", AuthorID: authorID})
+			if !errors.Is(err, ErrDuplicateTitle) {
+				t.Errorf("CreateArticle(%q): err = %v, want ErrDuplicateTitle", title, err)
+			}
+		}
+
+		if _, err := env.articles.UpdateArticle(ctx, second.ID, &dto.UpdateArticleRequest{Title: first.Title}, nil); !errors.Is(err, ErrDuplicateTitle) {
+			t.Errorf("UpdateArticle to a taken title: err = %v, want ErrDuplicateTitle", err)
+		}
+		// المقال لا يتعارض مع عنوانه نفسه
+		if _, err := env.articles.UpdateArticle(ctx, first.ID, &dto.UpdateArticleRequest{Title: first.Title, Content: "محتوى جديد للمقال"}, nil); err != nil {
+			t.Errorf("UpdateArticle keeping its own title: %v", err)
+		}
+
+		if _, err := env.articles.CreateArticle(ctx, &dto.CreateArticleRequest{Title: "Orphan article", Content: "no such author here", AuthorID: 999}); !apperr.Is(err, apperr.KindValidation) {
+			t.Errorf("CreateArticle with unknown author: err = %v, want validation error", err)
+		}
+	})
+}
+
+func TestArticleUseCasePagination(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, env *testEnv) {
+		ctx := context.Background()
+		authorID := env.createAuthor(t, "paging author")
+		for n := 1; n <= 5; n++ {
+			status := models.ArticleStatusPublished
+			if n == 5 {
+				status = models.ArticleStatusDraft
+			}
+			_, err := env.articles.CreateArticle(ctx, &dto.CreateArticleRequest{
+				Title:    fmt.Sprintf("Paged article %d", n),
+				Content:  "content of a paged article",
+				AuthorID: authorID,
+				Status:   status,
+			})
+			if err != nil {
+				t.Fatalf("CreateArticle %d: %v", n, err)
+			}
+		}
+
+		page, err := env.articles.GetAllArticles(ctx, &dto.ArticleListQuery{}, pagination.Request{Limit: 3}, false)
+		if err != nil {
+			t.Fatalf("GetAllArticles: %v", err)
+		}
+		if page.Meta.Total != 4 || len(page.Data) != 3 || !page.Meta.HasMore {
+			t.Fatalf("public page total=%d items=%d has_more=%v, want 4 3 true", page.Meta.Total, len(page.Data), page.Meta.HasMore)
+		}
+
+		next, err := env.articles.GetAllArticles(ctx, &dto.ArticleListQuery{}, pagination.Request{Limit: 3, Cursor: page.Meta.NextCursor}, false)
+		if err != nil {
+			t.Fatalf("GetAllArticles next page: %v", err)
+		}
+		if len(next.Data) != 1 || next.Meta.HasMore || next.Data[0].Title != "Paged article 4" {
+			t.Fatalf("next page = %+v, want only Paged article 4", next.Data)
+		}
+
+		if _, err := env.articles.GetAllArticles(ctx, &dto.ArticleListQuery{Status: "all"}, pagination.Request{}, false); !apperr.Is(err, apperr.KindForbidden) {
+			t.Errorf("public GetAllArticles(status=all): err = %v, want forbidden", err)
+		}
+		all, err := env.articles.GetAllArticles(ctx, &dto.ArticleListQuery{Status: "all"}, pagination.Request{}, true)
+		if err != nil {
+			t.Fatalf("admin GetAllArticles(status=all): %v", err)
+		}
+		if all.Meta.Total != 5 {
+			t.Errorf("admin total = %d, want 5", all.Meta.Total)
+		}
+	})
+}
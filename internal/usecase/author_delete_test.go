@@ -0,0 +1,143 @@
+// my-article-app/internal/usecase/author_delete_test.go
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"my-article-app/internal/apperr"
+	"my-article-app/internal/dto"
+	"testing"
+)
+
+func TestDeleteAuthorPolicies(t *testing.T) {
+	const (
+		self    = "self"
+		other   = "other"
+		missing = "missing"
+	)
+	tests := []struct {
+		name    string
+		live    int // مقالات المؤلف الظاهرة
+		trashed int // مقالات المؤلف في سلة المحذوفات
+		policy  string
+		to      string // self أو other أو missing أو فارغ
+		wantErr error
+		// wantOwner مالك المقالات بعد الحذف: self إذا بقيت للمؤلف المحذوف أو other إذا نُقلت
+		wantOwner string
+	}{
+		{name: "default without articles"},
+		{name: "block without articles", policy: OnArticlesBlock},
+		{name: "block with articles", live: 2, policy: OnArticlesBlock, wantErr: apperr.Conflict("author_has_articles")},
+		{name: "default with articles", live: 1, wantErr: apperr.Conflict("author_has_articles")},
+		{name: "block with only trashed articles", trashed: 1, policy: OnArticlesBlock, wantErr: apperr.Conflict("author_has_articles")},
+		{name: "cascade", live: 2, trashed: 1, policy: OnArticlesCascade, wantOwner: self},
+		{name: "reassign", live: 2, trashed: 1, policy: OnArticlesReassign, to: other, wantOwner: other},
+		{name: "reassign without target", live: 1, policy: OnArticlesReassign, wantErr: apperr.Validation("reassign_target_required")},
+		{name: "reassign to self", live: 1, policy: OnArticlesReassign, to: self, wantErr: apperr.Validation("reassign_to_self")},
+		{name: "reassign to missing author", live: 1, policy: OnArticlesReassign, to: missing, wantErr: apperr.Validation("reassign_target_not_found")},
+		{name: "unknown policy", live: 1, policy: "orphan", wantErr: apperr.Validation("invalid_delete_policy")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			forEachBackend(t, func(t *testing.T, env *testEnv) {
+				ctx := context.Background()
+				authorID := env.createAuthor(t, "leaving-author")
+				otherID := env.createAuthor(t, "staying-author")
+
+				var articleIDs []uint
+				for i := range tt.live + tt.trashed {
+					created, err := env.articles.CreateArticle(ctx, &dto.CreateArticleRequest{
+						Title: fmt.Sprintf("Article number %d", i), Content: "article content", AuthorID: authorID,
+					})
+					if err != nil {
+						t.Fatalf("CreateArticle: %v", err)
+					}
+					if i >= tt.live {
+						if err := env.articles.DeleteArticle(ctx, created.ID); err != nil {
+							t.Fatalf("DeleteArticle: %v", err)
+						}
+					}
+					articleIDs = append(articleIDs, created.ID)
+				}
+
+				query := &dto.DeleteAuthorQuery{OnArticles: tt.policy}
+				switch tt.to {
+				case self:
+					query.To = authorID
+				case other:
+					query.To = otherID
+				case missing:
+					query.To = otherID + 100
+				}
+
+				err := env.authors.DeleteAuthor(ctx, authorID, query)
+				if tt.wantErr != nil {
+					if !errors.Is(err, tt.wantErr) {
+						t.Fatalf("DeleteAuthor: err = %v, want %v", err, tt.wantErr)
+					}
+					// الرفض لا يغيّر شيئًا: المؤلف باقٍ ومقالاته على حالها
+					if _, err := env.authors.GetAuthorByID(ctx, authorID); err != nil {
+						t.Errorf("author after refused delete: %v", err)
+					}
+					for i, id := range articleIDs {
+						article, err := env.repos.Articles.FindTrashedByID(ctx, id)
+						if trashed := err == nil; trashed != (i >= tt.live) {
+							t.Errorf("article %d trashed = %v after refused delete", id, trashed)
+						}
+						if err == nil && article.AuthorID != authorID {
+							t.Errorf("article %d author = %d, want %d", id, article.AuthorID, authorID)
+						}
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("DeleteAuthor: %v", err)
+				}
+				if _, err := env.authors.GetAuthorByID(ctx, authorID); !apperr.Is(err, apperr.KindNotFound) {
+					t.Errorf("author after delete: err = %v, want not found", err)
+				}
+
+				for _, id := range articleIDs {
+					switch tt.wantOwner {
+					case self:
+						// cascade ينقل كل المقالات إلى السلة، واستعادتها تُرفض لأن مؤلفها حُذف
+						article, err := env.repos.Articles.FindTrashedByID(ctx, id)
+						if err != nil {
+							t.Fatalf("article %d not in trash after cascade: %v", id, err)
+						}
+						if article.AuthorID != authorID {
+							t.Errorf("article %d author = %d, want %d", id, article.AuthorID, authorID)
+						}
+						if _, err := env.articles.RestoreArticle(ctx, id); !errors.Is(err, ErrAuthorDeleted) {
+							t.Errorf("RestoreArticle(%d): err = %v, want article_author_deleted", id, err)
+						}
+					case other:
+						// reassign ينقل المقالات المحذوفة أيضًا، فتبقى قابلة للاستعادة
+						article, err := env.repos.Articles.FindByID(ctx, id)
+						if err != nil {
+							article, err = env.repos.Articles.FindTrashedByID(ctx, id)
+							if err == nil {
+								_, err = env.articles.RestoreArticle(ctx, id)
+							}
+						}
+						if err != nil {
+							t.Fatalf("article %d after reassign: %v", id, err)
+						}
+						if article.AuthorID != otherID {
+							t.Errorf("article %d author = %d, want %d", id, article.AuthorID, otherID)
+						}
+					}
+				}
+			})
+		})
+	}
+}
+
+func TestDeleteMissingAuthor(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, env *testEnv) {
+		if err := env.authors.DeleteAuthor(context.Background(), 404, nil); !apperr.Is(err, apperr.KindNotFound) {
+			t.Errorf("DeleteAuthor(404): err = %v, want not found", err)
+		}
+	})
+}
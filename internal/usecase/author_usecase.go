@@ -2,121 +2,299 @@
 package usecase
 
 import (
+	"context"
+	"my-article-app/internal/apperr"
 	"my-article-app/internal/dto"
 	"my-article-app/internal/models"
+	"my-article-app/internal/pagination"
 	"my-article-app/internal/repository"
+	"strings"
 )
 
 type AuthorUseCase interface {
-	CreateAuthor(req *dto.CreateAuthorRequest) (*dto.AuthorResponse, error)
-	GetAllAuthors() ([]dto.AuthorResponse, error)
-	GetAuthorByID(id uint) (*dto.AuthorDetailResponse, error)
-	UpdateAuthor(id uint, req *dto.UpdateAuthorRequest) (*dto.AuthorResponse, error)
-	DeleteAuthor(id uint) error
+	CreateAuthor(ctx context.Context, req *dto.CreateAuthorRequest) (*dto.AuthorResponse, error)
+	GetAllAuthors(ctx context.Context, query *dto.AuthorListQuery, req pagination.Request) (*dto.PageResponse[dto.AuthorResponse], error)
+	GetAuthorByID(ctx context.Context, id uint) (*dto.AuthorDetailResponse, error)
+	GetAuthorBySlug(ctx context.Context, slug string) (*dto.AuthorDetailResponse, error)
+	UpdateAuthor(ctx context.Context, id uint, req *dto.UpdateAuthorRequest, expectedVersion *uint) (*dto.AuthorResponse, error)
+	DeleteAuthor(ctx context.Context, id uint, query *dto.DeleteAuthorQuery) error
 }
 
+// سياسات التعامل مع مقالات المؤلف عند حذفه
+const (
+	// OnArticlesBlock يرفض الحذف إذا كان للمؤلف مقالات (الافتراضي)
+	OnArticlesBlock = "block"
+	// OnArticlesCascade ينقل مقالات المؤلف إلى سلة المحذوفات معه
+	OnArticlesCascade = "cascade"
+	// OnArticlesReassign ينقل مقالات المؤلف إلى مؤلف آخر قبل حذفه
+	OnArticlesReassign = "reassign"
+)
+
 type authorUseCase struct {
 	authorRepo repository.AuthorRepository
+	txManager  repository.TxManager // عمليات الكتابة تمر عبر معاملة واحدة تشمل جميع المستودعات
 }
 
-func NewAuthorUseCase(authorRepo repository.AuthorRepository) AuthorUseCase {
-	return &authorUseCase{authorRepo: authorRepo}
+func NewAuthorUseCase(authorRepo repository.AuthorRepository, txManager repository.TxManager) AuthorUseCase {
+	return &authorUseCase{authorRepo: authorRepo, txManager: txManager}
 }
 
-// CreateAuthor ينشئ مؤلفًا جديدًا
-func (uc *authorUseCase) CreateAuthor(req *dto.CreateAuthorRequest) (*dto.AuthorResponse, error) {
+// CreateAuthor ينشئ مؤلفًا جديدًا بمعرف نصي من اسمه، واختيار المعرف والإنشاء في معاملة واحدة
+func (uc *authorUseCase) CreateAuthor(ctx context.Context, req *dto.CreateAuthorRequest) (*dto.AuthorResponse, error) {
 	author := &models.Author{
 		Name:  req.Name,
 		Email: req.Email,
 	}
 
-	if err := uc.authorRepo.Create(author); err != nil {
+	err := uc.txManager.WithinTx(ctx, func(repos repository.Repositories) error {
+		if err := assignAuthorSlug(ctx, repos.Authors, author); err != nil {
+			return err
+		}
+		return repos.Authors.Create(ctx, author)
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	response := &dto.AuthorResponse{
-		ID:    author.ID,
-		Name:  author.Name,
-		Email: author.Email,
+		ID:      author.ID,
+		Name:    author.Name,
+		Slug:    author.Slug,
+		Email:   author.Email,
+		Version: author.Version,
 	}
 	return response, nil
 }
 
-// GetAllAuthors يجلب جميع المؤلفين
-func (uc *authorUseCase) GetAllAuthors() ([]dto.AuthorResponse, error) {
-	authors, err := uc.authorRepo.FindAll()
+// GetAllAuthors يجلب صفحة من المؤلفين مع إمكانية البحث بالاسم والترتيب، وإحصاءات المقالات مع include=stats
+func (uc *authorUseCase) GetAllAuthors(ctx context.Context, query *dto.AuthorListQuery, req pagination.Request) (*dto.PageResponse[dto.AuthorResponse], error) {
+	var filter repository.AuthorFilter
+	var includeStats bool
+	if query != nil {
+		filter.Name = strings.TrimSpace(query.Name)
+
+		var err error
+		if includeStats, err = parseAuthorInclude(query.Include); err != nil {
+			return nil, err
+		}
+		if filter.Sort, err = parseSort(query.Sort, repository.IsAuthorSortField); err != nil {
+			return nil, err
+		}
+	}
+	if req.IsKeyset() && len(filter.Sort) > 0 {
+		return nil, repository.ErrCursorWithSort
+	}
+
+	if includeStats {
+		page, err := uc.authorRepo.FindAllWithStats(ctx, filter, req)
+		if err != nil {
+			return nil, err
+		}
+
+		responses := make([]dto.AuthorResponse, 0, len(page.Items))
+		for _, item := range page.Items {
+			response := mapAuthorToResponse(&item.Author)
+			response.Stats = &dto.AuthorStatsResponse{
+				ArticleCount:     item.Stats.ArticleCount,
+				FirstPublishedAt: item.Stats.FirstPublishedAt,
+				LastPublishedAt:  item.Stats.LastPublishedAt,
+				TotalWords:       item.Stats.TotalWords,
+			}
+			responses = append(responses, response)
+		}
+		return &dto.PageResponse[dto.AuthorResponse]{Data: responses, Meta: mapPageMeta(page)}, nil
+	}
+
+	page, err := uc.authorRepo.FindAll(ctx, filter, req)
 	if err != nil {
 		return nil, err
 	}
 
-	var responses []dto.AuthorResponse
-	for _, author := range authors {
-		responses = append(responses, dto.AuthorResponse{
-			ID:    author.ID,
-			Name:  author.Name,
-			Email: author.Email,
-		})
+	responses := make([]dto.AuthorResponse, 0, len(page.Items))
+	for _, author := range page.Items {
+		responses = append(responses, mapAuthorToResponse(&author))
+	}
+	return &dto.PageResponse[dto.AuthorResponse]{Data: responses, Meta: mapPageMeta(page)}, nil
+}
+
+// mapAuthorToResponse يحوّل المؤلف إلى DTO القياسي دون إحصاءات
+func mapAuthorToResponse(author *models.Author) dto.AuthorResponse {
+	return dto.AuthorResponse{
+		ID:      author.ID,
+		Name:    author.Name,
+		Slug:    author.Slug,
+		Email:   author.Email,
+		Version: author.Version,
+	}
+}
+
+// parseAuthorInclude يحلل معامل include بصيغة قائمة مفصولة بفواصل؛ القيمة المدعومة حاليًا stats
+func parseAuthorInclude(value string) (stats bool, err error) {
+	for _, part := range strings.Split(value, ",") {
+		switch part = strings.TrimSpace(part); part {
+		case "":
+		case "stats":
+			stats = true
+		default:
+			return false, apperr.Validation("invalid_include", part)
+		}
 	}
-	return responses, nil
+	return stats, nil
 }
 
 // GetAuthorByID يجلب مؤلفًا واحدًا مع مقالاته
-func (uc *authorUseCase) GetAuthorByID(id uint) (*dto.AuthorDetailResponse, error) {
-	author, err := uc.authorRepo.FindByID(id)
-	if err != nil || author == nil {
+func (uc *authorUseCase) GetAuthorByID(ctx context.Context, id uint) (*dto.AuthorDetailResponse, error) {
+	author, err := uc.authorRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return mapAuthorToDetail(author), nil
+}
+
+// GetAuthorBySlug يجلب مؤلفًا واحدًا مع مقالاته حسب معرفه النصي
+func (uc *authorUseCase) GetAuthorBySlug(ctx context.Context, slug string) (*dto.AuthorDetailResponse, error) {
+	author, err := uc.authorRepo.FindBySlug(ctx, slug)
+	if err != nil {
 		return nil, err
 	}
+	return mapAuthorToDetail(author), nil
+}
 
+// mapAuthorToDetail يحوّل المؤلف مع مقالاته المحمّلة إلى DTO التفاصيل
+func mapAuthorToDetail(author *models.Author) *dto.AuthorDetailResponse {
 	response := &dto.AuthorDetailResponse{
 		ID:        author.ID,
 		Name:      author.Name,
+		Slug:      author.Slug,
 		Email:     author.Email,
 		CreatedAt: author.CreatedAt,
+		Version:   author.Version,
 		Articles:  []dto.ArticleResponse{}, // Initialize to avoid null
 	}
 
 	for _, article := range author.Articles {
 		response.Articles = append(response.Articles, dto.ArticleResponse{
-			ID:        article.ID,
-			Title:     article.Title,
-			Content:   article.Content,
-			CreatedAt: article.CreatedAt,
-			UpdatedAt: article.UpdatedAt,
+			ID:            article.ID,
+			Title:         article.Title,
+			Slug:          article.Slug,
+			Content:       article.Content,
+			ContentFormat: contentFormat(&article),
+			CreatedAt:     article.CreatedAt,
+			UpdatedAt:     article.UpdatedAt,
+			Version:       article.Version,
+			Status:        article.Status,
+			PublishedAt:   article.PublishedAt,
+			Tags:          mapTags(article.Tags),
+			Categories:    mapCategories(article.Categories),
+			CommentCount:  article.CommentCount,
 			// Note: Author data is omitted here to avoid circular nesting
 		})
 	}
 
-	return response, nil
+	return response
 }
 
-// UpdateAuthor يحدّث بيانات المؤلف
-func (uc *authorUseCase) UpdateAuthor(id uint, req *dto.UpdateAuthorRequest) (*dto.AuthorResponse, error) {
-	author, err := uc.authorRepo.FindByID(id)
-	if err != nil || author == nil {
-		return nil, err
-	}
+// UpdateAuthor يحدّث بيانات المؤلف داخل معاملة تشمل القراءة والحفظ
+// expectedVersion رقم النسخة من ترويسة If-Match (nil عند غيابها)، ولا يُحفظ التعديل إلا إذا طابق النسخة الحالية
+func (uc *authorUseCase) UpdateAuthor(ctx context.Context, id uint, req *dto.UpdateAuthorRequest, expectedVersion *uint) (*dto.AuthorResponse, error) {
+	var author *models.Author
+	err := uc.txManager.WithinTx(ctx, func(repos repository.Repositories) error {
+		var err error
+		author, err = repos.Authors.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := checkVersion(author.Version, expectedVersion); err != nil {
+			return err
+		}
 
-	if req.Name != "" {
-		author.Name = req.Name
-	}
-	if req.Email != "" {
-		author.Email = req.Email
-	}
+		if req.Name != "" && req.Name != author.Name {
+			author.Name = req.Name
+			// المعرف النصي يتبع الاسم، ولا يُحفظ للمؤلفين سجل بمعرفاتهم السابقة
+			if err := assignAuthorSlug(ctx, repos.Authors, author); err != nil {
+				return err
+			}
+		}
+		if req.Email != "" {
+			author.Email = req.Email
+		}
 
-	if err := uc.authorRepo.Update(author); err != nil {
+		return mapVersionConflict(repos.Authors.Update(ctx, author))
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	response := &dto.AuthorResponse{
-		ID:    author.ID,
-		Name:  author.Name,
-		Email: author.Email,
+		ID:      author.ID,
+		Name:    author.Name,
+		Slug:    author.Slug,
+		Email:   author.Email,
+		Version: author.Version,
 	}
 	return response, nil
 }
 
-// DeleteAuthor يحذف المؤلف
-func (uc *authorUseCase) DeleteAuthor(id uint) error {
-	// Optional: Add logic here to check if the author has articles before deleting.
-	return uc.authorRepo.Delete(id)
-}
\ No newline at end of file
+// DeleteAuthor يحذف المؤلف ويطبّق سياسة مقالاته (block أو cascade أو reassign) داخل معاملة واحدة
+// صف المؤلف يُقفل حصريًا أولاً حتى لا تُنشأ له مقالة جديدة بين فحص مقالاته وحذفه
+func (uc *authorUseCase) DeleteAuthor(ctx context.Context, id uint, query *dto.DeleteAuthorQuery) error {
+	policy, targetID, err := parseDeletePolicy(id, query)
+	if err != nil {
+		return err
+	}
+
+	return uc.txManager.WithinTx(ctx, func(repos repository.Repositories) error {
+		if _, err := repos.Authors.FindByIDForUpdate(ctx, id); err != nil {
+			return err
+		}
+
+		switch policy {
+		case OnArticlesBlock:
+			count, err := repos.Articles.CountByAuthor(ctx, id)
+			if err != nil {
+				return err
+			}
+			if count > 0 {
+				return apperr.Conflict("author_has_articles", count)
+			}
+		case OnArticlesCascade:
+			if _, err := repos.Articles.DeleteByAuthor(ctx, id); err != nil {
+				return err
+			}
+		case OnArticlesReassign:
+			_, err := repos.Authors.FindByIDForShare(ctx, targetID)
+			if apperr.Is(err, apperr.KindNotFound) {
+				return apperr.Validation("reassign_target_not_found", targetID)
+			}
+			if err != nil {
+				return err
+			}
+			if _, err := repos.Articles.ReassignAuthor(ctx, id, targetID); err != nil {
+				return err
+			}
+		}
+
+		return repos.Authors.Delete(ctx, id)
+	})
+}
+
+// parseDeletePolicy يتحقق من سياسة الحذف ومعرف المؤلف الهدف
+func parseDeletePolicy(id uint, query *dto.DeleteAuthorQuery) (string, uint, error) {
+	if query == nil || query.OnArticles == "" {
+		return OnArticlesBlock, 0, nil
+	}
+	switch query.OnArticles {
+	case OnArticlesBlock, OnArticlesCascade:
+		return query.OnArticles, 0, nil
+	case OnArticlesReassign:
+		if query.To == 0 {
+			return "", 0, apperr.Validation("reassign_target_required")
+		}
+		if query.To == id {
+			return "", 0, apperr.Validation("reassign_to_self")
+		}
+		return OnArticlesReassign, query.To, nil
+	default:
+		return "", 0, apperr.Validation("invalid_delete_policy", query.OnArticles)
+	}
+}
@@ -0,0 +1,181 @@
+// my-article-app/internal/usecase/category_usecase.go
+package usecase
+
+import (
+	"context"
+	"my-article-app/internal/apperr"
+	"my-article-app/internal/dto"
+	"my-article-app/internal/models"
+	"my-article-app/internal/repository"
+	"my-article-app/internal/textnorm"
+	"strings"
+)
+
+type CategoryUseCase interface {
+	CreateCategory(ctx context.Context, req *dto.CreateCategoryRequest) (*dto.CategoryResponse, error)
+	GetCategoryTree(ctx context.Context) ([]dto.CategoryResponse, error)
+	GetCategoryByID(ctx context.Context, id uint) (*dto.CategoryResponse, error)
+	UpdateCategory(ctx context.Context, id uint, req *dto.UpdateCategoryRequest) (*dto.CategoryResponse, error)
+	DeleteCategory(ctx context.Context, id uint) error
+}
+
+type categoryUseCase struct {
+	categoryRepo repository.CategoryRepository
+	txManager    repository.TxManager // عمليات الكتابة تمر عبر معاملة واحدة تشمل جميع المستودعات
+}
+
+func NewCategoryUseCase(categoryRepo repository.CategoryRepository, txManager repository.TxManager) CategoryUseCase {
+	return &categoryUseCase{categoryRepo: categoryRepo, txManager: txManager}
+}
+
+// newCategoryName يعيد الاسم بعد قص المسافات ومعرفه النصي، والاسم الذي لا يبقى منه حرف أو رقم مرفوض
+func newCategoryName(name string) (string, string, error) {
+	name = strings.TrimSpace(name)
+	slug := textnorm.Slug(name)
+	if slug == "" {
+		return "", "", apperr.Validation("invalid_category_name", name)
+	}
+	return name, slug, nil
+}
+
+// checkParent يتحقق من وجود التصنيف الأب داخل المعاملة الجارية
+// غياب الأب خطأ في مدخلات الطلب وليس في المسار، لذا يُعاد كخطأ تحقق لا 404
+func checkParent(ctx context.Context, repos repository.Repositories, parentID uint) error {
+	_, err := repos.Categories.FindByID(ctx, parentID)
+	if apperr.Is(err, apperr.KindNotFound) {
+		return apperr.Validation("category_parent_not_found", parentID)
+	}
+	return err
+}
+
+// CreateCategory ينشئ تصنيفًا جديدًا في الجذر أو تحت تصنيف أب موجود
+func (uc *categoryUseCase) CreateCategory(ctx context.Context, req *dto.CreateCategoryRequest) (*dto.CategoryResponse, error) {
+	name, slug, err := newCategoryName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	category := &models.Category{Name: name, Slug: slug, ParentID: req.ParentID}
+	err = uc.txManager.WithinTx(ctx, func(repos repository.Repositories) error {
+		if category.ParentID != nil {
+			if err := checkParent(ctx, repos, *category.ParentID); err != nil {
+				return err
+			}
+		}
+		return repos.Categories.Create(ctx, category)
+	})
+	if err != nil {
+		return nil, err
+	}
+	response := mapCategory(category)
+	return &response, nil
+}
+
+// GetCategoryTree يجلب شجرة التصنيفات كاملة: التصنيفات الجذرية وتحت كل منها تصنيفاته الفرعية
+func (uc *categoryUseCase) GetCategoryTree(ctx context.Context) ([]dto.CategoryResponse, error) {
+	categories, err := uc.categoryRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return categoryTree(categories, nil), nil
+}
+
+// GetCategoryByID يجلب تصنيفًا واحدًا مع شجرة تصنيفاته الفرعية
+func (uc *categoryUseCase) GetCategoryByID(ctx context.Context, id uint) (*dto.CategoryResponse, error) {
+	categories, err := uc.categoryRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, category := range categories {
+		if category.ID == id {
+			response := mapCategory(&category)
+			response.Children = categoryTree(categories, &id)
+			return &response, nil
+		}
+	}
+	return nil, apperr.NotFound("category_not_found", id)
+}
+
+// categoryTree يبني من categories (مرتبة حسب المعرف) التصنيفات التي أبوها parentID مع فروعها، و nil للجذر
+func categoryTree(categories []models.Category, parentID *uint) []dto.CategoryResponse {
+	nodes := []dto.CategoryResponse{}
+	for _, category := range categories {
+		if (parentID == nil && category.ParentID == nil) || (parentID != nil && category.ParentID != nil && *category.ParentID == *parentID) {
+			node := mapCategory(&category)
+			if children := categoryTree(categories, &category.ID); len(children) > 0 {
+				node.Children = children
+			}
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+// UpdateCategory يحدّث اسم التصنيف (ومعه معرفه النصي) أو ينقله إلى أب آخر
+// parent_id بقيمة 0 ينقله إلى الجذر، ولا يُقبل أب من فروع التصنيف نفسه حتى لا تصبح الشجرة دائرية
+func (uc *categoryUseCase) UpdateCategory(ctx context.Context, id uint, req *dto.UpdateCategoryRequest) (*dto.CategoryResponse, error) {
+	var category *models.Category
+	err := uc.txManager.WithinTx(ctx, func(repos repository.Repositories) error {
+		var err error
+		if category, err = repos.Categories.FindByID(ctx, id); err != nil {
+			return err
+		}
+		if req.Name != "" {
+			if category.Name, category.Slug, err = newCategoryName(req.Name); err != nil {
+				return err
+			}
+		}
+		if req.ParentID != nil {
+			if err := moveCategory(ctx, repos, category, *req.ParentID); err != nil {
+				return err
+			}
+		}
+		return repos.Categories.Update(ctx, category)
+	})
+	if err != nil {
+		return nil, err
+	}
+	response := mapCategory(category)
+	return &response, nil
+}
+
+// moveCategory يغيّر أب التصنيف بعد التحقق من وجود الأب الجديد وأنه ليس التصنيف نفسه أو أحد فروعه
+func moveCategory(ctx context.Context, repos repository.Repositories, category *models.Category, parentID uint) error {
+	if parentID == 0 {
+		category.ParentID = nil
+		return nil
+	}
+	if err := checkParent(ctx, repos, parentID); err != nil {
+		return err
+	}
+
+	categories, err := repos.Categories.FindAll(ctx)
+	if err != nil {
+		return err
+	}
+	parents := make(map[uint]*uint, len(categories))
+	for _, c := range categories {
+		parents[c.ID] = c.ParentID
+	}
+	// الصعود من الأب الجديد إلى الجذر؛ المرور بالتصنيف نفسه يعني أن الأب من فروعه
+	for ancestor := &parentID; ancestor != nil; ancestor = parents[*ancestor] {
+		if *ancestor == category.ID {
+			return apperr.Validation("category_cycle", parentID)
+		}
+	}
+	category.ParentID = &parentID
+	return nil
+}
+
+// DeleteCategory يحذف التصنيف نهائيًا ويزيله من مقالاته، ولا يُحذف تصنيف له تصنيفات فرعية
+func (uc *categoryUseCase) DeleteCategory(ctx context.Context, id uint) error {
+	return uc.txManager.WithinTx(ctx, func(repos repository.Repositories) error {
+		children, err := repos.Categories.CountChildren(ctx, id)
+		if err != nil {
+			return err
+		}
+		if children > 0 {
+			return apperr.Conflict("category_has_children", children)
+		}
+		return repos.Categories.Delete(ctx, id)
+	})
+}
@@ -0,0 +1,323 @@
+// my-article-app/internal/usecase/comment_usecase.go
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"my-article-app/internal/apperr"
+	"my-article-app/internal/dto"
+	"my-article-app/internal/models"
+	"my-article-app/internal/pagination"
+	"my-article-app/internal/repository"
+	"strings"
+	"time"
+)
+
+// maxCommentDepth أقصى عمق للردود، فالرد على تعليق بهذا العمق مرفوض
+const maxCommentDepth = 5
+
+type CommentUseCase interface {
+	CreateComment(ctx context.Context, articleID uint, req *dto.CreateCommentRequest) (*dto.CommentCreatedResponse, error)
+	GetComments(ctx context.Context, articleID uint, req pagination.Request, includeUnpublished bool) (*dto.PageResponse[dto.CommentResponse], error)
+	UpdateComment(ctx context.Context, articleID, id uint, token string, req *dto.UpdateCommentRequest) (*dto.CommentResponse, error)
+	DeleteComment(ctx context.Context, articleID, id uint, token string, isAdmin bool) error
+	ModerateComment(ctx context.Context, articleID, id uint, status string) (*dto.CommentResponse, error)
+	GetModerationQueue(ctx context.Context, query *dto.CommentQueueQuery, req pagination.Request) (*dto.PageResponse[dto.CommentResponse], error)
+}
+
+type commentUseCase struct {
+	commentRepo repository.CommentRepository
+	articleRepo repository.ArticleRepository
+	txManager   repository.TxManager // عمليات الكتابة تمر عبر معاملة واحدة تشمل جميع المستودعات
+	// editWindow المدة التي يُسمح فيها لكاتب التعليق بتعديله بعد إنشائه، والصفر يمنع التعديل
+	editWindow time.Duration
+}
+
+func NewCommentUseCase(commentRepo repository.CommentRepository, articleRepo repository.ArticleRepository, txManager repository.TxManager, editWindow time.Duration) CommentUseCase {
+	return &commentUseCase{commentRepo: commentRepo, articleRepo: articleRepo, txManager: txManager, editWindow: editWindow}
+}
+
+// newCommentToken يولّد رمز تعديل عشوائيًا ويعيده مع بصمته التي تُحفظ بدلاً منه
+func newCommentToken() (string, string, error) {
+	raw := make([]byte, 24)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", err
+	}
+	token := hex.EncodeToString(raw)
+	return token, hashCommentToken(token), nil
+}
+
+// hashCommentToken يعيد بصمة SHA-256 لرمز التعديل بالنظام الست عشري
+func hashCommentToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// tokenMatches يقارن رمز الطلب ببصمة التعليق بزمن ثابت، والرمز الفارغ لا يطابق أي تعليق
+func tokenMatches(comment *models.Comment, token string) bool {
+	if token == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(hashCommentToken(token)), []byte(comment.EditTokenHash)) == 1
+}
+
+// mapCommentToResponse يحوّل التعليق إلى DTO دون ردوده؛ المحذوف يُعاد دون كاتبه ونصه
+func mapCommentToResponse(comment *models.Comment) dto.CommentResponse {
+	response := dto.CommentResponse{
+		ID:         comment.ID,
+		ArticleID:  comment.ArticleID,
+		ParentID:   comment.ParentID,
+		AuthorName: comment.AuthorName,
+		Body:       comment.Body,
+		Status:     comment.Status,
+		EditedAt:   comment.EditedAt,
+		CreatedAt:  comment.CreatedAt,
+		UpdatedAt:  comment.UpdatedAt,
+	}
+	if comment.DeletedAt.Valid {
+		response.Deleted = true
+		response.AuthorName, response.Body = "", ""
+	}
+	return response
+}
+
+// articleComment يجلب تعليقًا غير محذوف ويتحقق من أنه تابع للمقال في المسار
+func articleComment(ctx context.Context, repos repository.Repositories, articleID, id uint) (*models.Comment, error) {
+	comment, err := repos.Comments.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if comment.ArticleID != articleID {
+		return nil, apperr.NotFound("comment_not_found", id)
+	}
+	return comment, nil
+}
+
+// CreateComment يضيف تعليقًا بانتظار المراجعة على مقال منشور، أو ردًا على تعليق معتمد فيه
+func (uc *commentUseCase) CreateComment(ctx context.Context, articleID uint, req *dto.CreateCommentRequest) (*dto.CommentCreatedResponse, error) {
+	authorName, body := strings.TrimSpace(req.AuthorName), strings.TrimSpace(req.Body)
+	if authorName == "" {
+		return nil, apperr.Validation("invalid_comment_author")
+	}
+	if body == "" {
+		return nil, apperr.Validation("comment_body_empty")
+	}
+	token, tokenHash, err := newCommentToken()
+	if err != nil {
+		return nil, err
+	}
+
+	comment := &models.Comment{
+		ArticleID:     articleID,
+		AuthorName:    authorName,
+		Body:          body,
+		Status:        models.CommentStatusPending,
+		EditTokenHash: tokenHash,
+	}
+	err = uc.txManager.WithinTx(ctx, func(repos repository.Repositories) error {
+		// التعليق على المقال المنشور فقط، حتى للمشرف
+		article, err := repos.Articles.FindByID(ctx, articleID)
+		if err != nil {
+			return err
+		}
+		if article.Status != models.ArticleStatusPublished {
+			return apperr.NotFound("article_not_found", articleID)
+		}
+
+		if req.ParentID != nil {
+			parent, err := repos.Comments.FindByID(ctx, *req.ParentID)
+			// غياب الأب خطأ في مدخلات الطلب وليس في المسار، لذا لا يُعاد كـ 404
+			switch {
+			case apperr.Is(err, apperr.KindNotFound):
+				return apperr.Validation("comment_parent_not_found", *req.ParentID)
+			case err != nil:
+				return err
+			case parent.ArticleID != articleID || parent.Status != models.CommentStatusApproved:
+				return apperr.Validation("comment_parent_not_found", *req.ParentID)
+			case parent.Depth >= maxCommentDepth:
+				return apperr.Validation("comment_too_deep", maxCommentDepth)
+			}
+			threadID := parent.ID
+			if parent.ThreadID != nil {
+				threadID = *parent.ThreadID
+			}
+			comment.ParentID, comment.ThreadID, comment.Depth = &parent.ID, &threadID, parent.Depth+1
+		}
+		return repos.Comments.Create(ctx, comment)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &dto.CommentCreatedResponse{CommentResponse: mapCommentToResponse(comment), EditToken: token}, nil
+}
+
+// commentNode تعليق في شجرة السلسلة مع ردوده المباشرة
+type commentNode struct {
+	comment *models.Comment
+	replies []*commentNode
+}
+
+// response يحوّل العقدة وردودها إلى DTO، ويعيد false للتعليق المحذوف الذي لم يبقَ له رد ظاهر
+func (n *commentNode) response() (dto.CommentResponse, bool) {
+	response := mapCommentToResponse(n.comment)
+	for _, reply := range n.replies {
+		if r, ok := reply.response(); ok {
+			response.Replies = append(response.Replies, r)
+		}
+	}
+	return response, !response.Deleted || len(response.Replies) > 0
+}
+
+// GetComments يجلب صفحة من سلاسل التعليقات المعتمدة للمقال، كل تعليق جذري مع شجرة ردوده
+// الرد الذي لم يُعتمد أبوه لا يظهر حتى يُعتمد
+func (uc *commentUseCase) GetComments(ctx context.Context, articleID uint, req pagination.Request, includeUnpublished bool) (*dto.PageResponse[dto.CommentResponse], error) {
+	article, err := uc.articleRepo.FindByID(ctx, articleID)
+	if err != nil {
+		return nil, err
+	}
+	if !isVisible(article, includeUnpublished) {
+		return nil, apperr.NotFound("article_not_found", articleID)
+	}
+
+	page, err := uc.commentRepo.FindThreads(ctx, articleID, req)
+	if err != nil {
+		return nil, err
+	}
+	nodes := make(map[uint]*commentNode, len(page.Items))
+	threadIDs := make([]uint, 0, len(page.Items))
+	for i := range page.Items {
+		nodes[page.Items[i].ID] = &commentNode{comment: &page.Items[i]}
+		threadIDs = append(threadIDs, page.Items[i].ID)
+	}
+
+	replies, err := uc.commentRepo.FindReplies(ctx, threadIDs)
+	if err != nil {
+		return nil, err
+	}
+	// الردود مرتبة بالمعرف، والأب أقدم من ردوده دائمًا، فيكون في الشجرة قبلها
+	for i := range replies {
+		if parent, ok := nodes[*replies[i].ParentID]; ok {
+			node := &commentNode{comment: &replies[i]}
+			parent.replies = append(parent.replies, node)
+			nodes[replies[i].ID] = node
+		}
+	}
+
+	responses := make([]dto.CommentResponse, 0, len(page.Items))
+	for _, id := range threadIDs {
+		if response, ok := nodes[id].response(); ok {
+			responses = append(responses, response)
+		}
+	}
+	return &dto.PageResponse[dto.CommentResponse]{Data: responses, Meta: mapPageMeta(page)}, nil
+}
+
+// UpdateComment يعدّل نص التعليق برمز تعديله خلال مهلة التعديل، ويعيده إلى طابور المراجعة
+func (uc *commentUseCase) UpdateComment(ctx context.Context, articleID, id uint, token string, req *dto.UpdateCommentRequest) (*dto.CommentResponse, error) {
+	body := strings.TrimSpace(req.Body)
+	if body == "" {
+		return nil, apperr.Validation("comment_body_empty")
+	}
+
+	var comment *models.Comment
+	err := uc.txManager.WithinTx(ctx, func(repos repository.Repositories) error {
+		var err error
+		if comment, err = articleComment(ctx, repos, articleID, id); err != nil {
+			return err
+		}
+		if !tokenMatches(comment, token) {
+			return apperr.Forbidden("comment_token_invalid")
+		}
+		now := time.Now()
+		if now.Sub(comment.CreatedAt) > uc.editWindow {
+			return apperr.Forbidden("comment_edit_window_closed")
+		}
+
+		wasApproved := comment.Status == models.CommentStatusApproved
+		comment.Body = body
+		comment.Status = models.CommentStatusPending
+		comment.EditedAt = &now
+		if err := repos.Comments.Update(ctx, comment); err != nil {
+			return err
+		}
+		if wasApproved {
+			return repos.Comments.RefreshArticleCount(ctx, articleID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	response := mapCommentToResponse(comment)
+	return &response, nil
+}
+
+// DeleteComment يحذف التعليق حذفًا منطقيًا برمز تعديله أو بصلاحية المشرف، دون التقيد بمهلة التعديل
+func (uc *commentUseCase) DeleteComment(ctx context.Context, articleID, id uint, token string, isAdmin bool) error {
+	return uc.txManager.WithinTx(ctx, func(repos repository.Repositories) error {
+		comment, err := articleComment(ctx, repos, articleID, id)
+		if err != nil {
+			return err
+		}
+		if !isAdmin && !tokenMatches(comment, token) {
+			return apperr.Forbidden("comment_token_invalid")
+		}
+		if err := repos.Comments.Delete(ctx, id); err != nil {
+			return err
+		}
+		if comment.Status == models.CommentStatusApproved {
+			return repos.Comments.RefreshArticleCount(ctx, articleID)
+		}
+		return nil
+	})
+}
+
+// ModerateComment يعتمد التعليق أو يرفضه (status) ويسجل المراجع من ترويسة X-Editor
+func (uc *commentUseCase) ModerateComment(ctx context.Context, articleID, id uint, status string) (*dto.CommentResponse, error) {
+	var comment *models.Comment
+	err := uc.txManager.WithinTx(ctx, func(repos repository.Repositories) error {
+		var err error
+		if comment, err = articleComment(ctx, repos, articleID, id); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		comment.Status = status
+		comment.ModeratedAt = &now
+		comment.ModeratedBy = editorFrom(ctx)
+		if err := repos.Comments.Update(ctx, comment); err != nil {
+			return err
+		}
+		return repos.Comments.RefreshArticleCount(ctx, articleID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	response := mapCommentToResponse(comment)
+	return &response, nil
+}
+
+// GetModerationQueue يجلب صفحة من التعليقات بالحالة المطلوبة من كل المقالات، الأقدم أولاً
+func (uc *commentUseCase) GetModerationQueue(ctx context.Context, query *dto.CommentQueueQuery, req pagination.Request) (*dto.PageResponse[dto.CommentResponse], error) {
+	status := strings.TrimSpace(query.Status)
+	switch status {
+	case "":
+		status = models.CommentStatusPending
+	case models.CommentStatusPending, models.CommentStatusApproved, models.CommentStatusRejected:
+	default:
+		return nil, apperr.Validation("invalid_comment_status", status)
+	}
+
+	page, err := uc.commentRepo.FindQueue(ctx, status, req)
+	if err != nil {
+		return nil, err
+	}
+	responses := make([]dto.CommentResponse, 0, len(page.Items))
+	for i := range page.Items {
+		responses = append(responses, mapCommentToResponse(&page.Items[i]))
+	}
+	return &dto.PageResponse[dto.CommentResponse]{Data: responses, Meta: mapPageMeta(page)}, nil
+}
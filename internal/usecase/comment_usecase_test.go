@@ -0,0 +1,289 @@
+// my-article-app/internal/usecase/comment_usecase_test.go
+package usecase
+
+import (
+	"context"
+	"errors"
+	"my-article-app/internal/apperr"
+	"my-article-app/internal/dto"
+	"my-article-app/internal/models"
+	"my-article-app/internal/pagination"
+	"testing"
+	"time"
+)
+
+// commentFixture حالة استخدام التعليقات فوق مخزن البيئة مع مقال منشور للتعليق عليه
+type commentFixture struct {
+	env       *testEnv
+	comments  CommentUseCase
+	articleID uint
+}
+
+// newCommentFixture ينشئ مقالًا منشورًا وحالة استخدام تعليقات بمهلة التعديل editWindow
+func newCommentFixture(t *testing.T, env *testEnv, editWindow time.Duration) *commentFixture {
+	t.Helper()
+	authorID := env.createAuthor(t, "comment-author")
+	article, err := env.articles.CreateArticle(context.Background(), &dto.CreateArticleRequest{Title: "Commented article",
+		Content: "content open for comments", AuthorID: authorID, Status: models.ArticleStatusPublished})
+	if err != nil {
+		t.Fatalf("CreateArticle: %v", err)
+	}
+	return &commentFixture{
+		env:       env,
+		comments:  NewCommentUseCase(env.repos.Comments, env.repos.Articles, env.tx, editWindow),
+		articleID: article.ID,
+	}
+}
+
+// add يضيف تعليقًا (أو ردًا على parentID) ويعيده مع رمز تعديله
+func (f *commentFixture) add(t *testing.T, body string, parentID *uint) *dto.CommentCreatedResponse {
+	t.Helper()
+	comment, err := f.comments.CreateComment(context.Background(), f.articleID, &dto.CreateCommentRequest{AuthorName: "reader", Body: body, ParentID: parentID})
+	if err != nil {
+		t.Fatalf("CreateComment(%q): %v", body, err)
+	}
+	return comment
+}
+
+// approved يضيف تعليقًا ويعتمده
+func (f *commentFixture) approved(t *testing.T, body string, parentID *uint) *dto.CommentCreatedResponse {
+	t.Helper()
+	comment := f.add(t, body, parentID)
+	f.moderate(t, comment.ID, models.CommentStatusApproved)
+	return comment
+}
+
+func (f *commentFixture) moderate(t *testing.T, id uint, status string) {
+	t.Helper()
+	if _, err := f.comments.ModerateComment(context.Background(), f.articleID, id, status); err != nil {
+		t.Fatalf("ModerateComment(%d, %s): %v", id, status, err)
+	}
+}
+
+// threads يعيد سلاسل تعليقات المقال كما يراها القارئ
+func (f *commentFixture) threads(t *testing.T) []dto.CommentResponse {
+	t.Helper()
+	page, err := f.comments.GetComments(context.Background(), f.articleID, pagination.Request{}, false)
+	if err != nil {
+		t.Fatalf("GetComments: %v", err)
+	}
+	return page.Data
+}
+
+// commentCount يعيد عدد التعليقات المحفوظ في المقال
+func (f *commentFixture) commentCount(t *testing.T) int {
+	t.Helper()
+	article, err := f.env.articles.GetArticleByID(context.Background(), f.articleID, nil, true)
+	if err != nil {
+		t.Fatalf("GetArticleByID: %v", err)
+	}
+	return article.CommentCount
+}
+
+// TestCommentReplyDepth يتحقق أن الردود تتداخل حتى العمق الأقصى، وأن الرد لا يكون إلا على تعليق معتمد في المقال نفسه
+func TestCommentReplyDepth(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, env *testEnv) {
+		ctx := context.Background()
+		f := newCommentFixture(t, env, time.Hour)
+
+		parent := f.approved(t, "root comment", nil)
+		for depth := 1; depth <= maxCommentDepth; depth++ {
+			parent = f.approved(t, "reply", &parent.ID)
+		}
+		_, err := f.comments.CreateComment(ctx, f.articleID, &dto.CreateCommentRequest{AuthorName: "reader", Body: "too deep", ParentID: &parent.ID})
+		if !errors.Is(err, apperr.Validation("comment_too_deep")) {
+			t.Errorf("reply at depth %d: err = %v, want comment_too_deep", maxCommentDepth+1, err)
+		}
+
+		threads := f.threads(t)
+		if len(threads) != 1 {
+			t.Fatalf("threads = %d, want 1", len(threads))
+		}
+		depth := 0
+		for node := threads[0]; len(node.Replies) > 0; node = node.Replies[0] {
+			depth++
+		}
+		if depth != maxCommentDepth {
+			t.Errorf("thread depth = %d, want %d", depth, maxCommentDepth)
+		}
+
+		pending := f.add(t, "waiting for review", nil)
+		otherAuthor := env.createAuthor(t, "other-author")
+		other, err := env.articles.CreateArticle(ctx, &dto.CreateArticleRequest{Title: "Other article", Content: "other content",
+			AuthorID: otherAuthor, Status: models.ArticleStatusPublished})
+		if err != nil {
+			t.Fatalf("CreateArticle: %v", err)
+		}
+		missing := uint(999)
+		for name, parentID := range map[string]*uint{"pending parent": &pending.ID, "missing parent": &missing} {
+			_, err := f.comments.CreateComment(ctx, f.articleID, &dto.CreateCommentRequest{AuthorName: "reader", Body: "reply", ParentID: parentID})
+			if !errors.Is(err, apperr.Validation("comment_parent_not_found")) {
+				t.Errorf("%s: err = %v, want comment_parent_not_found", name, err)
+			}
+		}
+		if _, err := f.comments.CreateComment(ctx, other.ID, &dto.CreateCommentRequest{AuthorName: "reader", Body: "reply", ParentID: &threads[0].ID}); !errors.Is(err, apperr.Validation("comment_parent_not_found")) {
+			t.Errorf("reply to another article's comment: err = %v, want comment_parent_not_found", err)
+		}
+
+		draftAuthor := env.createAuthor(t, "draft-author")
+		draft, err := env.articles.CreateArticle(ctx, &dto.CreateArticleRequest{Title: "Draft article", Content: "draft content", AuthorID: draftAuthor})
+		if err != nil {
+			t.Fatalf("CreateArticle: %v", err)
+		}
+		if _, err := f.comments.CreateComment(ctx, draft.ID, &dto.CreateCommentRequest{AuthorName: "reader", Body: "on a draft"}); !apperr.Is(err, apperr.KindNotFound) {
+			t.Errorf("comment on a draft: err = %v, want not found", err)
+		}
+	})
+}
+
+// TestCommentEditTokenAndWindow يتحقق أن التعديل يتطلب رمز التعليق خلال المهلة ويعيده إلى المراجعة،
+// وأن الحذف برمزه لا يتقيد بالمهلة
+func TestCommentEditTokenAndWindow(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, env *testEnv) {
+		ctx := context.Background()
+		f := newCommentFixture(t, env, time.Hour)
+		comment := f.approved(t, "original body", nil)
+		if got := f.commentCount(t); got != 1 {
+			t.Fatalf("comment_count after approval = %d, want 1", got)
+		}
+
+		for _, token := range []string{"", "wrong-token"} {
+			_, err := f.comments.UpdateComment(ctx, f.articleID, comment.ID, token, &dto.UpdateCommentRequest{Body: "hijacked"})
+			if !errors.Is(err, apperr.Forbidden("comment_token_invalid")) {
+				t.Errorf("UpdateComment with token %q: err = %v, want comment_token_invalid", token, err)
+			}
+		}
+		if _, err := f.comments.UpdateComment(ctx, f.articleID+1, comment.ID, comment.EditToken, &dto.UpdateCommentRequest{Body: "elsewhere"}); !apperr.Is(err, apperr.KindNotFound) {
+			t.Errorf("UpdateComment under another article: err = %v, want not found", err)
+		}
+
+		updated, err := f.comments.UpdateComment(ctx, f.articleID, comment.ID, comment.EditToken, &dto.UpdateCommentRequest{Body: "  edited body  "})
+		if err != nil {
+			t.Fatalf("UpdateComment: %v", err)
+		}
+		if updated.Body != "edited body" || updated.Status != models.CommentStatusPending || updated.EditedAt == nil {
+			t.Errorf("edited comment = %+v, want the trimmed body back in review with edited_at", updated)
+		}
+		// التعليق المعدّل يغيب عن القراء حتى يُعتمد من جديد
+		if got := f.commentCount(t); got != 0 {
+			t.Errorf("comment_count after editing an approved comment = %d, want 0", got)
+		}
+		if threads := f.threads(t); len(threads) != 0 {
+			t.Errorf("threads after edit = %+v, want none until re-approved", threads)
+		}
+
+		closed := &commentFixture{env: env, comments: NewCommentUseCase(env.repos.Comments, env.repos.Articles, env.tx, 0), articleID: f.articleID}
+		late := closed.add(t, "posted a while ago", nil)
+		if _, err := closed.comments.UpdateComment(ctx, f.articleID, late.ID, late.EditToken, &dto.UpdateCommentRequest{Body: "too late"}); !errors.Is(err, apperr.Forbidden("comment_edit_window_closed")) {
+			t.Errorf("UpdateComment after the window: err = %v, want comment_edit_window_closed", err)
+		}
+		if err := closed.comments.DeleteComment(ctx, f.articleID, late.ID, "wrong-token", false); !errors.Is(err, apperr.Forbidden("comment_token_invalid")) {
+			t.Errorf("DeleteComment with a wrong token: err = %v, want comment_token_invalid", err)
+		}
+		if err := closed.comments.DeleteComment(ctx, f.articleID, late.ID, late.EditToken, false); err != nil {
+			t.Errorf("DeleteComment with its token after the window: %v", err)
+		}
+		if err := closed.comments.DeleteComment(ctx, f.articleID, late.ID, late.EditToken, false); !apperr.Is(err, apperr.KindNotFound) {
+			t.Errorf("DeleteComment twice: err = %v, want not found", err)
+		}
+	})
+}
+
+// TestCommentModeration يتحقق أن التعليق لا يظهر ولا يُعد إلا بعد اعتماده، وأن الرفض يخفيه وينقله إلى طابور المرفوضات
+func TestCommentModeration(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, env *testEnv) {
+		ctx := context.Background()
+		f := newCommentFixture(t, env, time.Hour)
+		first := f.add(t, "first comment", nil)
+		second := f.add(t, "second comment", nil)
+
+		queue := func(status string) []uint {
+			t.Helper()
+			page, err := f.comments.GetModerationQueue(ctx, &dto.CommentQueueQuery{Status: status}, pagination.Request{})
+			if err != nil {
+				t.Fatalf("GetModerationQueue(%q): %v", status, err)
+			}
+			var ids []uint
+			for _, c := range page.Data {
+				ids = append(ids, c.ID)
+			}
+			return ids
+		}
+		if got := queue(""); len(got) != 2 || got[0] != first.ID || got[1] != second.ID {
+			t.Errorf("pending queue = %v, want [%d %d] oldest first", got, first.ID, second.ID)
+		}
+		if threads := f.threads(t); len(threads) != 0 || f.commentCount(t) != 0 {
+			t.Errorf("pending comments are visible: %+v (count %d)", threads, f.commentCount(t))
+		}
+
+		f.moderate(t, first.ID, models.CommentStatusApproved)
+		f.moderate(t, second.ID, models.CommentStatusApproved)
+		if threads := f.threads(t); len(threads) != 2 || f.commentCount(t) != 2 {
+			t.Errorf("after approval: %d threads, count %d; want 2 and 2", len(threads), f.commentCount(t))
+		}
+
+		f.moderate(t, second.ID, models.CommentStatusRejected)
+		threads := f.threads(t)
+		if len(threads) != 1 || threads[0].ID != first.ID || f.commentCount(t) != 1 {
+			t.Errorf("after rejection: threads %+v, count %d; want only the first comment", threads, f.commentCount(t))
+		}
+		if got := queue(models.CommentStatusRejected); len(got) != 1 || got[0] != second.ID {
+			t.Errorf("rejected queue = %v, want [%d]", got, second.ID)
+		}
+		if got := queue(models.CommentStatusPending); len(got) != 0 {
+			t.Errorf("pending queue after moderation = %v, want empty", got)
+		}
+		if _, err := f.comments.GetModerationQueue(ctx, &dto.CommentQueueQuery{Status: "spam"}, pagination.Request{}); !apperr.Is(err, apperr.KindValidation) {
+			t.Errorf("queue with an unknown status: err = %v, want validation error", err)
+		}
+	})
+}
+
+// TestDeletedCommentKeepsReplies يتحقق أن التعليق المحذوف يبقى مكانًا فارغًا ما دام له رد معتمد ظاهر،
+// ويختفي مع آخر رد، وأن عدد تعليقات المقال يُعاد حسابه بعد كل حذف
+func TestDeletedCommentKeepsReplies(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, env *testEnv) {
+		ctx := context.Background()
+		f := newCommentFixture(t, env, time.Hour)
+		root := f.approved(t, "root with replies", nil)
+		middle := f.approved(t, "middle reply", &root.ID)
+		leaf := f.approved(t, "leaf reply", &middle.ID)
+		lonely := f.approved(t, "root with a pending reply", nil)
+		f.add(t, "pending reply", &lonely.ID)
+		if got := f.commentCount(t); got != 4 {
+			t.Fatalf("comment_count = %d, want 4", got)
+		}
+
+		for _, id := range []uint{root.ID, middle.ID, lonely.ID} {
+			if err := f.comments.DeleteComment(ctx, f.articleID, id, "", true); err != nil {
+				t.Fatalf("DeleteComment(%d): %v", id, err)
+			}
+		}
+		if got := f.commentCount(t); got != 1 {
+			t.Errorf("comment_count after deletions = %d, want 1 (only the leaf)", got)
+		}
+
+		// الجذر والرد الأوسط محذوفان لكنهما يحملان الرد الأخير، والجذر الذي لا رد معتمدًا له يختفي
+		threads := f.threads(t)
+		if len(threads) != 1 || threads[0].ID != root.ID {
+			t.Fatalf("threads = %+v, want only the deleted root holding its replies", threads)
+		}
+		kept := threads[0]
+		if !kept.Deleted || kept.Body != "" || kept.AuthorName != "" {
+			t.Errorf("deleted root = %+v, want it without author and body", kept)
+		}
+		if len(kept.Replies) != 1 || !kept.Replies[0].Deleted || len(kept.Replies[0].Replies) != 1 || kept.Replies[0].Replies[0].Body != "leaf reply" {
+			t.Errorf("replies of the deleted root = %+v, want a deleted middle reply holding the leaf", kept.Replies)
+		}
+
+		if err := f.comments.DeleteComment(ctx, f.articleID, leaf.ID, leaf.EditToken, false); err != nil {
+			t.Fatalf("DeleteComment(leaf): %v", err)
+		}
+		if threads := f.threads(t); len(threads) != 0 {
+			t.Errorf("threads after deleting the last reply = %+v, want none", threads)
+		}
+		if got := f.commentCount(t); got != 0 {
+			t.Errorf("comment_count = %d, want 0", got)
+		}
+	})
+}
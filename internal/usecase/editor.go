@@ -0,0 +1,18 @@
+// my-article-app/internal/usecase/editor.go
+package usecase
+
+import "context"
+
+// editorKey مفتاح اسم المحرر في سياق الطلب
+type editorKey struct{}
+
+// WithEditor يضع اسم المحرر في السياق لتُنسب إليه مراجعات المقالات التي يُنشئها الطلب
+func WithEditor(ctx context.Context, editor string) context.Context {
+	return context.WithValue(ctx, editorKey{}, editor)
+}
+
+// editorFrom يقرأ اسم المحرر من السياق، ويعيد نصًا فارغًا إذا لم يُحدد
+func editorFrom(ctx context.Context) string {
+	editor, _ := ctx.Value(editorKey{}).(string)
+	return editor
+}
@@ -0,0 +1,88 @@
+// my-article-app/internal/usecase/helpers_test.go
+package usecase
+
+import (
+	"context"
+	"my-article-app/internal/config"
+	"my-article-app/internal/database"
+	"my-article-app/internal/dto"
+	"my-article-app/internal/repository"
+	"testing"
+)
+
+// testEnv حالات الاستخدام فوق مخزن واحد مع مستودعاته للتحقق المباشر من محتواه
+type testEnv struct {
+	repos      repository.Repositories
+	tx         repository.TxManager
+	articles   ArticleUseCase
+	authors    AuthorUseCase
+	categories CategoryUseCase
+	tags       TagUseCase
+}
+
+func newTestEnv(repos repository.Repositories, tx repository.TxManager) *testEnv {
+	return &testEnv{
+		repos:      repos,
+		tx:         tx,
+		articles:   NewArticleUseCase(repos.Articles, repos.Authors, repos.Revisions, repos.SlugHistory, tx),
+		authors:    NewAuthorUseCase(repos.Authors, tx),
+		categories: NewCategoryUseCase(repos.Categories, tx),
+		tags:       NewTagUseCase(repos.Tags, tx),
+	}
+}
+
+// newSQLiteEnv يبني حالات الاستخدام فوق قاعدة SQLite في الذاكرة بعد تطبيق الترحيلات
+func newSQLiteEnv(t *testing.T) *testEnv {
+	t.Helper()
+	cfg := config.Default()
+	cfg.Database.Driver = config.DriverSQLite
+	cfg.Database.Path = config.SQLiteMemory
+	cfg.Log.Level = config.LogLevelError
+
+	db, err := database.Open(cfg)
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	t.Cleanup(func() { _ = database.Close(db) })
+	migrator, err := database.NewMigrator(db)
+	if err != nil {
+		t.Fatalf("NewMigrator: %v", err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		t.Fatalf("migrate up: %v", err)
+	}
+	return newTestEnv(repository.NewRepositories(db), repository.NewTxManager(db))
+}
+
+// newMemoryEnv يبني حالات الاستخدام فوق التخزين في الذاكرة
+func newMemoryEnv(t *testing.T) *testEnv {
+	t.Helper()
+	store := repository.NewMemoryStore()
+	return newTestEnv(repository.NewMemoryRepositories(store), repository.NewMemoryTxManager(store))
+}
+
+// testBackends المخزنان اللذان تُشغَّل عليهما الاختبارات المشتركة
+var testBackends = []struct {
+	name string
+	open func(t *testing.T) *testEnv
+}{
+	{"sqlite", newSQLiteEnv},
+	{"memory", newMemoryEnv},
+}
+
+// forEachBackend يشغّل test في اختبار فرعي لكل مخزن ببيئة جديدة
+func forEachBackend(t *testing.T, test func(t *testing.T, env *testEnv)) {
+	for _, backend := range testBackends {
+		t.Run(backend.name, func(t *testing.T) { test(t, backend.open(t)) })
+	}
+}
+
+// createAuthor ينشئ مؤلفًا عبر حالة الاستخدام ويعيد معرفه
+func (e *testEnv) createAuthor(t *testing.T, name string) uint {
+	t.Helper()
+	author, err := e.authors.CreateAuthor(context.Background(), &dto.CreateAuthorRequest{Name: name, Email: name + "@example.com"})
+	if err != nil {
+		t.Fatalf("CreateAuthor(%q): %v", name, err)
+	}
+	return author.ID
+}
@@ -0,0 +1,18 @@
+// my-article-app/internal/usecase/pagination.go
+package usecase
+
+import (
+	"my-article-app/internal/dto"
+	"my-article-app/internal/pagination"
+)
+
+// mapPageMeta يحوّل معلومات صفحة المستودع إلى DTO بيانات الترقيم
+func mapPageMeta[T any](page *pagination.Page[T]) dto.PageMeta {
+	return dto.PageMeta{
+		Total:      page.Total,
+		Limit:      page.Limit,
+		Offset:     page.Offset,
+		HasMore:    page.HasMore,
+		NextCursor: page.NextCursor,
+	}
+}
@@ -0,0 +1,103 @@
+// my-article-app/internal/usecase/slug.go
+package usecase
+
+import (
+	"context"
+	"maps"
+	"my-article-app/internal/models"
+	"my-article-app/internal/repository"
+	"my-article-app/internal/textnorm"
+)
+
+// أساس المعرف النصي للعنوان أو الاسم الذي لا يبقى منه حرف أو رقم (مثل "!!!")
+const (
+	fallbackArticleSlug = "article"
+	fallbackAuthorSlug  = "author"
+)
+
+// slugClaims المعرفات النصية المحجوزة مع أصحابها (0 لسجل لم يُنشأ بعد)،
+// ويُضاف إليها كل معرف يُختار حتى لا يتكرر داخل الطلب نفسه
+type slugClaims map[string]uint
+
+// claim يختار أول مرشح من base (base ثم base-2 ثم base-3...) لا يحجزه صاحب آخر، ويحجزه لـ owner
+// المعرف المحجوز لـ owner نفسه متاح له، فيعود المقال إلى معرفه القديم إذا عاد إلى عنوانه القديم
+func (c slugClaims) claim(base string, owner uint) string {
+	for n := 1; ; n++ {
+		slug := textnorm.SlugCandidate(base, n)
+		if holder, taken := c[slug]; !taken || (owner != 0 && holder == owner) {
+			c[slug] = owner
+			return slug
+		}
+	}
+}
+
+// slugBase يعيد أساس المعرف النصي للنص، أو fallback إذا لم يبقَ منه شيء
+func slugBase(text, fallback string) string {
+	if base := textnorm.Slug(text); base != "" {
+		return base
+	}
+	return fallback
+}
+
+// articleSlugClaims يجمع المعرفات المحجوزة من مرشحي bases: معرفات المقالات الحالية (ومنها المحذوفة) ومعرفاتها السابقة
+func articleSlugClaims(ctx context.Context, repos repository.Repositories, bases []string) (slugClaims, error) {
+	current, err := repos.Articles.TakenSlugs(ctx, bases)
+	if err != nil {
+		return nil, err
+	}
+	previous, err := repos.SlugHistory.TakenSlugs(ctx, bases)
+	if err != nil {
+		return nil, err
+	}
+	maps.Copy(current, previous)
+	return current, nil
+}
+
+// assignArticleSlug يختار معرفًا نصيًا لمقال جديد من عنوانه
+func assignArticleSlug(ctx context.Context, repos repository.Repositories, article *models.Article) error {
+	base := slugBase(article.Title, fallbackArticleSlug)
+	claims, err := articleSlugClaims(ctx, repos, []string{base})
+	if err != nil {
+		return err
+	}
+	article.Slug = claims.claim(base, 0)
+	return nil
+}
+
+// reslugArticle يغيّر معرف المقال النصي بعد تغيير عنوانه، ما لم يكن معرفه الحالي من مرشحي العنوان الجديد
+// المعرف السابق يُحفظ في سجله بعد حفظ المقال (recordSlugChange)
+func reslugArticle(ctx context.Context, repos repository.Repositories, article *models.Article) error {
+	base := slugBase(article.Title, fallbackArticleSlug)
+	if textnorm.SlugFrom(article.Slug, base) {
+		return nil
+	}
+	claims, err := articleSlugClaims(ctx, repos, []string{base})
+	if err != nil {
+		return err
+	}
+	article.Slug = claims.claim(base, article.ID)
+	return nil
+}
+
+// recordSlugChange يحفظ المعرف السابق للمقال إذا تغيّر، فيبقى محجوزًا له ويُعاد توجيهه إلى معرفه الجديد
+func recordSlugChange(ctx context.Context, repos repository.Repositories, article *models.Article, previous string) error {
+	if previous == "" || previous == article.Slug {
+		return nil
+	}
+	return repos.SlugHistory.Record(ctx, article.ID, previous, article.Slug)
+}
+
+// assignAuthorSlug يختار للمؤلف معرفًا نصيًا من اسمه، ويبقي معرفه الحالي إذا كان من مرشحي الاسم
+// المؤلف الجديد يُمرر بمعرف 0
+func assignAuthorSlug(ctx context.Context, authors repository.AuthorRepository, author *models.Author) error {
+	base := slugBase(author.Name, fallbackAuthorSlug)
+	if author.Slug != "" && textnorm.SlugFrom(author.Slug, base) {
+		return nil
+	}
+	claims, err := authors.TakenSlugs(ctx, []string{base})
+	if err != nil {
+		return err
+	}
+	author.Slug = slugClaims(claims).claim(base, author.ID)
+	return nil
+}
@@ -0,0 +1,110 @@
+// my-article-app/internal/usecase/slug_test.go
+package usecase
+
+import (
+	"context"
+	"my-article-app/internal/apperr"
+	"my-article-app/internal/dto"
+	"testing"
+)
+
+// TestArticleSlugHistory يتحقق أن المعرفات السابقة تقود إلى المعرف الحالي بعد أكثر من تغيير للعنوان،
+// وأنها تبقى محجوزة لمقالها فلا يأخذها مقال آخر، ويستعيدها المقال إذا عاد إلى عنوانه القديم
+func TestArticleSlugHistory(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, env *testEnv) {
+		ctx := context.Background()
+		authorID := env.createAuthor(t, "slug-author")
+		created, err := env.articles.CreateArticle(ctx, &dto.CreateArticleRequest{Title: "First title", Content: "content of the article", AuthorID: authorID})
+		if err != nil {
+			t.Fatalf("CreateArticle: %v", err)
+		}
+		rename := func(title, wantSlug string) {
+			t.Helper()
+			updated, err := env.articles.UpdateArticle(ctx, created.ID, &dto.UpdateArticleRequest{Title: title}, nil)
+			if err != nil {
+				t.Fatalf("UpdateArticle(%q): %v", title, err)
+			}
+			if updated.Slug != wantSlug {
+				t.Fatalf("slug after renaming to %q = %q, want %q", title, updated.Slug, wantSlug)
+			}
+		}
+		rename("Second title", "second-title")
+		rename("Third title", "third-title")
+
+		for _, slug := range []string{"first-title", "second-title"} {
+			article, moved, err := env.articles.GetArticleBySlug(ctx, slug, nil, true)
+			if err != nil || !moved || article.ID != created.ID || article.Slug != "third-title" {
+				t.Errorf("GetArticleBySlug(%q) = %+v moved=%v err=%v; want moved to third-title", slug, article, moved, err)
+			}
+		}
+		if article, moved, err := env.articles.GetArticleBySlug(ctx, "third-title", nil, true); err != nil || moved || article.ID != created.ID {
+			t.Errorf("GetArticleBySlug(current) = %+v moved=%v err=%v; want it without a redirect", article, moved, err)
+		}
+		if _, _, err := env.articles.GetArticleBySlug(ctx, "never-used", nil, true); !apperr.Is(err, apperr.KindNotFound) {
+			t.Errorf("GetArticleBySlug(unknown): err = %v, want not found", err)
+		}
+		// المسودة لا تظهر للقراء ولو بمعرفها السابق
+		if _, _, err := env.articles.GetArticleBySlug(ctx, "first-title", nil, false); !apperr.Is(err, apperr.KindNotFound) {
+			t.Errorf("public GetArticleBySlug(old slug of a draft): err = %v, want not found", err)
+		}
+
+		// العنوان القديم حر، لكن معرفه محجوز للمقال الأول فيأخذ المقال الجديد اللاحقة -2
+		other, err := env.articles.CreateArticle(ctx, &dto.CreateArticleRequest{Title: "First title", Content: "another article", AuthorID: authorID})
+		if err != nil {
+			t.Fatalf("CreateArticle: %v", err)
+		}
+		if other.Slug != "first-title-2" {
+			t.Errorf("slug of a new article with a previous title = %q, want first-title-2", other.Slug)
+		}
+
+		rename("Second title", "second-title")
+		if article, moved, err := env.articles.GetArticleBySlug(ctx, "second-title", nil, true); err != nil || moved || article.ID != created.ID {
+			t.Errorf("GetArticleBySlug(reclaimed) = %+v moved=%v err=%v; want it current again", article, moved, err)
+		}
+		if article, moved, err := env.articles.GetArticleBySlug(ctx, "third-title", nil, true); err != nil || !moved || article.Slug != "second-title" {
+			t.Errorf("GetArticleBySlug(third-title) = %+v moved=%v err=%v; want moved to second-title", article, moved, err)
+		}
+	})
+}
+
+// TestAuthorSlugSuffix يتحقق أن المؤلفين بالاسم نفسه يأخذون لواحق متتالية، وأن الاسم العربي يُنقل إلى اللاتينية
+func TestAuthorSlugSuffix(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, env *testEnv) {
+		ctx := context.Background()
+		emails := []string{"one@example.com", "two@example.com", "three@example.com"}
+		want := []string{"ahmd-aly", "ahmd-aly-2", "ahmd-aly-3"}
+		var ids []uint
+		for i, email := range emails {
+			author, err := env.authors.CreateAuthor(ctx, &dto.CreateAuthorRequest{Name: "أحمد علي", Email: email})
+			if err != nil {
+				t.Fatalf("CreateAuthor: %v", err)
+			}
+			if author.Slug != want[i] {
+				t.Errorf("author %d slug = %q, want %q", i+1, author.Slug, want[i])
+			}
+			ids = append(ids, author.ID)
+		}
+
+		// الاسم الذي لا يبقى منه حرف يأخذ المعرف الاحتياطي
+		author, err := env.authors.CreateAuthor(ctx, &dto.CreateAuthorRequest{Name: "!!!", Email: "symbols@example.com"})
+		if err != nil {
+			t.Fatalf("CreateAuthor: %v", err)
+		}
+		if author.Slug != fallbackAuthorSlug {
+			t.Errorf("symbol-only name slug = %q, want %q", author.Slug, fallbackAuthorSlug)
+		}
+
+		// تغيير الاسم إلى اسم مأخوذ يختار أول لاحقة حرة، والإبقاء على الاسم لا يغيّر المعرف
+		updated, err := env.authors.UpdateAuthor(ctx, ids[2], &dto.UpdateAuthorRequest{Name: "Author"}, nil)
+		if err != nil {
+			t.Fatalf("UpdateAuthor: %v", err)
+		}
+		if updated.Slug != "author-2" {
+			t.Errorf("renamed author slug = %q, want author-2", updated.Slug)
+		}
+		updated, err = env.authors.UpdateAuthor(ctx, ids[1], &dto.UpdateAuthorRequest{Email: "changed@example.com"}, nil)
+		if err != nil || updated.Slug != "ahmd-aly-2" {
+			t.Errorf("UpdateAuthor(email only) = %+v, %v; want the slug kept", updated, err)
+		}
+	})
+}
@@ -0,0 +1,128 @@
+// my-article-app/internal/usecase/tag_usecase.go
+package usecase
+
+import (
+	"context"
+	"my-article-app/internal/apperr"
+	"my-article-app/internal/dto"
+	"my-article-app/internal/models"
+	"my-article-app/internal/pagination"
+	"my-article-app/internal/repository"
+	"my-article-app/internal/textnorm"
+	"strings"
+)
+
+type TagUseCase interface {
+	CreateTag(ctx context.Context, req *dto.CreateTagRequest) (*dto.TagResponse, error)
+	GetAllTags(ctx context.Context, query *dto.TagListQuery, req pagination.Request) (*dto.PageResponse[dto.TagResponse], error)
+	GetTagByID(ctx context.Context, id uint) (*dto.TagResponse, error)
+	UpdateTag(ctx context.Context, id uint, req *dto.UpdateTagRequest) (*dto.TagResponse, error)
+	DeleteTag(ctx context.Context, id uint) error
+}
+
+type tagUseCase struct {
+	tagRepo   repository.TagRepository
+	txManager repository.TxManager // عمليات الكتابة تمر عبر معاملة واحدة تشمل جميع المستودعات
+}
+
+func NewTagUseCase(tagRepo repository.TagRepository, txManager repository.TxManager) TagUseCase {
+	return &tagUseCase{tagRepo: tagRepo, txManager: txManager}
+}
+
+// newTagName يعيد الاسم بعد قص المسافات ومعرفه النصي، والاسم الذي لا يبقى منه حرف أو رقم مرفوض
+func newTagName(name string) (string, string, error) {
+	name = strings.TrimSpace(name)
+	slug := textnorm.Slug(name)
+	if slug == "" {
+		return "", "", apperr.Validation("invalid_tag_name", name)
+	}
+	return name, slug, nil
+}
+
+// mapTagToResponse يحوّل الوسم إلى DTO دون عدد المقالات
+func mapTagToResponse(tag *models.Tag) *dto.TagResponse {
+	return &dto.TagResponse{ID: tag.ID, Name: tag.Name, Slug: tag.Slug}
+}
+
+// CreateTag ينشئ وسمًا جديدًا، والوسم الموجود بالمعرف النصي نفسه تعارض
+func (uc *tagUseCase) CreateTag(ctx context.Context, req *dto.CreateTagRequest) (*dto.TagResponse, error) {
+	name, slug, err := newTagName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	tag := &models.Tag{Name: name, Slug: slug}
+	err = uc.txManager.WithinTx(ctx, func(repos repository.Repositories) error {
+		return repos.Tags.Create(ctx, tag)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapTagToResponse(tag), nil
+}
+
+// GetAllTags يجلب صفحة من الوسوم مع عدد المقالات المنشورة لكل وسم، ومع sort=-article_count تصبح سحابة وسوم
+func (uc *tagUseCase) GetAllTags(ctx context.Context, query *dto.TagListQuery, req pagination.Request) (*dto.PageResponse[dto.TagResponse], error) {
+	var filter repository.TagFilter
+	if query != nil {
+		filter.Name = strings.TrimSpace(query.Name)
+
+		var err error
+		if filter.Sort, err = parseSort(query.Sort, repository.IsTagSortField); err != nil {
+			return nil, err
+		}
+	}
+	if req.IsKeyset() && len(filter.Sort) > 0 {
+		return nil, repository.ErrCursorWithSort
+	}
+
+	page, err := uc.tagRepo.FindAll(ctx, filter, req)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.TagResponse, 0, len(page.Items))
+	for _, item := range page.Items {
+		response := mapTagToResponse(&item.Tag)
+		response.ArticleCount = &item.ArticleCount
+		responses = append(responses, *response)
+	}
+	return &dto.PageResponse[dto.TagResponse]{Data: responses, Meta: mapPageMeta(page)}, nil
+}
+
+// GetTagByID يجلب وسمًا واحدًا
+func (uc *tagUseCase) GetTagByID(ctx context.Context, id uint) (*dto.TagResponse, error) {
+	tag, err := uc.tagRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return mapTagToResponse(tag), nil
+}
+
+// UpdateTag يعيد تسمية الوسم ويغيّر معرفه النصي معه، فتتبعه مقالاته دون تعديلها
+func (uc *tagUseCase) UpdateTag(ctx context.Context, id uint, req *dto.UpdateTagRequest) (*dto.TagResponse, error) {
+	name, slug, err := newTagName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	var tag *models.Tag
+	err = uc.txManager.WithinTx(ctx, func(repos repository.Repositories) error {
+		var err error
+		if tag, err = repos.Tags.FindByID(ctx, id); err != nil {
+			return err
+		}
+		tag.Name, tag.Slug = name, slug
+		return repos.Tags.Update(ctx, tag)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapTagToResponse(tag), nil
+}
+
+// DeleteTag يحذف الوسم نهائيًا ويزيله من كل المقالات التي تحمله
+func (uc *tagUseCase) DeleteTag(ctx context.Context, id uint) error {
+	return uc.txManager.WithinTx(ctx, func(repos repository.Repositories) error {
+		return repos.Tags.Delete(ctx, id)
+	})
+}
@@ -0,0 +1,214 @@
+// my-article-app/internal/usecase/taxonomy_test.go
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"my-article-app/internal/apperr"
+	"my-article-app/internal/dto"
+	"my-article-app/internal/models"
+	"my-article-app/internal/pagination"
+	"slices"
+	"testing"
+)
+
+// createCategory ينشئ تصنيفًا تحت parentID (nil للجذر) ويعيد معرفه
+func (e *testEnv) createCategory(t *testing.T, name string, parentID *uint) uint {
+	t.Helper()
+	category, err := e.categories.CreateCategory(context.Background(), &dto.CreateCategoryRequest{Name: name, ParentID: parentID})
+	if err != nil {
+		t.Fatalf("CreateCategory(%q): %v", name, err)
+	}
+	return category.ID
+}
+
+// listTitles يعيد عناوين المقالات المطابقة لـ query كما يراها القارئ، مرتبة أبجديًا
+func (e *testEnv) listTitles(t *testing.T, query *dto.ArticleListQuery) []string {
+	t.Helper()
+	page, err := e.articles.GetAllArticles(context.Background(), query, pagination.Request{}, false)
+	if err != nil {
+		t.Fatalf("GetAllArticles(%+v): %v", query, err)
+	}
+	titles := make([]string, 0, len(page.Data))
+	for _, article := range page.Data {
+		titles = append(titles, article.Title)
+	}
+	slices.Sort(titles)
+	return titles
+}
+
+// TestCategoryFilterIncludesDescendants يتحقق أن التصفية بتصنيف تشمل مقالات كل فروعه مهما كان عمقها،
+// ولا تشمل مقالات التصنيفات الشقيقة أو الآباء
+func TestCategoryFilterIncludesDescendants(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, env *testEnv) {
+		ctx := context.Background()
+		authorID := env.createAuthor(t, "taxonomy-author")
+		programming := env.createCategory(t, "Programming", nil)
+		backend := env.createCategory(t, "Backend", &programming)
+		golang := env.createCategory(t, "Go", &backend)
+		design := env.createCategory(t, "التصميم", nil)
+
+		add := func(title, status string, categoryIDs ...uint) {
+			t.Helper()
+			_, err := env.articles.CreateArticle(ctx, &dto.CreateArticleRequest{Title: title, Content: "content of " + title,
+				AuthorID: authorID, Status: status, CategoryIDs: categoryIDs})
+			if err != nil {
+				t.Fatalf("CreateArticle(%q): %v", title, err)
+			}
+		}
+		add("In programming", models.ArticleStatusPublished, programming)
+		add("In backend", models.ArticleStatusPublished, backend)
+		add("In go", models.ArticleStatusPublished, golang)
+		// المقال في تصنيفين متداخلين يظهر مرة واحدة
+		add("In go and backend", models.ArticleStatusPublished, golang, backend)
+		add("In design", models.ArticleStatusPublished, design)
+		add("Go draft", models.ArticleStatusDraft, golang)
+
+		tests := []struct {
+			category string
+			want     []string
+		}{
+			{"programming", []string{"In backend", "In go", "In go and backend", "In programming"}},
+			{"backend", []string{"In backend", "In go", "In go and backend"}},
+			{"Go", []string{"In go", "In go and backend"}},
+			{"التصميم", []string{"In design"}},
+			{"no-such-category", []string{}},
+		}
+		for _, tt := range tests {
+			if got := env.listTitles(t, &dto.ArticleListQuery{Category: tt.category}); !slices.Equal(got, tt.want) {
+				t.Errorf("category=%s: %q, want %q", tt.category, got, tt.want)
+			}
+		}
+
+		// نقل الفرع يغيّر نتائج التصفية بآبائه الجدد
+		root := uint(0)
+		if _, err := env.categories.UpdateCategory(ctx, backend, &dto.UpdateCategoryRequest{ParentID: &root}); err != nil {
+			t.Fatalf("UpdateCategory: %v", err)
+		}
+		if got, want := env.listTitles(t, &dto.ArticleListQuery{Category: "programming"}), []string{"In programming"}; !slices.Equal(got, want) {
+			t.Errorf("category=programming after moving backend to the root: %q, want %q", got, want)
+		}
+
+		if _, err := env.articles.CreateArticle(ctx, &dto.CreateArticleRequest{Title: "Unknown category", Content: "some content",
+			AuthorID: authorID, CategoryIDs: []uint{999}}); !apperr.Is(err, apperr.KindValidation) {
+			t.Errorf("CreateArticle with an unknown category: err = %v, want validation error", err)
+		}
+	})
+}
+
+// TestCategoryRejectsCycles يتحقق أن التصنيف لا يُنقل تحت نفسه أو تحت أحد فروعه، وأن الشجرة تبقى كما هي بعد الرفض
+func TestCategoryRejectsCycles(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, env *testEnv) {
+		ctx := context.Background()
+		root := env.createCategory(t, "Root", nil)
+		child := env.createCategory(t, "Child", &root)
+		grandchild := env.createCategory(t, "Grandchild", &child)
+
+		for _, parentID := range []uint{root, child, grandchild} {
+			_, err := env.categories.UpdateCategory(ctx, root, &dto.UpdateCategoryRequest{ParentID: &parentID})
+			if !errors.Is(err, apperr.Validation("category_cycle")) {
+				t.Errorf("move root under %d: err = %v, want category_cycle", parentID, err)
+			}
+		}
+		missing := uint(999)
+		if _, err := env.categories.UpdateCategory(ctx, child, &dto.UpdateCategoryRequest{ParentID: &missing}); !errors.Is(err, apperr.Validation("category_parent_not_found")) {
+			t.Errorf("move under a missing parent: err = %v, want category_parent_not_found", err)
+		}
+		if _, err := env.categories.CreateCategory(ctx, &dto.CreateCategoryRequest{Name: "Orphan", ParentID: &missing}); !errors.Is(err, apperr.Validation("category_parent_not_found")) {
+			t.Errorf("create under a missing parent: err = %v, want category_parent_not_found", err)
+		}
+
+		tree, err := env.categories.GetCategoryTree(ctx)
+		if err != nil {
+			t.Fatalf("GetCategoryTree: %v", err)
+		}
+		if len(tree) != 1 || tree[0].ID != root || len(tree[0].Children) != 1 || len(tree[0].Children[0].Children) != 1 {
+			t.Fatalf("tree after refused moves = %+v, want root > child > grandchild", tree)
+		}
+
+		// الحفيد ينتقل إلى الجذر، فيصبح نقل الجذر تحته مقبولًا
+		zero := uint(0)
+		if _, err := env.categories.UpdateCategory(ctx, grandchild, &dto.UpdateCategoryRequest{ParentID: &zero}); err != nil {
+			t.Fatalf("move grandchild to the root: %v", err)
+		}
+		moved, err := env.categories.UpdateCategory(ctx, root, &dto.UpdateCategoryRequest{ParentID: &grandchild})
+		if err != nil || moved.ParentID == nil || *moved.ParentID != grandchild {
+			t.Errorf("move root under the former grandchild = %+v, %v", moved, err)
+		}
+
+		if err := env.categories.DeleteCategory(ctx, grandchild); !apperr.Is(err, apperr.KindConflict) {
+			t.Errorf("DeleteCategory with children: err = %v, want conflict", err)
+		}
+	})
+}
+
+// TestArticleTagsNormalized يتحقق أن أسماء الوسوم تُوحَّد بمعرفها النصي فلا يتكرر الوسم في المقال ولا في الجدول،
+// وأن عدد المقالات لكل وسم يقتصر على المنشورة منها
+func TestArticleTagsNormalized(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, env *testEnv) {
+		ctx := context.Background()
+		authorID := env.createAuthor(t, "tags-author")
+		add := func(title, status string, tags ...string) *dto.ArticleResponse {
+			t.Helper()
+			article, err := env.articles.CreateArticle(ctx, &dto.CreateArticleRequest{Title: title, Content: "content of " + title,
+				AuthorID: authorID, Status: status, Tags: tags})
+			if err != nil {
+				t.Fatalf("CreateArticle(%q): %v", title, err)
+			}
+			return article
+		}
+
+		first := add("First tagged", models.ArticleStatusPublished, "Go", " go ", "GO!", "قواعد البيانات", "قَوَاعِد البيانات")
+		var got []string
+		for _, tag := range first.Tags {
+			got = append(got, tag.Name+"="+tag.Slug)
+		}
+		// يبقى أول اسم أُرسل لكل وسم، والوسوم مرتبة حسب المعرف النصي
+		if want := []string{"Go=go", "قواعد البيانات=qwaad-albyanat"}; !slices.Equal(got, want) {
+			t.Fatalf("tags of the first article = %q, want %q", got, want)
+		}
+
+		second := add("Second tagged", models.ArticleStatusPublished, "go", "Databases")
+		if second.Tags[1].Slug != "go" || second.Tags[1].ID != first.Tags[0].ID || second.Tags[1].Name != "Go" {
+			t.Errorf("reused tag = %+v, want the existing Go tag %+v", second.Tags[1], first.Tags[0])
+		}
+		add("Draft tagged", models.ArticleStatusDraft, "GO", "Databases")
+		trashed := add("Trashed tagged", models.ArticleStatusPublished, "Go")
+		if err := env.articles.DeleteArticle(ctx, trashed.ID); err != nil {
+			t.Fatalf("DeleteArticle: %v", err)
+		}
+
+		if _, err := env.articles.CreateArticle(ctx, &dto.CreateArticleRequest{Title: "Bad tag", Content: "some content",
+			AuthorID: authorID, Tags: []string{"fine", "!!!"}}); !errors.Is(err, apperr.Validation("invalid_tag_name")) {
+			t.Errorf("CreateArticle with a symbol-only tag: err = %v, want invalid_tag_name", err)
+		}
+		if _, err := env.tags.CreateTag(ctx, &dto.CreateTagRequest{Name: "go"}); !apperr.Is(err, apperr.KindConflict) {
+			t.Errorf("CreateTag with an existing slug: err = %v, want conflict", err)
+		}
+
+		page, err := env.tags.GetAllTags(ctx, &dto.TagListQuery{Sort: "-article_count,name"}, pagination.Request{})
+		if err != nil {
+			t.Fatalf("GetAllTags: %v", err)
+		}
+		var counts []string
+		for _, tag := range page.Data {
+			counts = append(counts, fmt.Sprintf("%s:%d", tag.Slug, *tag.ArticleCount))
+		}
+		if want := []string{"go:2", "databases:1", "qwaad-albyanat:1"}; !slices.Equal(counts, want) {
+			t.Errorf("tag counts = %q, want %q (the fine tag of the refused article is not created)", counts, want)
+		}
+
+		if got, want := env.listTitles(t, &dto.ArticleListQuery{Tag: "GO"}), []string{"First tagged", "Second tagged"}; !slices.Equal(got, want) {
+			t.Errorf("tag=GO: %q, want %q", got, want)
+		}
+
+		// إرسال [] يزيل وسوم المقال فينقص عددها
+		if _, err := env.articles.UpdateArticle(ctx, second.ID, &dto.UpdateArticleRequest{Tags: []string{}}, nil); err != nil {
+			t.Fatalf("UpdateArticle: %v", err)
+		}
+		if got, want := env.listTitles(t, &dto.ArticleListQuery{Tag: "go"}), []string{"First tagged"}; !slices.Equal(got, want) {
+			t.Errorf("tag=go after removing the second article's tags: %q, want %q", got, want)
+		}
+	})
+}
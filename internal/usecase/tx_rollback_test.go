@@ -0,0 +1,151 @@
+// my-article-app/internal/usecase/tx_rollback_test.go
+package usecase
+
+import (
+	"context"
+	"errors"
+	"my-article-app/internal/dto"
+	"my-article-app/internal/models"
+	"my-article-app/internal/pagination"
+	"my-article-app/internal/repository"
+	"testing"
+)
+
+var errInjected = errors.New("injected failure")
+
+// failingTxManager يمرر المعاملة إلى المدير الحقيقي بعد أن يستبدل بعض مستودعاتها بنسخ تفشل
+type failingTxManager struct {
+	inner repository.TxManager
+	wrap  func(repos *repository.Repositories)
+}
+
+func (m *failingTxManager) WithinTx(ctx context.Context, fn func(repos repository.Repositories) error) error {
+	return m.inner.WithinTx(ctx, func(repos repository.Repositories) error {
+		m.wrap(&repos)
+		return fn(repos)
+	})
+}
+
+// failingRevisions يفشل عند إلحاق المراجعة، آخر خطوة في إنشاء المقال، ويحفظ معرف المقال المُدرج
+type failingRevisions struct {
+	repository.RevisionRepository
+	articleID uint
+	panics    bool
+}
+
+func (r *failingRevisions) Append(_ context.Context, revision *models.ArticleRevision) error {
+	r.articleID = revision.ArticleID
+	if r.panics {
+		panic(errInjected)
+	}
+	return errInjected
+}
+
+// failingTags ينشئ الوسوم ثم يفشل عند ربطها بالمقال
+type failingTags struct {
+	repository.TagRepository
+}
+
+func (r *failingTags) ReplaceArticleTags(context.Context, uint, []uint) error {
+	return errInjected
+}
+
+func TestCreateArticleRollsBack(t *testing.T) {
+	for _, backend := range testBackends {
+		t.Run(backend.name, func(t *testing.T) {
+			t.Run("revision write fails", func(t *testing.T) {
+				revisions := &failingRevisions{}
+				env := backend.open(t)
+				err := createWithFailure(t, env, func(repos *repository.Repositories) {
+					revisions.RevisionRepository = repos.Revisions
+					repos.Revisions = revisions
+				})
+				if !errors.Is(err, errInjected) {
+					t.Fatalf("CreateArticle: err = %v, want injected failure", err)
+				}
+				if revisions.articleID == 0 {
+					t.Fatal("article was not inserted before the revision write")
+				}
+				assertNoArticleRows(t, env, revisions.articleID)
+			})
+
+			t.Run("tag write fails", func(t *testing.T) {
+				env := backend.open(t)
+				err := createWithFailure(t, env, func(repos *repository.Repositories) {
+					repos.Tags = &failingTags{TagRepository: repos.Tags}
+				})
+				if !errors.Is(err, errInjected) {
+					t.Fatalf("CreateArticle: err = %v, want injected failure", err)
+				}
+				assertNoArticleRows(t, env, 0)
+			})
+
+			t.Run("panic", func(t *testing.T) {
+				revisions := &failingRevisions{panics: true}
+				env := backend.open(t)
+				func() {
+					defer func() {
+						if r := recover(); r != errInjected {
+							t.Fatalf("recovered %v, want injected panic", r)
+						}
+					}()
+					_ = createWithFailure(t, env, func(repos *repository.Repositories) {
+						revisions.RevisionRepository = repos.Revisions
+						repos.Revisions = revisions
+					})
+				}()
+				assertNoArticleRows(t, env, revisions.articleID)
+			})
+		})
+	}
+}
+
+// createWithFailure ينشئ مؤلفًا ثم يحاول إنشاء مقال بوسوم عبر حالة استخدام معاملاتها تفشل بحسب wrap
+func createWithFailure(t *testing.T, env *testEnv, wrap func(repos *repository.Repositories)) error {
+	t.Helper()
+	authorID := env.createAuthor(t, "rollback author")
+	articles := NewArticleUseCase(env.repos.Articles, env.repos.Authors, env.repos.Revisions, env.repos.SlugHistory,
+		&failingTxManager{inner: env.tx, wrap: wrap})
+	_, err := articles.CreateArticle(context.Background(), &dto.CreateArticleRequest{
+		Title:    "Rolled back article",
+		Content:  "content that must not survive",
+		AuthorID: authorID,
+		Tags:     []string{"go", "transactions"},
+	})
+	return err
+}
+
+// assertNoArticleRows يتحقق أن المقال ومراجعاته ووسومه لم يبق منها شيء بعد التراجع
+func assertNoArticleRows(t *testing.T, env *testEnv, articleID uint) {
+	t.Helper()
+	ctx := context.Background()
+
+	articles, err := env.repos.Articles.FindAll(ctx, repository.ArticleFilter{}, pagination.Request{})
+	if err != nil {
+		t.Fatalf("Articles.FindAll: %v", err)
+	}
+	if articles.Total != 0 {
+		t.Errorf("%d article rows remain after rollback", articles.Total)
+	}
+	if exists, err := env.repos.Articles.ExistsByTitle(ctx, "Rolled back article", 0); err != nil || exists {
+		t.Errorf("ExistsByTitle after rollback = %v, %v; want false", exists, err)
+	}
+
+	if articleID != 0 {
+		revisions, err := env.repos.Revisions.FindByArticle(ctx, articleID, pagination.Request{})
+		if err != nil {
+			t.Fatalf("Revisions.FindByArticle: %v", err)
+		}
+		if revisions.Total != 0 {
+			t.Errorf("%d revision rows remain after rollback", revisions.Total)
+		}
+	}
+
+	tags, err := env.repos.Tags.FindAll(ctx, repository.TagFilter{}, pagination.Request{})
+	if err != nil {
+		t.Fatalf("Tags.FindAll: %v", err)
+	}
+	if tags.Total != 0 {
+		t.Errorf("%d tag rows remain after rollback", tags.Total)
+	}
+}
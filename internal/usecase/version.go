@@ -0,0 +1,27 @@
+// my-article-app/internal/usecase/version.go
+package usecase
+
+import (
+	"errors"
+	"my-article-app/internal/apperr"
+	"my-article-app/internal/repository"
+)
+
+// ErrPreconditionFailed يُرجع عندما يعدّل طلب آخر السجل بين قراءته وحفظه
+var ErrPreconditionFailed = apperr.PreconditionFailed("concurrent_modification")
+
+// checkVersion يقارن النسخة الحالية بالنسخة المتوقعة؛ القيمة nil تعني عدم اشتراط نسخة
+func checkVersion(current uint, expected *uint) error {
+	if expected != nil && *expected != current {
+		return apperr.PreconditionFailed("version_mismatch", current, *expected)
+	}
+	return nil
+}
+
+// mapVersionConflict يحوّل تعارض التحديث المشروط في المستودع إلى ErrPreconditionFailed
+func mapVersionConflict(err error) error {
+	if errors.Is(err, repository.ErrVersionConflict) {
+		return apperr.Wrap(apperr.KindPreconditionFailed, err, ErrPreconditionFailed.Code)
+	}
+	return err
+}
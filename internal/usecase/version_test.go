@@ -0,0 +1,100 @@
+// my-article-app/internal/usecase/version_test.go
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"my-article-app/internal/apperr"
+	"my-article-app/internal/dto"
+	"my-article-app/internal/repository"
+	"sync"
+	"testing"
+)
+
+func TestCheckVersion(t *testing.T) {
+	v := func(n uint) *uint { return &n }
+	tests := []struct {
+		name     string
+		current  uint
+		expected *uint
+		wantErr  bool
+	}{
+		{"no precondition", 3, nil, false},
+		{"matching", 3, v(3), false},
+		{"stale", 3, v(2), true},
+		{"ahead", 3, v(4), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkVersion(tt.current, tt.expected)
+			if tt.wantErr != apperr.Is(err, apperr.KindPreconditionFailed) || (!tt.wantErr && err != nil) {
+				t.Errorf("checkVersion(%d, %v) = %v, want error %v", tt.current, tt.expected, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMapVersionConflict(t *testing.T) {
+	wrapped := fmt.Errorf("update: %w", repository.ErrVersionConflict)
+	if err := mapVersionConflict(wrapped); !apperr.Is(err, apperr.KindPreconditionFailed) || !errors.Is(err, ErrPreconditionFailed) {
+		t.Errorf("mapVersionConflict(version conflict) = %v, want concurrent_modification", err)
+	}
+	other := apperr.NotFound("article_not_found")
+	if err := mapVersionConflict(other); err != other {
+		t.Errorf("mapVersionConflict(other) = %v, want it unchanged", err)
+	}
+	if err := mapVersionConflict(nil); err != nil {
+		t.Errorf("mapVersionConflict(nil) = %v", err)
+	}
+}
+
+// TestConcurrentUpdateArticleSameIfMatch طلبان بالترويسة If-Match نفسها في اللحظة نفسها: ينجح واحد فقط
+func TestConcurrentUpdateArticleSameIfMatch(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, env *testEnv) {
+		ctx := context.Background()
+		authorID := env.createAuthor(t, "race author")
+		created, err := env.articles.CreateArticle(ctx, &dto.CreateArticleRequest{Title: "Raced article", Content: "original content", AuthorID: authorID})
+		if err != nil {
+			t.Fatalf("CreateArticle: %v", err)
+		}
+
+		const writers = 4
+		var (
+			wg    sync.WaitGroup
+			start = make(chan struct{})
+			errs  = make([]error, writers)
+		)
+		for i := range writers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				version := created.Version
+				<-start
+				_, errs[i] = env.articles.UpdateArticle(ctx, created.ID, &dto.UpdateArticleRequest{Content: fmt.Sprintf("content from writer %d", i)}, &version)
+			}()
+		}
+		close(start)
+		wg.Wait()
+
+		succeeded := 0
+		for i, err := range errs {
+			switch {
+			case err == nil:
+				succeeded++
+			case !apperr.Is(err, apperr.KindPreconditionFailed):
+				t.Errorf("writer %d: err = %v, want precondition failed", i, err)
+			}
+		}
+		if succeeded != 1 {
+			t.Fatalf("%d writers succeeded, want exactly 1", succeeded)
+		}
+		current, err := env.articles.GetArticleByID(ctx, created.ID, nil, true)
+		if err != nil {
+			t.Fatalf("GetArticleByID: %v", err)
+		}
+		if current.Version != created.Version+1 {
+			t.Errorf("version after race = %d, want %d", current.Version, created.Version+1)
+		}
+	})
+}
@@ -0,0 +1,68 @@
+// my-article-app/internal/worker/group.go
+package worker
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// Group يدير دورة حياة المهام الخلفية (مثل المجدولات ومهام التنظيف)
+// بحيث يمكن إيقافها جميعًا والانتظار حتى تنتهي أثناء إغلاق التطبيق
+type Group struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewGroup ينشئ مجموعة مهام جديدة مشتقة من السياق المعطى
+func NewGroup(parent context.Context) *Group {
+	ctx, cancel := context.WithCancel(parent)
+	return &Group{ctx: ctx, cancel: cancel}
+}
+
+// Go يشغّل مهمة خلفية باسم معين، ويجب على المهمة أن تعود عند إلغاء السياق
+func (g *Group) Go(name string, fn func(ctx context.Context)) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		log.Printf("بدء المهمة الخلفية %q", name)
+		fn(g.ctx)
+		log.Printf("توقفت المهمة الخلفية %q", name)
+	}()
+}
+
+// Stop يلغي سياق جميع المهام وينتظر انتهاءها أو انتهاء السياق المعطى
+func (g *Group) Stop(ctx context.Context) error {
+	g.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		g.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Every يحوّل دالة دورية إلى مهمة تُمرر إلى Go: تعمل فورًا ثم كل interval حتى إلغاء السياق
+func Every(interval time.Duration, fn func(ctx context.Context)) func(ctx context.Context) {
+	return func(ctx context.Context) {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			fn(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}
+}